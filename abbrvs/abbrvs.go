@@ -2,6 +2,7 @@ package abbrvs
 
 const (
 	RKM_FARAD    = 'F'
+	RKM_HENRY    = 'H'
 	RKM_RESISTOR = 'R'
 )
 
@@ -47,6 +48,8 @@ const (
 var CURRENT = []string{"i", "I", "a", "A"}
 var FARAD = []string{"f", "F"}
 var FREQUENCY = []string{"Hz"}
+var HENRY = []string{"h", "H"}
 var POWER = []string{"p", "P", "w", "W"}
 var RESISTOR = []string{"r", "R"}
+var TIME = []string{"s"}
 var VOLTAGE = []string{"v", "V"}
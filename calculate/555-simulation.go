@@ -0,0 +1,164 @@
+package calculate
+
+import (
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/solver"
+	"gohm/utils"
+	"math"
+	"strings"
+)
+
+const (
+	// resistance of the discharge transistor of the 555 when it is off & on
+	ne555_discharge_off = 1e12
+	ne555_discharge_on  = 1e-3
+	// the 555 is simulated for 3 cycles after the first charge of the capacitor from 0V
+	ne555_simulated_cycles = 3
+	// steps per the shorter of the low & high time & of the whole simulation at most
+	ne555_steps     = 1000
+	ne555_max_steps = 20000
+)
+
+// ne555_simulation is the simulated waveform of an astable 555 & the time low & high of its last full cycle
+type ne555_simulation struct {
+	times     []float64
+	capacitor []float64
+	output    []float64
+	time_low  float64
+	time_high float64
+}
+
+// simulate_555_astable simulates an astable 555 with a transient - r1 from the supply to discharge, r2 from discharge
+// to threshold & trigger and the capacitor from threshold & trigger to ground. The discharge transistor is a resistor
+// switched by the comparators at 1/3 & 2/3 of the supply & the output is the supply or 0V
+func simulate_555_astable(r1 float64, r2 float64, capacitance float64, supply float64) *ne555_simulation {
+	discharge := &solver.Element{Name: "RDIS", Kind: 'R', Nodes: []string{"dis", "0"}, Value: ne555_discharge_off}
+	n, err := solver.NewNetlist("555 astable", []*solver.Element{
+		{Name: "VCC", Kind: 'V', Nodes: []string{"vcc", "0"}, Value: supply},
+		{Name: "R1", Kind: 'R', Nodes: []string{"vcc", "dis"}, Value: r1},
+		{Name: "R2", Kind: 'R', Nodes: []string{"dis", "thr"}, Value: r2},
+		{Name: "C1", Kind: 'C', Nodes: []string{"thr", "0"}, Value: capacitance},
+		discharge,
+	})
+	if err != nil {
+		panic(err)
+	}
+
+	tl, th, _ := get_555_astable_times(r1, r2, capacitance)
+	stop := 1.1*(r1+r2)*capacitance + ne555_simulated_cycles*(tl+th)
+	step := max(min(tl, th)/ne555_steps, stop/ne555_max_steps)
+
+	// the capacitor starts discharged, as at power on
+	tr, err := solver.NewTransient(n, step, solver.METHOD_TRAPEZOIDAL, true)
+	if err != nil {
+		panic(err)
+	}
+
+	sim := &ne555_simulation{}
+	high := true
+	// the last crossings of 1/3 (going high) & 2/3 (going low) of the supply
+	rose, fell := math.NaN(), math.NaN()
+
+	for tr.Time < stop {
+		v := tr.Point().Voltages["thr"]
+		sim.times = append(sim.times, tr.Time)
+		sim.capacitor = append(sim.capacitor, v)
+		sim.output = append(sim.output, utils.If(high, supply, 0))
+
+		next := tr.Clone()
+		if err := next.Step(); err != nil {
+			panic(err)
+		}
+		v_next := next.Point().Voltages["thr"]
+
+		threshold := utils.If(high, supply*2/3, supply/3)
+		if (high && v_next < threshold) || (!high && v_next > threshold) {
+			tr = next
+			continue
+		}
+
+		// the comparator switches within the step - the transient steps to the crossing instead, so the discharge
+		// transistor switches at the threshold rather than at the end of the step
+		if err := tr.StepBy(step * (threshold - v) / (v_next - v)); err != nil {
+			panic(err)
+		}
+		if high {
+			fell = tr.Time
+			if !math.IsNaN(rose) {
+				sim.time_high = fell - rose
+			}
+			discharge.Value = ne555_discharge_on
+		} else {
+			rose = tr.Time
+			sim.time_low = rose - fell
+			discharge.Value = ne555_discharge_off
+		}
+		high = !high
+		tr.Discontinuity()
+	}
+
+	return sim
+}
+
+func cmd_555_handler_simulate(format string, resistances []string, capacitance float64, supply float64) string {
+	r1 := utils.GetValueForRKMElseShorthand(resistances[0], abbrvs.RKM_RESISTOR, abbrvs.RESISTOR)
+	r2 := utils.GetValueForRKMElseShorthand(resistances[1], abbrvs.RKM_RESISTOR, abbrvs.RESISTOR)
+	if supply <= 0 {
+		panic("invalid: supply voltage - expected above 0V")
+	}
+
+	tl, th, f := get_555_astable_times(r1, r2, capacitance)
+	sim := simulate_555_astable(r1, r2, capacitance, supply)
+	simulated_f := 1 / (sim.time_low + sim.time_high)
+
+	var sb strings.Builder
+
+	switch format {
+	case "json":
+		fmt.Fprintf(&sb, `{"timeLow":%s,"timeLowAbbreviated":"%ss","timeHigh":%s,"timeHighAbbreviated":"%ss","frequency":%s,"frequencyAbbreviated":"%sHz","simulatedTimeLow":%s,"simulatedTimeLowAbbreviated":"%ss","simulatedTimeHigh":%s,"simulatedTimeHighAbbreviated":"%ss","simulatedFrequency":%s,"simulatedFrequencyAbbreviated":"%sHz"}`,
+			utils.FormatFloat(tl),
+			utils.GetAbbreviatedValue(tl),
+			utils.FormatFloat(th),
+			utils.GetAbbreviatedValue(th),
+			utils.FormatFloat(f),
+			utils.GetAbbreviatedValue(f),
+			utils.FormatFloat(sim.time_low),
+			utils.GetAbbreviatedValue(sim.time_low),
+			utils.FormatFloat(sim.time_high),
+			utils.GetAbbreviatedValue(sim.time_high),
+			utils.FormatFloat(simulated_f),
+			utils.GetAbbreviatedValue(simulated_f),
+		)
+	case "csv":
+		sb.WriteString("time,capacitor,output")
+		for i := range sim.times {
+			fmt.Fprintf(&sb, "\n%s,%s,%s", utils.FormatFloat(sim.times[i]), utils.FormatFloat(sim.capacitor[i]), utils.FormatFloat(sim.output[i]))
+		}
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		fmt.Fprintf(&sb, "time_low=%ss time_high=%ss frequency=%sHz simulated_time_low=%ss simulated_time_high=%ss simulated_frequency=%sHz",
+			value(tl),
+			value(th),
+			value(f),
+			value(sim.time_low),
+			value(sim.time_high),
+			value(simulated_f),
+		)
+
+		if format == "plot" {
+			sb.WriteString("\n\n")
+			sb.WriteString(utils.Plot(
+				sim.times,
+				utils.PlotAxis{Label: "time", Unit: abbrvs.TIME[0]},
+				utils.PlotAxis{Label: "voltage", Unit: abbrvs.VOLTAGE[1]},
+				[]utils.PlotSeries{{Label: "capacitor", Values: sim.capacitor}, {Label: "output", Values: sim.output}},
+				plot_width,
+				plot_height,
+			))
+		}
+	}
+
+	return sb.String()
+}
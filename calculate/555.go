@@ -14,10 +14,17 @@ func cmd_555_handler(cmd *cli.Command) string {
 	len_in_resistances := len(in_resistances)
 	capacitance := utils.GetValueForRKMElseShorthand(cmd.GetFlagValue("capacitance"), abbrvs.RKM_FARAD, abbrvs.FARAD)
 
-	if len_in_resistances == 2 {
-		return cmd_555_handler_astable(format, in_resistances, capacitance)
-	} else if len_in_resistances > 2 {
+	if len_in_resistances > 2 {
 		panic("too many arguments: -resistance")
+	} else if cmd.IsFlagSet("simulate") && len_in_resistances != 2 {
+		panic("unsupported: -simulate of a monostable circuit - specify 2 resistors for an astable circuit")
+	} else if cmd.IsFlagSet("simulate") {
+		supply := utils.ParseShorthand(cmd.GetFlagValue("voltage"), abbrvs.VOLTAGE)
+		return cmd_555_handler_simulate(format, in_resistances, capacitance, supply)
+	} else if format == "csv" || format == "plot" {
+		panic(fmt.Errorf("unsupported: -format %s without -simulate", format))
+	} else if len_in_resistances == 2 {
+		return cmd_555_handler_astable(format, in_resistances, capacitance)
 	}
 
 	return cmd_555_handler_monostable(format, in_resistances[0], capacitance)
@@ -41,9 +48,7 @@ func cmd_555_handler_astable(format string, resistances []string, capacitance fl
 	r1 := utils.GetValueForRKMElseShorthand(resistances[0], abbrvs.RKM_RESISTOR, abbrvs.RESISTOR)
 	r2 := utils.GetValueForRKMElseShorthand(resistances[1], abbrvs.RKM_RESISTOR, abbrvs.RESISTOR)
 
-	th := 0.693 * (r1 + r2) * capacitance
-	tl := 0.693 * r2 * capacitance
-	f := 1.44 / ((r1 + 2*r2) * capacitance)
+	tl, th, f := get_555_astable_times(r1, r2, capacitance)
 
 	switch format {
 	case "json":
@@ -61,3 +66,11 @@ func cmd_555_handler_astable(format string, resistances []string, capacitance fl
 		return fmt.Sprintf("time_low=%ss time_high=%ss frequency=%sHz", utils.GetAbbreviatedValue(tl), utils.GetAbbreviatedValue(th), utils.GetAbbreviatedValue(f))
 	}
 }
+
+// get_555_astable_times returns the textbook approximations of an astable 555 - 0.693 for ln(2) & 1.44 for 1/ln(2)
+func get_555_astable_times(r1 float64, r2 float64, capacitance float64) (float64, float64, float64) {
+	th := 0.693 * (r1 + r2) * capacitance
+	tl := 0.693 * r2 * capacitance
+	f := 1.44 / ((r1 + 2*r2) * capacitance)
+	return tl, th, f
+}
@@ -0,0 +1,171 @@
+package calculate
+
+import (
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/cli"
+	"gohm/solver"
+	"gohm/utils"
+	"math"
+	"math/cmplx"
+	"strings"
+)
+
+const (
+	plot_width  = 64
+	plot_height = 12
+)
+
+// topologies are the built-in filters - the components of each from the input to the output node out & the output
+// across the last of them
+var topologies = map[string][]byte{
+	"rc-lowpass":   {'R', 'C'},
+	"rc-highpass":  {'C', 'R'},
+	"rl-lowpass":   {'L', 'R'},
+	"rl-highpass":  {'R', 'L'},
+	"rlc-lowpass":  {'R', 'L', 'C'},
+	"rlc-highpass": {'R', 'C', 'L'},
+	"rlc-bandpass": {'L', 'C', 'R'},
+	"rlc-bandstop": {'R', 'L', 'C'},
+}
+
+func cmd_ac_handler(cmd *cli.Command) string {
+	if cmd.ArgsLength > 0 {
+		panic("too many arguments: [args...]")
+	}
+
+	topology := cmd.GetFlagValue("topology")
+	source := &solver.Element{Name: "V1", Kind: 'V', Nodes: []string{"in", "0"}, AC: 1}
+
+	n := get_topology_netlist(topology, get_topology_values(cmd, topology), source)
+	return format_ac_sweep(n, "out", solve_ac_sweep(n, cmd.GetFlagValue("sweep")), cmd.GetFlagValue("format"))
+}
+
+// get_topology_values returns the value of every component of a built-in filter from the -resistance, -capacitance &
+// -inductance flags
+func get_topology_values(cmd *cli.Command, topology string) map[byte]float64 {
+	kinds, ok := topologies[topology]
+	if !ok {
+		panic(fmt.Errorf("invalid or unsupported: topology %s", topology))
+	}
+
+	values := map[byte]float64{}
+	for _, kind := range kinds {
+		flag := map[byte]string{'R': "resistance", 'C': "capacitance", 'L': "inductance"}[kind]
+		if !cmd.IsFlagSet(flag) {
+			panic(fmt.Errorf("invalid: topology %s requires -%s", topology, flag))
+		}
+		c := component_kinds[map[byte]string{'R': "resistor", 'C': "capacitor", 'L': "inductor"}[kind]]
+		values[kind] = utils.GetValueForRKMElseShorthand(cmd.GetFlagValue(flag), c.rkm_target, c.targets)
+	}
+	return values
+}
+
+// get_topology_netlist builds the netlist of a built-in filter driven by the source from in to ground - the output
+// node is out
+func get_topology_netlist(topology string, values map[byte]float64, source *solver.Element) *solver.Netlist {
+	kinds := topologies[topology]
+	elements := []*solver.Element{source}
+
+	// the series elements up to the output node, then the elements from the output node to ground
+	from := "in"
+	split := len(kinds) - 1
+	if topology == "rlc-bandstop" {
+		split = 1
+	}
+	for i, kind := range kinds {
+		to := fmt.Sprintf("n%d", i+1)
+		switch {
+		case i == split-1:
+			to = "out"
+		case i == len(kinds)-1:
+			to = "0"
+		}
+		elements = append(elements, &solver.Element{Name: string(kind) + "1", Kind: kind, Nodes: []string{from, to}, Value: values[kind]})
+		from = to
+	}
+
+	n, err := solver.NewNetlist(topology, elements)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// solve_ac_sweep solves the netlist at every frequency of a sweep, i.e. dec 10 10 1Meg
+func solve_ac_sweep(n *solver.Netlist, sweep string) []solver.ACPoint {
+	frequencies, err := solver.ParseSweep(strings.Fields(sweep))
+	if err != nil {
+		panic(err)
+	}
+
+	points, err := solver.SolveAC(n, frequencies)
+	if err != nil {
+		panic(err)
+	}
+	return points
+}
+
+// format_ac_sweep returns the magnitude in dB (re 1V) & the phase in degrees of a node at every frequency of a sweep
+func format_ac_sweep(n *solver.Netlist, node string, points []solver.ACPoint, format string) string {
+	if _, ok := points[0].Voltages[node]; !ok {
+		panic(fmt.Errorf("invalid: node %s not in netlist %s", node, n.Title))
+	}
+
+	frequencies := make([]float64, len(points))
+	magnitudes := make([]float64, len(points))
+	phases := make([]float64, len(points))
+	for i, p := range points {
+		frequencies[i] = p.Frequency
+		magnitudes[i] = 20 * math.Log10(cmplx.Abs(p.Voltages[node]))
+		phases[i] = cmplx.Phase(p.Voltages[node]) * 180 / math.Pi
+	}
+
+	var sb strings.Builder
+
+	switch format {
+	case "json":
+		fmt.Fprintf(&sb, `{"analysis":"ac","title":%s,"node":%s,"points":[`, format_json_string(n.Title), format_json_string(node))
+		for i := range points {
+			fmt.Fprintf(&sb, `{"frequency":%s,"frequencyAbbreviated":"%sHz","magnitude":%s,"phase":%s}`,
+				utils.FormatFloat(frequencies[i]),
+				utils.GetAbbreviatedValue(frequencies[i]),
+				format_json_float(magnitudes[i]),
+				format_json_float(phases[i]),
+			)
+			if i != len(points)-1 {
+				sb.WriteRune(',')
+			}
+		}
+		sb.WriteString("]}")
+	case "csv":
+		sb.WriteString("frequency,magnitude,phase")
+		for i := range points {
+			fmt.Fprintf(&sb, "\n%s,%s,%s", utils.FormatFloat(frequencies[i]), utils.FormatFloat(magnitudes[i]), utils.FormatFloat(phases[i]))
+		}
+	case "plot":
+		frequency := utils.PlotAxis{Label: "frequency", Unit: abbrvs.FREQUENCY[0], Log: true}
+		sb.WriteString(utils.Plot(frequencies, frequency, utils.PlotAxis{Label: "V(" + node + ")", Unit: "dB", Plain: true}, []utils.PlotSeries{{Label: node, Values: magnitudes}}, plot_width, plot_height))
+		sb.WriteString("\n\n")
+		sb.WriteString(utils.Plot(frequencies, frequency, utils.PlotAxis{Label: "phase", Unit: "°", Plain: true}, []utils.PlotSeries{{Label: node, Values: phases}}, plot_width, plot_height))
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		for i := range points {
+			if i > 0 {
+				sb.WriteRune('\n')
+			}
+			fmt.Fprintf(&sb, "frequency=%sHz magnitude=%sdB phase=%s°", value(frequencies[i]), utils.FormatFloat(magnitudes[i]), utils.FormatFloat(phases[i]))
+		}
+	}
+
+	return sb.String()
+}
+
+// format_json_float formats a float as a json number - null for the -Inf dB of a node at 0V
+func format_json_float(f float64) string {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return "null"
+	}
+	return utils.FormatFloat(f)
+}
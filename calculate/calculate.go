@@ -12,11 +12,19 @@ func GetCommand() *cli.Command {
 	}
 
 	cmd.AddSubcommand(get_command_555())
+	cmd.AddSubcommand(get_command_ac())
 	cmd.AddSubcommand(get_command_capacitance())
 	cmd.AddSubcommand(get_command_current_divider())
+	cmd.AddSubcommand(get_command_impedance())
+	cmd.AddSubcommand(get_command_inductance())
+	cmd.AddSubcommand(get_command_match())
+	cmd.AddSubcommand(get_command_missing())
 	cmd.AddSubcommand(get_command_missing_resistance())
+	cmd.AddSubcommand(get_command_netlist())
 	cmd.AddSubcommand(get_command_ohmslaw())
 	cmd.AddSubcommand(get_command_resistance())
+	cmd.AddSubcommand(get_command_thevenin())
+	cmd.AddSubcommand(get_command_transient())
 	cmd.AddSubcommand(get_command_voltage_divider())
 
 	return cmd
@@ -37,6 +45,11 @@ func get_command_555() *cli.Command {
 				Description: "astable circuit example - providing 2 resistors",
 				Output:      "time_low=693μs time_high=1.386ms frequency=480Hz",
 			},
+			{
+				Command:     "gohm calculate 555 -capacitance 1μF -resistance 1k -resistance 1k -simulate",
+				Description: "astable circuit simulated with a transient - -format plot for the capacitor charge & discharge waveform",
+				Output:      "time_low=693μs time_high=1.386ms frequency=480Hz simulated_time_low=693.14963399μs simulated_time_high=1.3862945395800002ms simulated_frequency=480.8977383043638Hz",
+			},
 		},
 	}
 	cmd.AddFlag(&cli.Flag{
@@ -52,11 +65,79 @@ func get_command_555() *cli.Command {
 		IsMulti:     true,
 		Required:    true,
 	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "simulate",
+		Description: "Simulate the astable circuit with a transient & compare the simulated times with the 0.693 approximations",
+		IsBoolean:   true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "voltage",
+		Aliases:     []string{"v"},
+		Description: "Supply voltage of the simulation - shorthand supported",
+		Default:     "9V",
+	})
 	cmd.AddFlag(&cli.Flag{
 		Name:           "format",
-		Description:    "Output format",
+		Description:    "Output format - csv & plot for the capacitor & output waveform with -simulate",
 		Default:        "abbr",
-		PossibleValues: []string{"abbr", "raw", "json"},
+		PossibleValues: []string{"abbr", "raw", "json", "csv", "plot"},
+	})
+	return cmd
+}
+
+func get_command_ac() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "ac",
+		Aliases:     []string{"bode"},
+		Description: "Sweep the AC response of a built-in filter - magnitude (dB) & phase (°) of the output node at every frequency",
+		Handler:     cmd_ac_handler,
+		Examples: []cli.Example{
+			{
+				Command: "gohm calculate ac -topology rc-lowpass -resistance 1k -capacitance 159n -sweep \"dec 1 10 100k\"",
+				Output: `frequency=10Hz magnitude=-0.00043342766190516686dB phase=-0.5723809582723236°
+      frequency=100Hz magnitude=-0.04313005472805208dB phase=-5.705070360215296°
+      frequency=1kHz magnitude=-3.006071943492703dB phase=-44.972096663210095°
+      frequency=10kHz magnitude=-20.03483743605426dB phase=-84.28387880997566°
+      frequency=100kHz magnitude=-39.9919749730944dB phase=-89.42650301950549°`,
+			},
+			{
+				Command:     "gohm calculate ac -topology rlc-bandpass -resistance 100 -capacitance 100n -inductance 10m -format plot",
+				Description: "ASCII Bode plot",
+			},
+		},
+	}
+	cmd.AddFlag(&cli.Flag{
+		Name:           "topology",
+		Aliases:        []string{"t"},
+		Description:    "Filter - the output is across the component after the dash, i.e. the capacitor of rc-lowpass, & a series LC for rlc-bandstop",
+		PossibleValues: []string{"rc-lowpass", "rc-highpass", "rl-lowpass", "rl-highpass", "rlc-lowpass", "rlc-highpass", "rlc-bandpass", "rlc-bandstop"},
+		Required:       true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "resistance",
+		Aliases:     []string{"r"},
+		Description: "RKM & shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "capacitance",
+		Aliases:     []string{"c"},
+		Description: "RKM & shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "inductance",
+		Aliases:     []string{"l"},
+		Description: "RKM & shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "sweep",
+		Description: "Frequencies of the sweep as in a SPICE .ac - dec|oct|lin points fstart fstop",
+		Default:     "dec 10 1 1Meg",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format - csv & plot for the ASCII Bode plot",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json", "csv", "plot"},
 	})
 	return cmd
 }
@@ -64,7 +145,7 @@ func get_command_555() *cli.Command {
 func get_command_capacitance() *cli.Command {
 	cmd := &cli.Command{
 		Name:        "capacitance",
-		Description: "Calculate total capacitance of capacitors - values are n args passed in - args supports RKM & shorthand",
+		Description: "Calculate total capacitance of capacitors - values are n args passed in or a series-parallel network expression - args supports RKM & shorthand",
 		Handler:     cmd_capacitance_handler,
 		Examples: []cli.Example{
 			{
@@ -75,6 +156,16 @@ func get_command_capacitance() *cli.Command {
 				Command: "gohm calculate capacitance 10μF 22μF 18μF -circuit parallel",
 				Output:  "50μF",
 			},
+			{
+				Command:     `gohm calculate capacitance "(10n + 10n) || 4n7" -explain`,
+				Description: "series-parallel network expression - + in series, || in parallel",
+				Output: `capacitance=9.700000000000001nF
+      parallel=9.700000000000001nF
+      ├─ series=5nF
+      │  ├─ 10nF
+      │  └─ 10nF
+      └─ 4.7nF`,
+			},
 		},
 	}
 	cmd.AddFlag(&cli.Flag{
@@ -83,6 +174,11 @@ func get_command_capacitance() *cli.Command {
 		Default:        "series",
 		PossibleValues: []string{"series", "parallel"},
 	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "explain",
+		IsBoolean:   true,
+		Description: "Print the tree of a network expression with the value of every series & parallel combination",
+	})
 	cmd.AddFlag(&cli.Flag{
 		Name:           "format",
 		Description:    "Output format",
@@ -134,6 +230,250 @@ func get_command_current_divider() *cli.Command {
 	return cmd
 }
 
+func get_command_impedance() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "impedance",
+		Aliases:     []string{"reactance"},
+		Description: "Calculate the reactance & complex impedance of series or parallel components at a frequency - the components are the args passed in or the -resistance, -inductance & -capacitance flags",
+		Handler:     cmd_impedance_handler,
+		Examples: []cli.Example{
+			{
+				Command: "gohm calculate impedance 1k 100nF -frequency 1k",
+				Output:  "frequency=1kHz xc=1.5915494309189535kΩ impedance=1k-1.5915494309189535kjΩ magnitude=1.8796354942005231kΩ phase=-57.85809236465795° q=1.5915494309189535",
+			},
+			{
+				Command:     "gohm calculate impedance 1k 10mH 100nF -circuit parallel -frequency 5k",
+				Description: "parallel RLC tank close to its resonance - an F suffix for a capacitor, an H suffix for an inductor",
+				Output:      "frequency=5kHz xc=318.30988618379064Ω xl=314.1592653589793Ω impedance=998.2801974929858+41.434825767111185jΩ magnitude=999.1397287131493Ω phase=2.376766312474928° q=0.04150620824811294",
+			},
+			{
+				Command:     "gohm calculate impedance -inductance 10mH -resistance 10 -sweep \"dec 1 100 10k\"",
+				Description: "inductor & its winding resistance across a sweep - frequencies as in a SPICE .ac",
+				Output: `frequency=100Hz xl=6.283185307179586Ω impedance=10+6.283185307179586jΩ magnitude=11.810098120013969Ω phase=32.14190763534206° q=0.6283185307179586
+      frequency=1kHz xl=62.83185307179586Ω impedance=10+62.83185307179586jΩ magnitude=63.62265131567329Ω phase=80.95693892096232° q=6.283185307179586
+      frequency=10kHz xl=628.3185307179587Ω impedance=10+628.3185307179587jΩ magnitude=628.3981031508406Ω phase=89.08818633038616° q=62.83185307179586`,
+			},
+		},
+	}
+	cmd.AddFlag(&cli.Flag{
+		Name:        "capacitance",
+		Aliases:     []string{"c"},
+		Description: "RKM & shorthand supported",
+		IsMulti:     true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "circuit",
+		Description:    "Type of circuit",
+		Default:        "series",
+		PossibleValues: []string{"series", "parallel"},
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "frequency",
+		Aliases:     []string{"f"},
+		Description: "Frequency - shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "inductance",
+		Aliases:     []string{"l"},
+		Description: "RKM & shorthand supported",
+		IsMulti:     true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "resistance",
+		Aliases:     []string{"r"},
+		Description: "RKM & shorthand supported",
+		IsMulti:     true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "sweep",
+		Description: "Frequencies of a sweep as in a SPICE .ac - dec|oct|lin points fstart fstop",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json"},
+	})
+	return cmd
+}
+
+func get_command_inductance() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "inductance",
+		Description: "Calculate total inductance of inductors - values are n args passed in or a series-parallel network expression - args supports RKM & shorthand",
+		Handler:     cmd_inductance_handler,
+		Examples: []cli.Example{
+			{
+				Command: "gohm calculate inductance 4μ7H 10μH",
+				Output:  "inductance=14.7μH",
+			},
+			{
+				Command: "gohm calculate inductance 10μH 10μH -circuit parallel",
+				Output:  "inductance=5μH",
+			},
+			{
+				Command:     "gohm calculate inductance 10μH 10μH -coupling 0.5",
+				Description: "coupled inductors in series aiding - L1 + L2 + 2M",
+				Output:      "inductance=30μH",
+			},
+			{
+				Command:     "gohm calculate inductance 10μH 22μH 4R7 -circuit parallel -coupling 1:2=0.8 -coupling 2:3=-0.1",
+				Description: "coupling between the 1st & 2nd and, in opposition, the 2nd & 3rd inductor",
+				Output:      "inductance=9.568338334166418μH",
+			},
+			{
+				Command:     `gohm calculate inductance "10μH || 10μH + 4μ7H" -explain`,
+				Description: "series-parallel network expression - || binds tighter than +",
+				Output: `inductance=9.7μH
+      series=9.7μH
+      ├─ parallel=5μH
+      │  ├─ 10μH
+      │  └─ 10μH
+      └─ 4.7μH`,
+			},
+		},
+	}
+	cmd.AddFlag(&cli.Flag{
+		Name:           "circuit",
+		Description:    "Type of circuit",
+		Default:        "series",
+		PossibleValues: []string{"series", "parallel"},
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "coupling",
+		Aliases:     []string{"k"},
+		Description: "Coupling coefficient (-1 to 1) between 2 inductors - i:j=k between the i-th & j-th inductor or k alone for 2 inductors - negative couples in opposition, i.e. -coupling=-0.5 - can be specified multiple times",
+		IsMulti:     true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "explain",
+		IsBoolean:   true,
+		Description: "Print the tree of a network expression with the value of every series & parallel combination",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json"},
+	})
+	return cmd
+}
+
+func get_command_match() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "match",
+		Description: "Bin measured parts into tolerance classes & find matched sets with the smallest spread",
+		Handler:     cmd_match_handler,
+		Examples: []cli.Example{
+			{
+				Command:     "gohm calculate match -input measured.csv -tolerance 0.1% -set 2 -nominal 10k",
+				Description: "pairs - the tightest set comes first",
+				Output: `nominal=10kΩ parts=6
+      bin=±0.01% count=1 parts=R1
+      bin=±0.05% count=2 parts=R2,R5
+      bin=±0.1% count=2 parts=R3,R6
+      bin=±0.25% count=1 parts=R4
+      set=1 parts=R6,R3 values=10.008kΩ,10.0095kΩ mean=10.00875kΩ spread=0.015%
+      set=2 parts=R1,R5 values=10kΩ,10.004kΩ mean=10.002kΩ spread=0.04%
+      unmatched=R2,R4`,
+			},
+			{
+				Command:     "gohm calculate match -input log.csv -tolerance 0.05% -set 4",
+				Description: "quads for a bridge out of a csv logged by gohm measure",
+			},
+		},
+	}
+	cmd.AddFlag(&cli.Flag{
+		Name:        "input",
+		Aliases:     []string{"i"},
+		Description: "CSV or list of measured values, 1 part per line - a header selects the value & label/id/part columns - RKM & shorthand supported",
+		Required:    true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "tolerance",
+		Aliases:     []string{"t"},
+		Description: "Largest spread (%) of the values in a matched set relative to their mean",
+		Default:     "0.1%",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "set",
+		Description: "Number of parts in a matched set, i.e. 2 for pairs or 4 for quads",
+		Default:     "2",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "nominal",
+		Description: "Nominal value the tolerance classes are binned around - the median of the values when omitted - RKM & shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "component",
+		Description:    "Type of the measured parts",
+		Default:        "resistor",
+		PossibleValues: []string{"resistor", "capacitor"},
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json"},
+	})
+	return cmd
+}
+
+func get_command_missing() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "missing",
+		Description: "Calculate the component value needed to complete a series or parallel circuit to a target - components are n args passed in - RKM & shorthand supported",
+		Handler:     cmd_missing_handler,
+		Examples: []cli.Example{
+			{
+				Command:     "gohm calculate missing -target 10k -series E96 9K1",
+				Description: "series trim resistor",
+				Output:      "resistance=909Ω exact=900Ω series=E96 total=10.009kΩ error=0.09%",
+			},
+			{
+				Command:     "gohm calculate missing -component capacitor -circuit parallel -target 150p -series E12 100p",
+				Description: "parallel padding capacitor",
+				Output:      "capacitance=47pF exact=49.99999999999999pF series=E12 total=147pF error=-2%",
+			},
+			{
+				Command:     "gohm calculate missing -circuit parallel -target 150 -series E24 250",
+				Description: "the parallel resistor of missing-resistance, snapped to E24",
+				Output:      "resistance=390Ω exact=374.99999999999994Ω series=E24 total=152.34375Ω error=1.5625%",
+			},
+		},
+	}
+	cmd.AddFlag(&cli.Flag{
+		Name:           "component",
+		Description:    "Type of the components",
+		Default:        "resistor",
+		PossibleValues: []string{"resistor", "capacitor", "inductor"},
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "circuit",
+		Description:    "Type of circuit",
+		Default:        "series",
+		PossibleValues: []string{"series", "parallel"},
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "target",
+		Aliases:     []string{"t"},
+		Description: "Desired total/target value - RKM & shorthand supported",
+		Required:    true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "series",
+		Description:    "E series to snap the missing value to - the error reports how far the total is off the target",
+		PossibleValues: []string{"E6", "E12", "E24", "E48", "E96", "E192"},
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json"},
+	})
+	return cmd
+}
+
 func get_command_missing_resistance() *cli.Command {
 	cmd := &cli.Command{
 		Name:        "missing-resistance",
@@ -165,6 +505,60 @@ func get_command_missing_resistance() *cli.Command {
 	return cmd
 }
 
+func get_command_netlist() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "netlist",
+		Description: "Solve a SPICE netlist with modified nodal analysis - the netlist file is the arg passed in",
+		Handler:     cmd_netlist_handler,
+		Examples: []cli.Example{
+			{
+				Command:     "gohm calculate netlist divider.cir",
+				Description: "DC operating point (.op) of a divider - V1 in 0 10, R1 in out 1k & R2 out 0 4k",
+				Output: `node=in voltage=10V
+      node=out voltage=8V
+      element=V1 voltage=10V current=-2mA power=-20mW
+      element=R1 voltage=2V current=2mA power=4mW
+      element=R2 voltage=8V current=2mA power=16mW`,
+			},
+			{
+				Command:     "gohm calculate netlist -node out rc.cir",
+				Description: "AC sweep (.ac) of an RC low-pass - V1 in 0 AC 1, R1 in out 1k, C1 out 0 159n & .ac dec 1 100 10k",
+				Output: `frequency=100Hz magnitude=-0.04313005472805208dB phase=-5.705070360215296°
+      frequency=1kHz magnitude=-3.006071943492703dB phase=-44.972096663210095°
+      frequency=10kHz magnitude=-20.03483743605426dB phase=-84.28387880997566°`,
+			},
+			{
+				Command:     "gohm calculate netlist -node out rcstep.cir",
+				Description: "transient (.tran) of an RC step response - V1 in 0 PULSE(0 1 0), R1 in out 1k, C1 out 0 1u & .tran 500u 2m",
+				Output: `time=0s out=0V
+      time=500.00000000000006μs out=393.5057180739055mV
+      time=1ms out=632.1664160789213mV
+      time=1.5ms out=776.9120839350102mV
+      time=2ms out=864.6990909212406mV`,
+			},
+		},
+	}
+	cmd.AddFlag(&cli.Flag{
+		Name:        "node",
+		Aliases:     []string{"n"},
+		Description: "Output node of the .ac analysis - required with .ac - & the only node of the .tran analysis",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "method",
+		Aliases:        []string{"m"},
+		Description:    "Integration method of the .tran analysis - euler is backward Euler",
+		Default:        "trapezoidal",
+		PossibleValues: []string{"trapezoidal", "euler"},
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format - csv & plot for the ASCII Bode plot of the .ac & the ASCII plot of the .tran analysis",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json", "csv", "plot"},
+	})
+	return cmd
+}
+
 func get_command_ohmslaw() *cli.Command {
 	cmd := &cli.Command{
 		Name:        "ohmslaw",
@@ -185,6 +579,11 @@ func get_command_ohmslaw() *cli.Command {
 				Description: "series total resistance 40Ω",
 				Output:      "voltage=5V current=125mA resistance=40Ω power=625mW",
 			},
+			{
+				Command:     "gohm calculate ohmslaw -voltage 10V -resistance 1k -package 0402",
+				Description: "warns when the power exceeds the typical rating of the package",
+				Output:      `voltage=10V current=10mA resistance=1kΩ power=100mW package=0402 power_rating=62.5mW voltage_rating=50V warning="power exceeds the 62.5mW rating of 0402"`,
+			},
 		},
 	}
 	cmd.AddFlag(&cli.Flag{
@@ -192,6 +591,10 @@ func get_command_ohmslaw() *cli.Command {
 		Aliases:     []string{"c", "i"},
 		Description: "Current value (I) - shorthand supported",
 	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "package",
+		Description: "Package of the resistor, i.e. 0402 or 1206 - warns when the power or voltage exceeds its typical rating",
+	})
 	cmd.AddFlag(&cli.Flag{
 		Name:        "power",
 		Aliases:     []string{"p"},
@@ -220,7 +623,7 @@ func get_command_ohmslaw() *cli.Command {
 func get_command_resistance() *cli.Command {
 	cmd := &cli.Command{
 		Name:        "resistance",
-		Description: "Calculate total resistance of resistors - values are n args passed in - args supports RKM & shorthand",
+		Description: "Calculate total resistance of resistors - values are n args passed in or a series-parallel network expression - args supports RKM & shorthand",
 		Handler:     cmd_resistance_handler,
 		Examples: []cli.Example{
 			{
@@ -231,6 +634,19 @@ func get_command_resistance() *cli.Command {
 				Command: "gohm calculate resistance 5k 2.5k 5k -circuit parallel",
 				Output:  "resistance=1.25kΩ",
 			},
+			{
+				Command:     `gohm calculate resistance "(1k + 2k2) || 4k7 || (10k + 10k)" -explain`,
+				Description: "series-parallel network expression - + in series, || in parallel",
+				Output: `resistance=1.7383263985205732kΩ
+      parallel=1.7383263985205732kΩ
+      ├─ series=3.2kΩ
+      │  ├─ 1kΩ
+      │  └─ 2.2kΩ
+      ├─ 4.7kΩ
+      └─ series=20kΩ
+         ├─ 10kΩ
+         └─ 10kΩ`,
+			},
 		},
 	}
 	cmd.AddFlag(&cli.Flag{
@@ -239,6 +655,11 @@ func get_command_resistance() *cli.Command {
 		Default:        "series",
 		PossibleValues: []string{"series", "parallel"},
 	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "explain",
+		IsBoolean:   true,
+		Description: "Print the tree of a network expression with the value of every series & parallel combination",
+	})
 	cmd.AddFlag(&cli.Flag{
 		Name:           "format",
 		Description:    "Output format",
@@ -248,17 +669,174 @@ func get_command_resistance() *cli.Command {
 	return cmd
 }
 
+func get_command_thevenin() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "thevenin",
+		Aliases:     []string{"norton"},
+		Description: "Calculate the Thevenin & Norton equivalent of a netlist at a port & the load of maximum power transfer - the netlist file is the arg passed in",
+		Handler:     cmd_thevenin_handler,
+		Examples: []cli.Example{
+			{
+				Command:     "gohm calculate thevenin -port out divider.cir",
+				Description: "divider loaded at its output - V1 in 0 10, R1 in out 1k & R2 out 0 4k",
+				Output:      "vth=8V rth=800Ω in=10mA rn=800Ω load=800Ω power=20mW",
+			},
+			{
+				Command:     "gohm calculate thevenin -port out -frequency 1k rc.cir",
+				Description: "complex Zth of an RC low-pass - V1 in 0 AC 1, R1 in out 1k & C1 out 0 159n",
+				Output:      "frequency=1kHz vth=707.4510619274477mV vth_phase=-44.972096663210095° zth=500.48700502227337-499.999762826052jΩ zth_magnitude=707.4510619274477Ω zth_phase=-44.972096663210095° in=1mA in_phase=0.0000000000000031805546814635168° load=500.48700502227337+499.999762826052jΩ power=125.00000000000001μW",
+			},
+		},
+	}
+	cmd.AddFlag(&cli.Flag{
+		Name:        "port",
+		Aliases:     []string{"p"},
+		Description: "Node of the port - when specified 2 times - the port is from the first to the second node, else to ground",
+		IsMulti:     true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "frequency",
+		Aliases:     []string{"f"},
+		Description: "Frequency of a complex Zth driven by the AC value of the sources - shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json"},
+	})
+	return cmd
+}
+
+func get_command_transient() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "transient",
+		Aliases:     []string{"tran"},
+		Description: "Simulate the response of a built-in filter over time to a step, pulse or sine source - the voltage of the input & output node at every step",
+		Handler:     cmd_transient_handler,
+		Examples: []cli.Example{
+			{
+				Command:     "gohm calculate transient -topology rc-lowpass -resistance 1k -capacitance 1μ -stop 2m -step 500μ",
+				Description: "step response of a 1ms time constant",
+				Output: `time=0s in=0V out=0V
+      time=500.00000000000006μs in=1V out=393.5057180739055mV
+      time=1ms in=1V out=632.1664160789213mV
+      time=1.5ms in=1V out=776.9120839350102mV
+      time=2ms in=1V out=864.6990909212406mV`,
+			},
+			{
+				Command:     "gohm calculate transient -topology rlc-lowpass -resistance 20 -capacitance 1μ -inductance 10m -stop 3m -format plot",
+				Description: "ringing of an underdamped RLC as an ASCII plot",
+			},
+		},
+	}
+	cmd.AddFlag(&cli.Flag{
+		Name:           "topology",
+		Aliases:        []string{"t"},
+		Description:    "Filter - the output is across the component after the dash, i.e. the capacitor of rc-lowpass, & a series LC for rlc-bandstop",
+		PossibleValues: []string{"rc-lowpass", "rc-highpass", "rl-lowpass", "rl-highpass", "rlc-lowpass", "rlc-highpass", "rlc-bandpass", "rlc-bandstop"},
+		Required:       true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "resistance",
+		Aliases:     []string{"r"},
+		Description: "RKM & shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "capacitance",
+		Aliases:     []string{"c"},
+		Description: "RKM & shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "inductance",
+		Aliases:     []string{"l"},
+		Description: "RKM & shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "source",
+		Aliases:        []string{"s"},
+		Description:    "Waveform of the input - a step at 0, a square wave with a 50% duty cycle or a sine",
+		Default:        "step",
+		PossibleValues: []string{"step", "pulse", "sine"},
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "voltage",
+		Aliases:     []string{"v"},
+		Description: "Amplitude of the source - shorthand supported",
+		Default:     "1V",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "frequency",
+		Aliases:     []string{"f"},
+		Description: "Frequency of the pulse & sine source - shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "stop",
+		Description: "Time to simulate - shorthand supported",
+		Required:    true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "step",
+		Description: "Time between the points - 1/500 of the stop time by default - shorthand supported. The simulation steps at most 1/50 of the stop time & lands on every point",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "method",
+		Aliases:        []string{"m"},
+		Description:    "Integration method - euler is backward Euler",
+		Default:        "trapezoidal",
+		PossibleValues: []string{"trapezoidal", "euler"},
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format - csv & plot for an ASCII plot",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json", "csv", "plot"},
+	})
+	return cmd
+}
+
 func get_command_voltage_divider() *cli.Command {
 	cmd := &cli.Command{
 		Name:        "voltage-divider",
 		Aliases:     []string{"vdiv"},
-		Description: "Calculate output voltage for series components",
+		Description: "Calculate the voltage of every tap of series components - the components from the input to ground are the args passed in or the -resistance, -inductance & -capacitance flags - or design a divider of standard values with -design",
 		Handler:     cmd_voltage_divider_handler,
 		Examples: []cli.Example{
 			{
 				Command: "gohm calculate voltage-divider -voltage 9v -resistance 3k -resistance 3k",
 				Output:  "voltage=4.5V",
 			},
+			{
+				Command:     "gohm calculate voltage-divider -voltage 12V 10k 4k7 2k2 -load 100k",
+				Description: "multi-tap divider loaded at its output - the components from the input to ground are the args",
+				Output: `tap=1 voltage=4.879454695356318V
+      tap=2 voltage=1.532798402173787V`,
+			},
+			{
+				Command:     "gohm calculate voltage-divider -voltage 3.3V -resistance 10k -resistance 10k -load 1M -load 10pF -frequency 100k",
+				Description: "divider into the input of an ADC - a divider of mixed components is complex",
+				Output:      "voltage=1.640989482318235V phase=-1.7904617362049084° real=1.6401883112027063V imaginary=-51.271677104261414mV",
+			},
+			{
+				Command:     "gohm calculate voltage-divider -voltage 1v -resistance 1k -capacitance 159n -sweep \"dec 1 100 10k\"",
+				Description: "AC sweep of a resistor <-> capacitor divider - magnitude (dB re 1V) & phase of the output",
+				Output: `frequency=100Hz magnitude=-0.04313005472805208dB phase=-5.705070360215296°
+      frequency=1kHz magnitude=-3.006071943492703dB phase=-44.972096663210095°
+      frequency=10kHz magnitude=-20.03483743605426dB phase=-84.28387880997566°`,
+			},
+			{
+				Command:     "gohm calculate voltage-divider -design -vin 12V -vout 3.3V -series E96 -current-max 100μA -top 3",
+				Description: "design a divider of standard values - min & max are the output with both resistors at the tolerance of the series",
+				Output: `r1=137kΩ r2=52.3kΩ vout=3.3153724247226624V error=0.4658% current=63.391442155309036μA min=3.267598226635182V max=3.3635760640584125V
+      r1=309kΩ r2=118kΩ vout=3.3161592505854802V error=0.4897% current=28.103044496487122μA min=3.268377981394698V max=3.3643698981392176V
+      r1=432kΩ r2=165kΩ vout=3.3165829145728645V error=0.5025% current=20.100502512562816μA min=3.2687978388113463V max=3.3647973348139923V`,
+			},
+			{
+				Command:     "gohm calculate voltage-divider -design -vout 5V -vref 0.8V -top 2",
+				Description: "feedback divider of a regulator with a 0.8V reference - R1 from the output to the feedback pin, R2 to ground",
+				Output: `r1=430kΩ r2=82kΩ vout=4.995121951219512V error=-0.0976% current=9.75609756097561μA min=4.595586527293844V max=5.436713735558408V
+      r1=680kΩ r2=130kΩ vout=4.984615384615385V error=-0.3077% current=6.153846153846154μA min=4.586080586080586V max=5.425101214574899V`,
+			},
 		},
 	}
 	cmd.AddFlag(&cli.Flag{
@@ -267,10 +845,30 @@ func get_command_voltage_divider() *cli.Command {
 		Description: "RKM & shorthand supported",
 		IsMulti:     true,
 	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "current-max",
+		Description: "Largest quiescent current through the divider with -design - shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "design",
+		Description: "Design a divider of 2 resistors of -series for -vout - the closest dividers first, ranked by error, quiescent current & spread across the tolerance of the series",
+		IsBoolean:   true,
+	})
 	cmd.AddFlag(&cli.Flag{
 		Name:        "frequency",
 		Aliases:     []string{"f"},
-		Description: "used only with a resistor <-> capacitor divider type - shorthand supported",
+		Description: "Frequency of a divider of mixed components - the corner frequency of a resistor <-> capacitor divider by default - shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "inductance",
+		Aliases:     []string{"l"},
+		Description: "RKM & shorthand supported",
+		IsMulti:     true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "load",
+		Description: "Load across the output, i.e. the input of an ADC - when specified multiple times - the loads are in parallel - an F suffix for a capacitor, an H suffix for an inductor",
+		IsMulti:     true,
 	})
 	cmd.AddFlag(&cli.Flag{
 		Name:        "resistance",
@@ -278,17 +876,39 @@ func get_command_voltage_divider() *cli.Command {
 		Description: "RKM & shorthand supported",
 		IsMulti:     true,
 	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "series",
+		Description:    "E series of the resistors with -design",
+		Default:        "E24",
+		PossibleValues: []string{"E6", "E12", "E24", "E48", "E96", "E192"},
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "sweep",
+		Description: "Sweep the AC response of the divider - frequencies as in a SPICE .ac - dec|oct|lin points fstart fstop",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "top",
+		Description: "Number of dividers listed with -design",
+		Default:     "5",
+	})
 	cmd.AddFlag(&cli.Flag{
 		Name:        "voltage",
-		Aliases:     []string{"v"},
+		Aliases:     []string{"v", "vin"},
 		Description: "Input voltage - shorthand supported",
-		Required:    true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "vout",
+		Description: "Target output voltage with -design - shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "vref",
+		Description: "Reference voltage of a regulator with -design - the divider is its feedback divider from -vout to the feedback pin, R1 on top - shorthand supported",
 	})
 	cmd.AddFlag(&cli.Flag{
 		Name:           "format",
-		Description:    "Output format",
+		Description:    "Output format - csv & plot for the ASCII Bode plot with -sweep",
 		Default:        "abbr",
-		PossibleValues: []string{"abbr", "raw", "json"},
+		PossibleValues: []string{"abbr", "raw", "json", "csv", "plot"},
 	})
 	return cmd
 }
@@ -1,8 +1,13 @@
 package calculate
 
 import (
+	"gohm/abbrvs"
 	"gohm/cli"
 	"gohm/test_utils"
+	"gohm/utils"
+	"math"
+	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -136,10 +141,170 @@ func TestCmd555HandlerPanics(t *testing.T) {
 			cmd_555_handler(cmd)
 		})
 	})
+
+	t.Run("simulate monostable", func(t *testing.T) {
+		cmd := test_utils.CreateTestCommand(
+			cmd_555_handler,
+			map[string]string{
+				"format":      "abbr",
+				"capacitance": "1μ",
+				"simulate":    "true",
+				"voltage":     "9V",
+			},
+			map[string][]string{
+				"resistance": {"1k"},
+			},
+			nil,
+		)
+		test_utils.ExpectPanic(t, "unsupported: -simulate of a monostable circuit - specify 2 resistors for an astable circuit", func() {
+			cmd_555_handler(cmd)
+		})
+	})
+
+	t.Run("csv without simulate", func(t *testing.T) {
+		cmd := test_utils.CreateTestCommand(
+			cmd_555_handler,
+			map[string]string{
+				"format":      "csv",
+				"capacitance": "1μ",
+			},
+			map[string][]string{
+				"resistance": {"1k", "1k"},
+			},
+			nil,
+		)
+		test_utils.ExpectPanic(t, "unsupported: -format csv without -simulate", func() {
+			cmd_555_handler(cmd)
+		})
+	})
+
+	t.Run("simulate without supply", func(t *testing.T) {
+		cmd := test_utils.CreateTestCommand(
+			cmd_555_handler,
+			map[string]string{
+				"format":      "abbr",
+				"capacitance": "1μ",
+				"simulate":    "true",
+				"voltage":     "0V",
+			},
+			map[string][]string{
+				"resistance": {"1k", "1k"},
+			},
+			nil,
+		)
+		test_utils.ExpectPanic(t, "invalid: supply voltage - expected above 0V", func() {
+			cmd_555_handler(cmd)
+		})
+	})
+}
+
+func TestCmd555HandlerSimulate(t *testing.T) {
+	tests := []struct {
+		name     string
+		r1       string
+		r2       string
+		format   string
+		contains []string
+	}{
+		{"simulate abbr", "1k", "1k", "abbr", []string{"time_low=693μs time_high=1.386ms frequency=480Hz simulated_time_low=693.14963399μs simulated_time_high=1.3862945395800002ms simulated_frequency=480.8977383043638Hz"}},
+		{"simulate json", "1k", "1k", "json", []string{`"frequency":480,`, `"simulatedTimeLow":0.0006931496339899999,"simulatedTimeLowAbbreviated":"693.14963399μs"`, `"simulatedFrequencyAbbreviated":"480.8977383043638Hz"`}},
+		{"simulate csv", "1k", "1k", "csv", []string{"time,capacitor,output\n0,0,9\n0.000000693,"}},
+		{"simulate plot", "1k", "1k", "plot", []string{"simulated_frequency=480.8977383043638Hz\n\nvoltage (V) vs time (s)  * capacitor  + output", "9V ┤+"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(
+				cmd_555_handler,
+				map[string]string{
+					"format":      tt.format,
+					"capacitance": "1μ",
+					"simulate":    "true",
+					"voltage":     "9V",
+				},
+				map[string][]string{
+					"resistance": {tt.r1, tt.r2},
+				},
+				nil,
+			)
+			result := cmd_555_handler(cmd)
+			test_utils.AssertContains(t, result, tt.contains...)
+		})
+	}
+
+	// the 0.693 approximation holds to within 0.1% for any ratio of R1 & R2
+	for _, resistances := range [][]string{{"1k", "10k"}, {"10k", "1k"}, {"4K7", "47k"}} {
+		r1 := utils.GetValueForRKMElseShorthand(resistances[0], abbrvs.RKM_RESISTOR, abbrvs.RESISTOR)
+		r2 := utils.GetValueForRKMElseShorthand(resistances[1], abbrvs.RKM_RESISTOR, abbrvs.RESISTOR)
+		tl, th, _ := get_555_astable_times(r1, r2, 10e-9)
+		sim := simulate_555_astable(r1, r2, 10e-9, 5)
+		if math.Abs(sim.time_low/tl-1) > 1e-3 || math.Abs(sim.time_high/th-1) > 1e-3 {
+			t.Errorf("simulated time low & high of %v = %v & %v, expected about %v & %v", resistances, sim.time_low, sim.time_high, tl, th)
+		}
+	}
 }
 
 //endregion 555 Timer Tests
 
+//region AC Tests
+
+func TestCmdAcHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		contains []string
+	}{
+		{"rc low-pass corner", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "159n", "sweep": "lin 1 1k 1k"}, []string{"frequency=1kHz magnitude=-3.006071943492703dB phase=-44.972096663210095°"}},
+		{"rc high-pass corner", map[string]string{"topology": "rc-highpass", "resistance": "1k", "capacitance": "159n", "sweep": "lin 1 1k 1k"}, []string{"phase=45.027903336789905°"}},
+		{"rl low-pass", map[string]string{"topology": "rl-lowpass", "resistance": "1k", "inductance": "1m", "sweep": "dec 1 1 1"}, []string{"frequency=1Hz magnitude=-0.00000000017145356624500042dB phase=-0.0003599999999952626°"}},
+		{"rl high-pass blocks dc", map[string]string{"topology": "rl-highpass", "resistance": "1k", "inductance": "1m", "sweep": "dec 1 1 1"}, []string{"frequency=1Hz magnitude=-104.03640263300915dB phase=89.99964°"}},
+		{"rlc band-pass resonance", map[string]string{"topology": "rlc-bandpass", "resistance": "100", "capacitance": "100n", "inductance": "10m", "sweep": "lin 1 5.032921210448704k 5.032921210448704k"}, []string{"frequency=5.032921210448704kHz magnitude=0dB"}},
+		{"rlc band-stop resonance", map[string]string{"topology": "rlc-bandstop", "resistance": "100", "capacitance": "100n", "inductance": "10m", "sweep": "lin 1 1k 1k"}, []string{"phase=-"}},
+		{"rlc low-pass", map[string]string{"topology": "rlc-lowpass", "resistance": "100", "capacitance": "100n", "inductance": "10m", "sweep": "dec 1 1 10"}, []string{"frequency=1Hz", "frequency=10Hz"}},
+		{"rlc high-pass", map[string]string{"topology": "rlc-highpass", "resistance": "100", "capacitance": "100n", "inductance": "10m", "sweep": "oct 1 1k 4k"}, []string{"frequency=1kHz", "frequency=2kHz", "frequency=4kHz"}},
+		{"raw format", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "159n", "sweep": "lin 1 1k 1k", "format": "raw"}, []string{"frequency=1000Hz magnitude=-3.006071943492703dB"}},
+		{"json format", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "159n", "sweep": "lin 1 1k 1k", "format": "json"}, []string{`{"analysis":"ac","title":"rc-lowpass","node":"out","points":[{"frequency":1000,"frequencyAbbreviated":"1kHz","magnitude":-3.006071943492703,"phase":-44.972096663210095}]}`}},
+		{"csv format", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "159n", "sweep": "lin 2 1k 2k", "format": "csv"}, []string{"frequency,magnitude,phase\n1000,-3.006071943492703,-44.972096663210095\n2000,"}},
+		{"plot format", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "159n", "sweep": "dec 10 10 100k", "format": "plot"}, []string{"V(out) (dB) vs frequency (Hz)", "phase (°) vs frequency (Hz)", "0dB ┤", "10Hz", "100kHz"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr", "sweep": "dec 10 1 1Meg"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_ac_handler, flags, nil, nil)
+			test_utils.AssertContains(t, cmd_ac_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestCmdAcHandlerPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		expected string
+	}{
+		{"unknown topology", map[string]string{"topology": "lc-tank"}, "invalid or unsupported: topology lc-tank"},
+		{"missing component", map[string]string{"topology": "rlc-bandpass", "resistance": "1k", "capacitance": "1n"}, "invalid: topology rlc-bandpass requires -inductance"},
+		{"invalid sweep", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "1n", "sweep": "dec 10 1k"}, "invalid: sweep dec 10 1k - expected dec|oct|lin points fstart fstop"},
+		{"invalid sweep type", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "1n", "sweep": "log 10 1k 10k"}, "invalid or unsupported: sweep type log"},
+		{"reversed sweep", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "1n", "sweep": "dec 10 10k 1k"}, "invalid: sweep stop frequency 1k"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr", "sweep": "dec 10 1 1Meg"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_ac_handler, flags, nil, nil)
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_ac_handler(cmd)
+			})
+		})
+	}
+}
+
+//endregion AC Tests
+
 //region Capacitance Tests
 
 func TestCmdCapacitanceHandler(t *testing.T) {
@@ -321,59 +486,673 @@ func TestCmdCurrentDividerHandlerPanics(t *testing.T) {
 	})
 }
 
-//endregion Current Divider Tests
+//endregion Current Divider Tests
+
+//region Impedance Tests
+
+func TestCmdImpedanceHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		multi    map[string][]string
+		args     []string
+		expected []string
+	}{
+		{
+			"series RC",
+			map[string]string{"frequency": "1k"},
+			nil,
+			[]string{"1k", "100nF"},
+			[]string{"frequency=1kHz xc=1.5915494309189535kΩ impedance=1k-1.5915494309189535kjΩ magnitude=1.8796354942005231kΩ phase=-57.85809236465795° q=1.5915494309189535"},
+		},
+		{
+			"lowercase units",
+			map[string]string{"frequency": "1k"},
+			nil,
+			[]string{"1k", "100nf", "10mh"},
+			[]string{"frequency=1kHz xc=1.5915494309189535kΩ xl=62.83185307179586Ω impedance=1k-1.5287175778471576kjΩ"},
+		},
+		{
+			"parallel RLC at resonance",
+			map[string]string{"frequency": "1k", "circuit": "parallel", "format": "raw"},
+			map[string][]string{"resistance": {"1k"}, "inductance": {"10m"}, "capacitance": {"2.533029591058444μ"}},
+			nil,
+			[]string{"xc=62.83185307179588Ω xl=62.83185307179586Ω impedance=1000+0jΩ magnitude=1000Ω phase=0° q=0"},
+		},
+		{
+			"series capacitors",
+			map[string]string{"frequency": "1k"},
+			nil,
+			[]string{"100nF", "100nF"},
+			[]string{"xc=3.183098861837907kΩ impedance=0-3.183098861837907kjΩ", "phase=-90°"},
+		},
+		{
+			"parallel inductors",
+			map[string]string{"frequency": "1k", "circuit": "parallel"},
+			nil,
+			[]string{"10mH", "10mH"},
+			[]string{"xl=31.41592653589793Ω", "phase=90°"},
+		},
+		{
+			"sweep",
+			map[string]string{"sweep": "dec 1 100 10k"},
+			map[string][]string{"resistance": {"10"}, "inductance": {"10m"}},
+			nil,
+			[]string{
+				"frequency=100Hz xl=6.283185307179586Ω impedance=10+6.283185307179586jΩ",
+				"\nfrequency=1kHz xl=62.83185307179586Ω impedance=10+62.83185307179586jΩ magnitude=63.62265131567329Ω phase=80.95693892096232° q=6.283185307179586\n",
+				"frequency=10kHz",
+			},
+		},
+		{
+			"json",
+			map[string]string{"frequency": "1k", "format": "json"},
+			nil,
+			[]string{"100nF"},
+			[]string{`{"frequency":1000,"frequencyAbbreviated":"1kHz","xc":1591.5494309189535,"xl":null,"impedance":{"real":0,"imaginary":-1591.5494309189535,"magnitude":1591.5494309189535,"magnitudeAbbreviated":"1.5915494309189535kΩ","phase":-90},"q":null}`},
+		},
+		{
+			"json sweep",
+			map[string]string{"sweep": "lin 2 1k 2k", "format": "json"},
+			nil,
+			[]string{"1k"},
+			[]string{`{"points":[{"frequency":1000,`, `"q":0},{"frequency":2000,`, `"xc":null,"xl":null,"impedance":{"real":1000,"imaginary":0,`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr", "circuit": "series"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_impedance_handler, flags, tt.multi, tt.args)
+			output := cmd_impedance_handler(cmd)
+			for _, expected := range tt.expected {
+				test_utils.AssertContains(t, output, expected)
+			}
+		})
+	}
+}
+
+func TestCmdImpedanceHandlerResonance(t *testing.T) {
+	// an LC at the resonance of 10mH & 100nF is a short in series & an open circuit in parallel - neither has a Q, nor
+	// a pure reactance
+	lc := map[string][]string{"inductance": {"10m"}, "capacitance": {"100n"}}
+
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		multi    map[string][]string
+		expected string
+	}{
+		{"series", nil, lc, "frequency=5.032921210448704kHz xc=316.2277660168379Ω xl=316.22776601683796Ω impedance=0+0jΩ magnitude=0Ω phase=0°"},
+		{"parallel", map[string]string{"circuit": "parallel"}, lc, "frequency=5.032921210448704kHz xc=316.2277660168379Ω xl=316.22776601683796Ω impedance=∞Ω"},
+		{"parallel json", map[string]string{"circuit": "parallel", "format": "json"}, lc, `{"frequency":5032.921210448704,"frequencyAbbreviated":"5.032921210448704kHz","xc":316.2277660168379,"xl":316.22776601683796,"impedance":null,"q":null}`},
+		{"pure reactance", map[string]string{"frequency": "1k"}, map[string][]string{"inductance": {"10m"}}, "frequency=1kHz xl=62.83185307179586Ω impedance=0+62.83185307179586jΩ magnitude=62.83185307179586Ω phase=90°"},
+		{"parallel pure reactance", map[string]string{"frequency": "1k", "circuit": "parallel"}, map[string][]string{"inductance": {"10m"}, "capacitance": {"2.533μ"}}, "frequency=1kHz xc=62.83258708720702Ω xl=62.83185307179586Ω impedance=0+5.3784809147065396MjΩ magnitude=5.3784809147065396MΩ phase=90°"},
+		{"parallel pure reactance json", map[string]string{"frequency": "1k", "circuit": "parallel", "format": "json"}, map[string][]string{"inductance": {"10m"}, "capacitance": {"2.533μ"}}, `{"frequency":1000,"frequencyAbbreviated":"1kHz","xc":62.83258708720702,"xl":62.83185307179586,"impedance":{"real":0,"imaginary":5378480.914706539,"magnitude":5378480.914706539,"magnitudeAbbreviated":"5.3784809147065396MΩ","phase":90},"q":null}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr", "circuit": "series", "frequency": "5032.921210448704"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_impedance_handler, flags, tt.multi, nil)
+			test_utils.AssertEquals(t, cmd_impedance_handler(cmd), tt.expected)
+		})
+	}
+}
+
+func TestCmdImpedanceHandlerPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		multi    map[string][]string
+		args     []string
+		expected string
+	}{
+		{"no components", map[string]string{"frequency": "1k"}, nil, nil, "too few arguments: [args...]"},
+		{"args & flags", map[string]string{"frequency": "1k"}, map[string][]string{"resistance": {"1k"}}, []string{"1k"}, "invalid: components as both args & flags - specify the impedance with either"},
+		{"invalid circuit", map[string]string{"frequency": "1k", "circuit": "bridge"}, nil, []string{"1k"}, "invalid or unsupported: circuit bridge"},
+		{"without frequency", nil, nil, []string{"1k"}, "invalid: impedance requires -frequency or -sweep"},
+		{"frequency & sweep", map[string]string{"frequency": "1k", "sweep": "dec 1 100 1k"}, nil, []string{"1k"}, "invalid: -frequency with -sweep - the sweep sets the frequencies"},
+		{"invalid frequency", map[string]string{"frequency": "0Hz"}, nil, []string{"1k"}, "invalid: frequency 0Hz"},
+		{"ambiguous capacitor", map[string]string{"frequency": "1k"}, nil, []string{"1k", "100n", "10mH"}, "invalid: ambiguous component 100n - suffix a capacitor with F & an inductor with H, i.e. 100nF"},
+		{"ambiguous inductor", map[string]string{"frequency": "1k"}, nil, []string{"1k", "100nF", "10m"}, "invalid: ambiguous component 10m - suffix a capacitor with F & an inductor with H, i.e. 10mF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr", "circuit": "series"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_impedance_handler, flags, tt.multi, tt.args)
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_impedance_handler(cmd)
+			})
+		})
+	}
+}
+
+//endregion Impedance Tests
+
+//region Inductance Tests
+
+func TestCmdInductanceHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		circuit  string
+		coupling []string
+		args     []string
+		format   string
+		contains []string
+	}{
+		{"series inductance", "series", nil, []string{"4μ7H", "10μH"}, "abbr", []string{"inductance=14.7μH"}},
+		{"parallel inductance", "parallel", nil, []string{"10μH", "10μH"}, "abbr", []string{"inductance=5μH"}},
+		{"RKM notation", "series", nil, []string{"4R7", "2m2"}, "abbr", []string{"inductance=4.7022H"}},
+		{"series aiding", "series", []string{"0.5"}, []string{"10μ", "10μ"}, "abbr", []string{"inductance=30μH"}},
+		{"series opposing", "series", []string{"-0.5"}, []string{"10μ", "10μ"}, "abbr", []string{"inductance=10μH"}},
+		{"parallel aiding", "parallel", []string{"0.5"}, []string{"10μ", "10μ"}, "abbr", []string{"inductance=7.499999999999998μH"}},
+		{"parallel opposing", "parallel", []string{"-0.5"}, []string{"10μ", "10μ"}, "abbr", []string{"inductance=2.5μH"}},
+		{"uncoupled pairs", "series", []string{"1:2=0"}, []string{"10μ", "22μ", "4μ7"}, "abbr", []string{"inductance=36.699999999999996μH"}},
+		{"3 coupled inductors", "parallel", []string{"1:2=0.8", "2:3=-0.1"}, []string{"10μ", "22μ", "4R7"}, "abbr", []string{"inductance=9.568338334166418μH"}},
+		{"3 fully coupled inductors", "series", []string{"1:2=1", "1:3=1", "2:3=1"}, []string{"10μ", "10μ", "10μ"}, "abbr", []string{"inductance=90μH"}},
+		{"raw format", "series", nil, []string{"10μ", "10μ"}, "raw", []string{"inductance=0.000019999999999999998H"}},
+		{"json format", "series", nil, []string{"10μ", "10μ"}, "json", []string{`{"inductance":0.000019999999999999998,"inductanceAbbreviated":"20μH"}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			multiFlags := map[string][]string{}
+			if len(tt.coupling) > 0 {
+				multiFlags["coupling"] = tt.coupling
+			}
+
+			cmd := test_utils.CreateTestCommand(cmd_inductance_handler, map[string]string{"format": tt.format, "circuit": tt.circuit}, multiFlags, tt.args)
+			test_utils.AssertContains(t, cmd_inductance_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestCmdInductanceHandlerPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		circuit  string
+		coupling []string
+		args     []string
+		expected string
+	}{
+		{"no inductors", "series", nil, nil, "too few arguments: [args...]"},
+		{"1 inductor in parallel", "parallel", nil, []string{"10μ"}, "too few arguments: [args...] - requires at least 2"},
+		{"coupling out of range", "series", []string{"1.5"}, []string{"10μ", "10μ"}, "invalid: coupling coefficient 1.5 - expected -1 to 1"},
+		{"coupling without pair", "series", []string{"0.5"}, []string{"10μ", "10μ", "10μ"}, "invalid: coupling 0.5 - expected i:j=k for more than 2 inductors"},
+		{"coupling of unknown inductor", "series", []string{"1:3=0.5"}, []string{"10μ", "10μ"}, "invalid: coupling 1:3=0.5 - inductors are numbered 1 to 2"},
+		{"coupling of itself", "series", []string{"1:1=0.5"}, []string{"10μ", "10μ"}, "invalid: coupling 1:1=0.5 - inductors are numbered 1 to 2"},
+		{"malformed coupling", "series", []string{"1-2=0.5"}, []string{"10μ", "10μ"}, "invalid: coupling 1-2=0.5 - expected i:j=k"},
+		{"fully opposing in series", "series", []string{"-1"}, []string{"10μ", "10μ"}, "invalid inductance"},
+		{"fully coupled in parallel", "parallel", []string{"1"}, []string{"10μ", "10μ"}, "invalid: coupled inductors in parallel with a singular inductance matrix, i.e. a coupling of 1"},
+		{"pairwise opposing in series", "series", []string{"1:2=-0.9", "1:3=-0.9", "2:3=-0.9"}, []string{"10μ", "10μ", "10μ"}, "invalid: coupling coefficients"},
+		{"pairwise opposing in parallel", "parallel", []string{"1:2=-0.9", "1:3=-0.9", "2:3=-0.9"}, []string{"10μ", "10μ", "10μ"}, "invalid: coupling coefficients"},
+		{"couplings that can not coexist", "parallel", []string{"1:2=0.9", "1:3=0.9", "2:3=-0.9"}, []string{"10μ", "22μ", "4R7"}, "invalid: coupling coefficients"},
+		{"fully coupled to 2 uncoupled inductors", "series", []string{"1:2=1", "1:3=1"}, []string{"10μ", "10μ", "10μ"}, "invalid: coupling coefficients"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			multiFlags := map[string][]string{}
+			if len(tt.coupling) > 0 {
+				multiFlags["coupling"] = tt.coupling
+			}
+
+			cmd := test_utils.CreateTestCommand(cmd_inductance_handler, map[string]string{"format": "abbr", "circuit": tt.circuit}, multiFlags, tt.args)
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_inductance_handler(cmd)
+			})
+		})
+	}
+}
+
+//endregion Inductance Tests
+
+//region Match Tests
+
+func write_test_match_input(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "measured.csv")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCmdMatchHandler(t *testing.T) {
+	labeled := "label,value\nR1,10000\nR2,9996\nR3,10009.5\nR4,10020\nR5,10004\nR6,10008\n"
+
+	tests := []struct {
+		name     string
+		input    string
+		flags    map[string]string
+		contains []string
+	}{
+		{
+			name:  "pairs",
+			input: labeled,
+			flags: map[string]string{"nominal": "10k"},
+			contains: []string{
+				"nominal=10kΩ parts=6",
+				"bin=±0.01% count=1 parts=R1\nbin=±0.05% count=2 parts=R2,R5\nbin=±0.1% count=2 parts=R3,R6\nbin=±0.25% count=1 parts=R4",
+				"set=1 parts=R6,R3 values=10.008kΩ,10.0095kΩ mean=10.00875kΩ spread=0.015%",
+				"set=2 parts=R1,R5",
+				"unmatched=R2,R4",
+			},
+		},
+		{
+			name:     "quads",
+			input:    labeled,
+			flags:    map[string]string{"set": "4", "tolerance": "0.15%"},
+			contains: []string{"nominal=10.006kΩ", "set=1 parts=R1,R5,R6,R3", "spread=0.0949%", "unmatched=R2,R4"},
+		},
+		{
+			name:     "nothing within tolerance",
+			input:    labeled,
+			flags:    map[string]string{"tolerance": "0.01"},
+			contains: []string{"unmatched=R2,R1,R5,R6,R3,R4"},
+		},
+		{
+			name:     "plain list with RKM, shorthand & labels",
+			input:    "4K7\n4.71k\n4702Ω,spare\n# comment\n4.69k\n",
+			contains: []string{"parts=4", "set=1 parts=#1,spare values=4.7kΩ,4.702kΩ", "unmatched=#4,#2"},
+		},
+		{
+			name:     "measure csv log",
+			input:    "time,quantity,value,unit\n2026-10-19T09:30:00.000Z,resistance,100.1,Ω\n2026-10-19T09:30:01.000Z,resistance,100.2,Ω\n",
+			contains: []string{"set=1 parts=#1,#2 values=100.1Ω,100.2Ω"},
+		},
+		{
+			name:     "capacitors",
+			input:    "100n\n101n\n",
+			flags:    map[string]string{"component": "capacitor", "tolerance": "1%"},
+			contains: []string{"set=1 parts=#1,#2 values=100nF,101nF"},
+		},
+		{
+			name:     "raw format",
+			input:    "1000\n1001\n",
+			flags:    map[string]string{"format": "raw"},
+			contains: []string{"nominal=1000.5Ω parts=2", "values=1000Ω,1001Ω mean=1000.5Ω"},
+		},
+		{
+			name:     "json format",
+			input:    labeled,
+			flags:    map[string]string{"format": "json", "nominal": "10k"},
+			contains: []string{`{"nominal":10000,"nominalAbbreviated":"10kΩ","parts":6,"bins":[{"tolerance":0.01,"parts":["R1"]}`, `"sets":[{"parts":["R6","R3"],"values":[10008,10009.5],"mean":10008.75,"meanAbbreviated":"10.00875kΩ","spread":0.015}`, `"unmatched":["R2","R4"]}`},
+		},
+		{
+			name:     "json escapes labels",
+			input:    "label,value\n\"R\"\"1\"\"\",1000\nR\\2,1001\n",
+			flags:    map[string]string{"format": "json"},
+			contains: []string{`"sets":[{"parts":["R\"1\"","R\\2"]`, `"unmatched":[]}`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{
+				"input":     write_test_match_input(t, tt.input),
+				"tolerance": "0.1%",
+				"set":       "2",
+				"component": "resistor",
+				"format":    "abbr",
+			}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_match_handler, flags, nil, nil)
+			result := cmd_match_handler(cmd)
+			test_utils.AssertContains(t, result, tt.contains...)
+		})
+	}
+}
+
+func TestCmdMatchHandlerPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		flags    map[string]string
+		expected string
+	}{
+		{"invalid tolerance", "1k\n1k\n", map[string]string{"tolerance": "tight"}, "invalid: tolerance tight"},
+		{"set of 1", "1k\n1k\n", map[string]string{"set": "1"}, "invalid: set 1 - a set has at least 2 parts"},
+		{"too few parts", "1k\n", nil, "too few parts: 1 in "},
+		{"invalid value", "1k\nabc\n", nil, "invalid"},
+		{"unsupported component", "1k\n1k\n", map[string]string{"component": "inductor"}, "invalid or unsupported: component inductor"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{
+				"input":     write_test_match_input(t, tt.input),
+				"tolerance": "0.1%",
+				"set":       "2",
+				"component": "resistor",
+				"format":    "abbr",
+			}, tt.flags)
+
+			test_utils.ExpectPanicContains(t, tt.expected, func() {
+				cmd_match_handler(test_utils.CreateTestCommand(cmd_match_handler, flags, nil, nil))
+			})
+		})
+	}
+}
+
+//endregion Match Tests
+
+//region Missing Tests
+
+func TestCmdMissingHandler(t *testing.T) {
+	tests := []struct {
+		name      string
+		component string
+		circuit   string
+		target    string
+		series    string
+		args      []string
+		format    string
+		contains  []string
+	}{
+		{"series resistor", "resistor", "series", "10k", "", []string{"4K7", "2K2"}, "abbr", []string{"resistance=3.1kΩ exact=3.1kΩ series=nil total=10kΩ error=0%"}},
+		{"series trim resistor", "resistor", "series", "10k", "E96", []string{"9K1"}, "abbr", []string{"resistance=909Ω exact=900Ω series=E96 total=10.009kΩ error=0.09%"}},
+		{"parallel resistor", "resistor", "parallel", "150", "", []string{"250"}, "abbr", []string{"resistance=374.99999999999994Ω", "total=150Ω error=0%"}},
+		{"parallel resistor snapped", "resistor", "parallel", "150", "E24", []string{"250"}, "abbr", []string{"resistance=390Ω exact=374.99999999999994Ω series=E24 total=152.34375Ω error=1.5625%"}},
+		{"parallel padding capacitor", "capacitor", "parallel", "150p", "E12", []string{"100p"}, "abbr", []string{"capacitance=47pF exact=49.99999999999999pF series=E12 total=147pF error=-2%"}},
+		{"series capacitor", "capacitor", "series", "10n", "", []string{"22n"}, "abbr", []string{"capacitance=18.333333333333332nF", "total=10nF error=0%"}},
+		{"series inductor", "inductor", "series", "10μH", "E6", []string{"4μ7H"}, "abbr", []string{"inductance=4.7μH exact=5.3μH series=E6 total=9.4μH error=-6%"}},
+		{"parallel inductor", "inductor", "parallel", "5μH", "", []string{"10μH"}, "abbr", []string{"inductance=10μH", "series=nil"}},
+		{"raw format", "resistor", "series", "10k", "E96", []string{"9K1"}, "raw", []string{"resistance=909Ω exact=900Ω series=E96 total=10009Ω error=0.09%"}},
+		{"json format", "resistor", "series", "10k", "E96", []string{"9K1"}, "json", []string{`{"resistance":909,"resistanceAbbreviated":"909Ω","exact":900,"exactAbbreviated":"900Ω","series":"E96","total":10009,"totalAbbreviated":"10.009kΩ","error":0.09}`}},
+		{"json format without series", "capacitor", "series", "10n", "", []string{"22n"}, "json", []string{`"series":null`, `"error":0}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := map[string]string{
+				"format":    tt.format,
+				"component": tt.component,
+				"circuit":   tt.circuit,
+				"target":    tt.target,
+				"series":    tt.series,
+			}
+			cmd := test_utils.CreateTestCommand(cmd_missing_handler, flags, nil, tt.args)
+			test_utils.AssertContains(t, cmd_missing_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestCmdMissingHandlerPanics(t *testing.T) {
+	tests := []struct {
+		name      string
+		component string
+		circuit   string
+		target    string
+		series    string
+		args      []string
+		expected  string
+	}{
+		{"no args", "resistor", "series", "10k", "", nil, "too few arguments: [args...]"},
+		{"series above target", "resistor", "series", "10k", "", []string{"12K"}, "invalid resistance - the series of the args is already above the target"},
+		{"series at target", "resistor", "series", "10k", "", []string{"10K"}, "invalid resistance - the series of the args is already above the target"},
+		{"parallel below target", "resistor", "parallel", "1k", "", []string{"470"}, "invalid resistance - the parallel of the args is already below the target"},
+		{"series capacitors below target", "capacitor", "series", "10n", "", []string{"4n7"}, "invalid capacitance - the series of the args is already below the target"},
+		{"unknown component", "diode", "series", "10k", "", []string{"1K"}, "invalid or unsupported: component diode"},
+		{"unknown series", "resistor", "series", "10k", "E3", []string{"1K"}, "invalid or unsupported: series E3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := map[string]string{
+				"format":    "abbr",
+				"component": tt.component,
+				"circuit":   tt.circuit,
+				"target":    tt.target,
+				"series":    tt.series,
+			}
+			cmd := test_utils.CreateTestCommand(cmd_missing_handler, flags, nil, tt.args)
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_missing_handler(cmd)
+			})
+		})
+	}
+}
+
+//endregion Missing Tests
+//region Missing Resistance Tests
+
+func TestCmdMissingResistanceHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		target   string
+		args     []string
+		format   string
+		contains []string
+	}{
+		{
+			name:     "find missing resistance",
+			target:   "500",
+			args:     []string{"1k"},
+			format:   "abbr",
+			contains: []string{"resistance=1kΩ"},
+		},
+		{
+			name:     "raw format",
+			target:   "500",
+			args:     []string{"1k"},
+			format:   "raw",
+			contains: []string{"resistance=1000Ω"},
+		},
+		{
+			name:     "json format",
+			target:   "500",
+			args:     []string{"1k"},
+			format:   "json",
+			contains: []string{`"resistance":1000`, `"resistanceAbbreviated":"1kΩ"`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(
+				cmd_missing_resistance_handler,
+				map[string]string{
+					"format": tt.format,
+					"target": tt.target,
+				},
+				nil,
+				tt.args,
+			)
+			result := cmd_missing_resistance_handler(cmd)
+			test_utils.AssertContains(t, result, tt.contains...)
+		})
+	}
+}
+
+//endregion Missing Resistance Tests
+//region Netlist Tests
+
+func write_test_netlist(t *testing.T, content string) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "circuit.cir")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestCmdNetlistHandler(t *testing.T) {
+	divider := "Divider\nV1 in 0 10\nR1 in out 1k\nR2 out 0 4k\n.op\n.end\n"
+
+	tests := []struct {
+		name     string
+		netlist  string
+		format   string
+		contains []string
+	}{
+		{"divider", divider, "abbr", []string{"node=in voltage=10V\nnode=out voltage=8V\nelement=V1 voltage=10V current=-2mA power=-20mW\nelement=R1 voltage=2V current=2mA power=4mW\nelement=R2 voltage=8V current=2mA power=16mW"}},
+		{"op by default", "Divider\nV1 in 0 10\nR1 in out 1k\nR2 out 0 4k\n", "abbr", []string{"node=out voltage=8V"}},
+		{"raw format", divider, "raw", []string{"element=R2 voltage=8V current=0.002A power=0.016W"}},
+		{"json format", divider, "json", []string{`{"analysis":"op","title":"Divider","nodes":[{"node":"in","voltage":10,"voltageAbbreviated":"10V"},{"node":"out","voltage":8,"voltageAbbreviated":"8V"}],"elements":[{"element":"V1","voltage":10,"voltageAbbreviated":"10V","current":-0.002,"currentAbbreviated":"-2mA","power":-0.02,"powerAbbreviated":"-20mW"},`}},
+		{"json escapes names", "Divider \"A\"\nV1 in\\1 0 10\nR\"1 in\\1 0 1k\n", "json", []string{`{"analysis":"op","title":"Divider \"A\"","nodes":[{"node":"in\\1",`, `{"element":"R\"1",`}},
+		{"multiple analyses in json", "Divider\nV1 in 0 10\nR1 in 0 1k\n.op\n.op\n", "json", []string{`[{"analysis":"op"`, `]},{"analysis":"op"`}},
+		{"capacitor open & inductor short", "LC\nI1 0 in 1m\nL1 in out 10u\nR1 out 0 1k\nC1 out 0 1u\n", "abbr", []string{"node=in voltage=1V", "element=L1 voltage=0V current=1mA power=0W", "element=C1 voltage=1V current=0A power=0W"}},
+		{"vcvs", "Amplifier\nV1 in 0 DC 100m\nR1 in 0 1k\nE1 out 0 in 0 10\nR2 out 0 1k\n", "abbr", []string{"node=out voltage=1V", "element=E1 voltage=1V current=-1mA power=-1mW"}},
+		{"ac sweep", "RC\nV1 in 0 DC 5 AC 1\nR1 in out 1k\nC1 out 0 159n\n.ac lin 1 1k 1k\n", "abbr", []string{"frequency=1kHz magnitude=-3.006071943492703dB phase=-44.972096663210095°"}},
+		{"ac source phase", "RC\nV1 in 0 AC 2 90\nR1 in out 1k\nC1 out 0 159n\n.ac lin 1 1k 1k\n", "abbr", []string{"magnitude=3.0145279697869203dB phase=45.027903336789905°"}},
+		{"op & ac", "RC\nV1 in 0 DC 5 AC 1\nR1 in out 1k\nC1 out 0 159n\n.op\n.ac lin 1 1k 1k\n", "abbr", []string{"node=out voltage=5V\n", "\nfrequency=1kHz"}},
+		{"op csv", divider, "csv", []string{"kind,name,voltage,current,power\nnode,in,10,,\nnode,out,8,,\nelement,V1,10,-0.002,-0.02"}},
+		{"cccs", "Mirror\nV1 in 0 1\nR1 in 0 1k\nF1 0 out V1 -2\nR2 out 0 1k\n", "abbr", []string{"node=out voltage=2V", "element=F1 voltage=-2V current=2mA power=-4mW"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// -node only selects the output of the .ac analyses
+			cmd := test_utils.CreateTestCommand(cmd_netlist_handler, map[string]string{"format": tt.format, "node": "out"}, nil, []string{write_test_netlist(t, tt.netlist)})
+			test_utils.AssertContains(t, cmd_netlist_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestCmdNetlistHandlerTransient(t *testing.T) {
+	rc := "RC step\nV1 in 0 PULSE(0 1 0)\nR1 in out 1k\nC1 out 0 1u\n.tran 500u 2m\n.end\n"
+
+	tests := []struct {
+		name     string
+		netlist  string
+		flags    map[string]string
+		contains []string
+	}{
+		{"all nodes", rc, nil, []string{"time=0s in=0V out=0V\ntime=500.00000000000006μs in=1V out=393.5057180739055mV\ntime=1ms in=1V out=632.1664160789213mV"}},
+		{"node", rc, map[string]string{"node": "out"}, []string{"time=1ms out=632.1664160789213mV\ntime=1.5ms out=776.9120839350102mV\ntime=2ms out=864.6990909212406mV"}},
+		{"euler", rc, map[string]string{"node": "out", "method": "euler"}, []string{"time=1ms out=625.2160748177607mV"}},
+		{"tstart", "RC step\nV1 in 0 PULSE(0 1 0)\nR1 in out 1k\nC1 out 0 1u\n.tran 500u 2m 1m\n", map[string]string{"node": "out", "format": "raw"}, []string{"time=0.001s out=0.6321664160789213V\ntime=0.0015s"}},
+		{"uic", "Discharge\nR1 out 0 1k\nC1 out 0 1u IC=1\n.tran 500u 500u UIC\n", map[string]string{"node": "out"}, []string{"time=0s out=1V\ntime=500.00000000000006μs out=606.5281807899137mV"}},
+		{"sine", "Sine\nV1 in 0 SIN(0 1 250)\nR1 in 0 1k\n.tran 1m 1m\n", map[string]string{"node": "in"}, []string{"time=1ms in=1V"}},
+		{"json", rc, map[string]string{"node": "out", "format": "json"}, []string{`{"analysis":"tran","title":"RC step","points":[{"time":0,"timeAbbreviated":"0s","voltages":{"out":0}},{"time":0.0005,"timeAbbreviated":"500.00000000000006μs","voltages":{"out":0.3935057180739055}}`}},
+		{"csv", rc, map[string]string{"format": "csv"}, []string{"time,in,out\n0,0,0\n0.0005,1,0.3935057180739055\n0.001,1,0.6321664160789213"}},
+		{"plot", rc, map[string]string{"format": "plot"}, []string{"voltage (V) vs time (s)  * in  + out", "0s", "2ms"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr", "method": "trapezoidal"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_netlist_handler, flags, nil, []string{write_test_netlist(t, tt.netlist)})
+			test_utils.AssertContains(t, cmd_netlist_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestCmdNetlistHandlerPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		netlist  string
+		expected string
+	}{
+		{"unsupported analysis", "Divider\nV1 in 0 10\nR1 in 0 1k\n.dc V1 0 10 1\n", "unsupported: netlist analysis .dc"},
+		{"floating node", "Floating\nV1 in 0 10\nC1 in out 1u\nR1 out x 1k\n", "invalid: netlist Floating cannot be solved - a node without a DC path to ground or a loop of voltage sources & inductors"},
+		{"voltage source loop", "Loop\nV1 in 0 10\nV2 in 0 5\n", "invalid: netlist Loop cannot be solved - a node without a DC path to ground or a loop of voltage sources & inductors"},
+		{"unsupported element", "Transistor\nQ1 c b 0 npn\n", "invalid or unsupported: element Q1"},
+		{"ac without node", "RC\nV1 in 0 AC 1\nR1 in out 1k\nC1 out 0 159n\n.ac lin 1 1k 1k\n", "invalid: .ac requires -node"},
+		{"invalid transient", "RC\nV1 in 0 1\nR1 in out 1k\nC1 out 0 1u\n.tran 1m\n", "invalid: transient 1m - expected tstep tstop [tstart [tmax]] [UIC]"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(cmd_netlist_handler, map[string]string{"format": "abbr"}, nil, []string{write_test_netlist(t, tt.netlist)})
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_netlist_handler(cmd)
+			})
+		})
+	}
+
+	for _, args := range [][]string{{}, {"a.cir", "b.cir"}} {
+		cmd := test_utils.CreateTestCommand(cmd_netlist_handler, map[string]string{"format": "abbr"}, nil, args)
+		test_utils.ExpectPanicContains(t, "arguments", func() {
+			cmd_netlist_handler(cmd)
+		})
+	}
+
+	cmd := test_utils.CreateTestCommand(cmd_netlist_handler, map[string]string{"format": "abbr"}, nil, []string{filepath.Join(t.TempDir(), "missing.cir")})
+	test_utils.ExpectPanicContains(t, "invalid: could not open netlist", func() {
+		cmd_netlist_handler(cmd)
+	})
+}
+
+//endregion Netlist Tests
 
-//region Missing Resistance Tests
+//region Network Tests
 
-func TestCmdMissingResistanceHandler(t *testing.T) {
+func TestCmdNetworkHandlers(t *testing.T) {
 	tests := []struct {
 		name     string
-		target   string
+		handler  func(*cli.Command) string
 		args     []string
+		explain  string
 		format   string
 		contains []string
 	}{
-		{
-			name:     "find missing resistance",
-			target:   "500",
-			args:     []string{"1k"},
-			format:   "abbr",
-			contains: []string{"resistance=1kΩ"},
-		},
-		{
-			name:     "raw format",
-			target:   "500",
-			args:     []string{"1k"},
-			format:   "raw",
-			contains: []string{"resistance=1000Ω"},
-		},
-		{
-			name:     "json format",
-			target:   "500",
-			args:     []string{"1k"},
-			format:   "json",
-			contains: []string{`"resistance":1000`, `"resistanceAbbreviated":"1kΩ"`},
-		},
+		{"resistors", cmd_resistance_handler, []string{"(1k + 2k2) || 4k7 || (10k + 10k)"}, "", "abbr", []string{"resistance=1.7383263985205732kΩ"}},
+		{"unquoted args", cmd_resistance_handler, []string{"1k", "+", "2k2"}, "", "abbr", []string{"resistance=3.2kΩ"}},
+		{"single pipe & units", cmd_resistance_handler, []string{"2kΩ | 2kΩ"}, "", "abbr", []string{"resistance=1kΩ"}},
+		{"parallel binds tighter", cmd_resistance_handler, []string{"1k + 2k || 2k"}, "", "abbr", []string{"resistance=2kΩ"}},
+		{"nested parenthesis", cmd_resistance_handler, []string{"((1k))"}, "", "abbr", []string{"resistance=1kΩ"}},
+		{"capacitors in series add reciprocals", cmd_capacitance_handler, []string{"(10n + 10n) || 4n7"}, "", "abbr", []string{"capacitance=9.700000000000001nF"}},
+		{"inductors", cmd_inductance_handler, []string{"10μH || 10μH + 4μ7H"}, "", "abbr", []string{"inductance=9.7μH"}},
+		{"explain", cmd_resistance_handler, []string{"(1k + 2k2) || 4k7 || (10k + 10k)"}, "true", "abbr", []string{"resistance=1.7383263985205732kΩ\nparallel=1.7383263985205732kΩ\n├─ series=3.2kΩ\n│  ├─ 1kΩ\n│  └─ 2.2kΩ\n├─ 4.7kΩ\n└─ series=20kΩ\n   ├─ 10kΩ\n   └─ 10kΩ"}},
+		{"explain raw format", cmd_capacitance_handler, []string{"10n || 4n7"}, "true", "raw", []string{"capacitance=0.000000014700000000000001F\nparallel=0.000000014700000000000001F\n├─ 0.00000001F\n└─ 0.000000004700000000000001F"}},
+		{"json format", cmd_resistance_handler, []string{"1k + 1k"}, "", "json", []string{`{"resistance":2000,"resistanceAbbreviated":"2kΩ"}`}},
+		{"explain json format", cmd_resistance_handler, []string{"1k + 1k"}, "true", "json", []string{`{"resistance":2000,"resistanceAbbreviated":"2kΩ","network":{"circuit":"series","value":2000,"valueAbbreviated":"2kΩ","children":[{"value":1000,"valueAbbreviated":"1kΩ"},{"value":1000,"valueAbbreviated":"1kΩ"}]}}`}},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			cmd := test_utils.CreateTestCommand(
-				cmd_missing_resistance_handler,
-				map[string]string{
-					"format": tt.format,
-					"target": tt.target,
-				},
-				nil,
-				tt.args,
-			)
-			result := cmd_missing_resistance_handler(cmd)
-			test_utils.AssertContains(t, result, tt.contains...)
+			cmd := test_utils.CreateTestCommand(tt.handler, map[string]string{"format": tt.format, "explain": tt.explain}, nil, tt.args)
+			test_utils.AssertContains(t, tt.handler(cmd), tt.contains...)
 		})
 	}
 }
 
-//endregion Missing Resistance Tests
+func TestCmdNetworkHandlersPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		handler  func(*cli.Command) string
+		args     []string
+		circuit  string
+		coupling []string
+		expected string
+	}{
+		{"unbalanced parenthesis", cmd_resistance_handler, []string{"(1k + 1k"}, "", nil, "invalid: network expression (1k + 1k - expected )"},
+		{"trailing operator", cmd_resistance_handler, []string{"1k +"}, "", nil, "invalid: network expression 1k + - expected a value at the end"},
+		{"leading operator", cmd_resistance_handler, []string{"|| 1k"}, "", nil, "invalid: network expression || 1k - unexpected ||"},
+		{"unexpected parenthesis", cmd_resistance_handler, []string{"1k + 1k)"}, "", nil, "invalid: network expression 1k + 1k) - unexpected )"},
+		{"empty parenthesis", cmd_resistance_handler, []string{"()"}, "", nil, "invalid: network expression () - unexpected )"},
+		{"coupled inductors", cmd_inductance_handler, []string{"10μH + 10μH"}, "", []string{"0.5"}, "unsupported: -coupling with a network expression"},
+		{"resistor circuit", cmd_resistance_handler, []string{"1k + 1k"}, "parallel", nil, "invalid: -circuit with a network expression - the expression connects the components"},
+		{"capacitor circuit", cmd_capacitance_handler, []string{"1n || 1n"}, "series", nil, "invalid: -circuit with a network expression - the expression connects the components"},
+		{"inductor circuit", cmd_inductance_handler, []string{"1μ + 1μ"}, "series", nil, "invalid: -circuit with a network expression - the expression connects the components"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			multiFlags := map[string][]string{}
+			if len(tt.coupling) > 0 {
+				multiFlags["coupling"] = tt.coupling
+			}
+
+			cmd := test_utils.CreateTestCommand(tt.handler, map[string]string{"format": "abbr", "circuit": tt.circuit}, multiFlags, tt.args)
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				tt.handler(cmd)
+			})
+		})
+	}
+}
+
+//endregion Network Tests
 
 //region Ohm's Law Tests
 
@@ -514,6 +1293,40 @@ func TestCmdOhmslawHandlerPanics(t *testing.T) {
 	}
 }
 
+func TestCmdOhmslawHandlerPackage(t *testing.T) {
+	tests := []struct {
+		name       string
+		voltage    string
+		resistance string
+		pkg        string
+		format     string
+		contains   []string
+	}{
+		{"within rating", "5V", "1k", "0402", "abbr", []string{"power=25mW package=0402 power_rating=62.5mW voltage_rating=50V warning=nil"}},
+		{"power exceeded", "10V", "1k", "0402", "abbr", []string{`power=100mW package=0402 power_rating=62.5mW voltage_rating=50V warning="power exceeds the 62.5mW rating of 0402"`}},
+		{"power & voltage exceeded", "100V", "100k", "0402", "abbr", []string{`warning="power exceeds the 62.5mW rating of 0402, voltage exceeds the 50V rating of 0402"`}},
+		{"voltage exceeded", "300V", "10M", "2512", "abbr", []string{`warning="voltage exceeds the 200V rating of 2512"`}},
+		{"metric package", "10V", "1k", "1608M", "abbr", []string{"package=0603 power_rating=100mW", "warning=nil"}},
+		{"package without voltage rating", "100V", "1M", "SOT-23", "abbr", []string{"voltage_rating=nil warning=nil"}},
+		{"raw format", "10V", "1k", "0402", "raw", []string{"power_rating=0.0625W voltage_rating=50V"}},
+		{"json format", "10V", "1k", "0402", "json", []string{`"powerAbbreviated":"100mW","package":"0402","powerRating":0.0625,"powerRatingAbbreviated":"62.5mW","voltageRating":50,"voltageRatingAbbreviated":"50V","warning":"power exceeds the 62.5mW rating of 0402"}`}},
+		{"json format within rating", "1V", "1k", "SOT-23", "json", []string{`"voltageRating":null,"voltageRatingAbbreviated":null,"warning":null}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := map[string]string{"format": tt.format, "voltage": tt.voltage, "package": tt.pkg}
+			cmd := test_utils.CreateTestCommand(cmd_ohmslaw_handler, flags, map[string][]string{"resistance": {tt.resistance}}, nil)
+			test_utils.AssertContains(t, cmd_ohmslaw_handler(cmd), tt.contains...)
+		})
+	}
+
+	cmd := test_utils.CreateTestCommand(cmd_ohmslaw_handler, map[string]string{"format": "abbr", "voltage": "5V", "package": "0608"}, map[string][]string{"resistance": {"1k"}}, nil)
+	test_utils.ExpectPanic(t, "invalid or unsupported: package 0608", func() {
+		cmd_ohmslaw_handler(cmd)
+	})
+}
+
 //endregion Ohm's Law Tests
 
 //region Resistance Tests
@@ -582,6 +1395,172 @@ func TestCmdResistanceHandler(t *testing.T) {
 
 //endregion Resistance Tests
 
+//region Thevenin Tests
+
+func TestCmdTheveninHandler(t *testing.T) {
+	divider := "Divider\nV1 in 0 10\nR1 in out 1k\nR2 out 0 4k\n.end\n"
+	rc := "RC low-pass\nV1 in 0 AC 1\nR1 in out 1k\nC1 out 0 159n\n.end\n"
+	lc := "LC\nV1 in 0 AC 1\nC1 in out 1u\nL1 out 0 1m\n.end\n"
+
+	tests := []struct {
+		name     string
+		netlist  string
+		flags    map[string]string
+		port     []string
+		contains []string
+	}{
+		{"divider", divider, nil, []string{"out"}, []string{"vth=8V rth=800Ω in=10mA rn=800Ω load=800Ω power=20mW"}},
+		{"port between nodes", divider, map[string]string{"format": "raw"}, []string{"out", "in"}, []string{"vth=-2V rth=800Ω in=-0.0025A rn=800Ω load=800Ω power=0.00125W"}},
+		{"bridge", "Bridge\nV1 in 0 10\nR1 in a 1k\nR2 a 0 1k\nR3 in b 1k\nR4 b 0 3k\n", nil, []string{"b", "a"}, []string{"vth=2.5V rth=1.25kΩ in=2mA rn=1.25kΩ load=1.25kΩ power=1.25mW"}},
+		{"norton source", "Norton\nI1 0 out 2m\nR1 out 0 1k\n", nil, []string{"out"}, []string{"vth=2V rth=1kΩ in=2mA"}},
+		{"json", divider, map[string]string{"format": "json"}, []string{"out"}, []string{`{"vth":8,"vthAbbreviated":"8V","rth":800,"rthAbbreviated":"800Ω","in":0.01,"inAbbreviated":"10mA","rn":800,"rnAbbreviated":"800Ω","load":800,"loadAbbreviated":"800Ω","power":0.02,"powerAbbreviated":"20mW"}`}},
+		{"ac", rc, map[string]string{"frequency": "1kHz"}, []string{"out"}, []string{"frequency=1kHz vth=707.4510619274477mV vth_phase=-44.972096663210095° zth=500.48700502227337-499.999762826052jΩ zth_magnitude=707.4510619274477Ω", "load=500.48700502227337+499.999762826052jΩ power=125.00000000000001μW"}},
+		{"ac json", rc, map[string]string{"frequency": "1kHz", "format": "json"}, []string{"out"}, []string{`{"frequency":1000,"frequencyAbbreviated":"1kHz","vth":{"real":0.5004870050222734,"imaginary":-0.49999976282605196,`, `"zth":{"real":500.48700502227337,"imaginary":-499.999762826052,"magnitude":707.4510619274477,"magnitudeAbbreviated":"707.4510619274477Ω","phase":-44.972096663210095}`}},
+		{"reactive port json", lc, map[string]string{"frequency": "1kHz", "format": "json"}, []string{"out"}, []string{`"power":null,"powerAbbreviated":null}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_thevenin_handler, flags, map[string][]string{"port": tt.port}, []string{write_test_netlist(t, tt.netlist)})
+			test_utils.AssertContains(t, cmd_thevenin_handler(cmd), tt.contains...)
+		})
+	}
+
+	// a port without a resistive part has no maximum power
+	cmd := test_utils.CreateTestCommand(cmd_thevenin_handler, map[string]string{"format": "abbr", "frequency": "1kHz"}, map[string][]string{"port": {"out"}}, []string{write_test_netlist(t, lc)})
+	test_utils.AssertEquals(t, cmd_thevenin_handler(cmd), "frequency=1kHz vth=41.101020870238095mV vth_phase=180° zth=0+6.541430637621548jΩ zth_magnitude=6.541430637621548Ω zth_phase=90° in=6.283185307179585mA in_phase=90° load=0-6.541430637621548jΩ")
+}
+
+func TestCmdTheveninHandlerNetwork(t *testing.T) {
+	cmd := test_utils.CreateTestCommand(cmd_thevenin_handler, map[string]string{"format": "abbr"}, nil, []string{"1k", "+", "2k", "||", "2k"})
+	test_utils.ExpectPanic(t, "unsupported: a network expression - a netlist with a source is required", func() {
+		cmd_thevenin_handler(cmd)
+	})
+
+	// a netlist with parentheses in its path is still a netlist
+	path := filepath.Join(t.TempDir(), "filter(v2).cir")
+	if err := os.WriteFile(path, []byte("Divider\nV1 in 0 10\nR1 in out 1k\nR2 out 0 4k\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	cmd = test_utils.CreateTestCommand(cmd_thevenin_handler, map[string]string{"format": "abbr"}, map[string][]string{"port": {"out"}}, []string{path})
+	test_utils.AssertContains(t, cmd_thevenin_handler(cmd), "vth=8V rth=800Ω")
+}
+
+func TestCmdTheveninHandlerPanics(t *testing.T) {
+	divider := "Divider\nV1 in 0 10\nR1 in out 1k\nR2 out 0 4k\n"
+
+	tests := []struct {
+		name     string
+		netlist  string
+		flags    map[string]string
+		port     []string
+		expected string
+	}{
+		{"missing port", divider, nil, nil, "invalid: a netlist requires -port - i.e. -port out for the port from out to ground"},
+		{"too many ports", divider, nil, []string{"in", "out", "0"}, "too many arguments: -port"},
+		{"unknown node", divider, nil, []string{"x"}, "invalid: node x not in netlist Divider"},
+		{"same node", divider, nil, []string{"out", "out"}, "invalid: port out,out - expected 2 different nodes"},
+		{"voltage source", divider, nil, []string{"in"}, "invalid: port across a voltage source - an Rth of 0Ω has no Norton equivalent"},
+		{"invalid frequency", divider, map[string]string{"frequency": "0Hz"}, []string{"out"}, "invalid: frequency 0Hz"},
+		{"ac without ac source", divider, map[string]string{"frequency": "1kHz"}, []string{"out"}, "invalid: netlist Divider without an AC source, i.e. V1 in 0 AC 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr"}, tt.flags)
+
+			multi := map[string][]string{}
+			if tt.port != nil {
+				multi["port"] = tt.port
+			}
+
+			cmd := test_utils.CreateTestCommand(cmd_thevenin_handler, flags, multi, []string{write_test_netlist(t, tt.netlist)})
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_thevenin_handler(cmd)
+			})
+		})
+	}
+
+	cmd := test_utils.CreateTestCommand(cmd_thevenin_handler, map[string]string{"format": "abbr"}, map[string][]string{"port": {"out"}}, []string{"1k || 1k"})
+	test_utils.ExpectPanic(t, "unsupported: a network expression - a netlist with a source is required", func() {
+		cmd_thevenin_handler(cmd)
+	})
+
+	for _, args := range [][]string{{}, {"a.cir", "b.cir"}} {
+		cmd := test_utils.CreateTestCommand(cmd_thevenin_handler, map[string]string{"format": "abbr"}, nil, args)
+		test_utils.ExpectPanicContains(t, "arguments", func() {
+			cmd_thevenin_handler(cmd)
+		})
+	}
+}
+
+//endregion Thevenin Tests
+
+//region Transient Tests
+
+func TestCmdTransientHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		contains []string
+	}{
+		{"rc step", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "1μ"}, []string{"time=0s in=0V out=0V\ntime=500.00000000000006μs in=1V out=393.5057180739055mV\ntime=1ms in=1V out=632.1664160789213mV\ntime=1.5ms in=1V out=776.9120839350102mV\ntime=2ms in=1V out=864.6990909212406mV"}},
+		{"rc step euler", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "1μ", "method": "euler"}, []string{"time=1ms in=1V out=625.2160748177607mV"}},
+		{"rl high-pass", map[string]string{"topology": "rl-highpass", "resistance": "1k", "inductance": "1m", "stop": "2μ", "step": "1μ"}, []string{"time=1μs in=1V out=367.83204242569667mV"}},
+		{"pulse", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "1μ", "source": "pulse", "frequency": "1k", "stop": "1m", "step": "250μ", "format": "csv"}, []string{"time,in,out\n0,0,0\n0.00025,1,0.22120503578377895\n0.0005,0,0.38850365066319265"}},
+		{"sine", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "1μ", "source": "sine", "frequency": "1k", "voltage": "2V", "stop": "1m", "step": "250μ"}, []string{"time=250.00000000000003μs in=2V out=290.7808861104659mV", "time=750μs in=-2V"}},
+		{"default step", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "1μ", "step": ""}, []string{"time=4μs", "time=2ms"}},
+		{"raw format", map[string]string{"topology": "rc-lowpass", "resistance": "1k", "capacitance": "1μ", "format": "raw"}, []string{"time=0.001s in=1V out=0.6321664160789213V"}},
+		{"json format", map[string]string{"topology": "rl-highpass", "resistance": "1k", "inductance": "1m", "stop": "2μ", "step": "1μ", "format": "json"}, []string{`{"analysis":"tran","title":"rl-highpass","points":[{"time":0,"timeAbbreviated":"0s","voltages":{"in":0,"out":0}},{"time":0.000001,"timeAbbreviated":"1μs","voltages":{"in":1,"out":0.36783204242569667}}`}},
+		{"plot format", map[string]string{"topology": "rlc-lowpass", "resistance": "1k", "capacitance": "1μ", "inductance": "1m", "format": "plot"}, []string{"voltage (V) vs time (s)  * in  + out", "1V ┤", "0V ┤+"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr", "source": "step", "voltage": "1V", "stop": "2m", "step": "500μ", "method": "trapezoidal"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_transient_handler, flags, nil, nil)
+			test_utils.AssertContains(t, cmd_transient_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestCmdTransientHandlerPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		expected string
+	}{
+		{"unknown topology", map[string]string{"topology": "lc-tank"}, "invalid or unsupported: topology lc-tank"},
+		{"missing component", map[string]string{"topology": "rl-lowpass", "resistance": "1k"}, "invalid: topology rl-lowpass requires -inductance"},
+		{"pulse without frequency", map[string]string{"source": "pulse"}, "invalid: -source pulse requires -frequency"},
+		{"unknown source", map[string]string{"source": "triangle"}, "invalid or unsupported: source triangle"},
+		{"zero stop", map[string]string{"stop": "0s"}, "invalid: stop 0s"},
+		{"too many steps", map[string]string{"step": "1n", "stop": "1s"}, "invalid: transient of more than 1000000 steps - a larger tstep or a smaller tstop"},
+		{"unknown method", map[string]string{"method": "gear"}, "invalid or unsupported: integration method gear"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr", "topology": "rc-lowpass", "resistance": "1k", "capacitance": "1μ", "source": "step", "voltage": "1V", "stop": "2m", "step": "500μ", "method": "trapezoidal"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_transient_handler, flags, nil, nil)
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_transient_handler(cmd)
+			})
+		})
+	}
+
+	cmd := test_utils.CreateTestCommand(cmd_transient_handler, map[string]string{"format": "abbr", "topology": "rc-lowpass", "resistance": "1k", "capacitance": "1μ", "stop": "2m"}, nil, []string{"extra"})
+	test_utils.ExpectPanic(t, "too many arguments: [args...]", func() {
+		cmd_transient_handler(cmd)
+	})
+}
+
+//endregion Transient Tests
+
 //region Voltage Divider Tests
 
 func TestCmdVoltageDividerHandler(t *testing.T) {
@@ -642,6 +1621,185 @@ func TestCmdVoltageDividerHandler(t *testing.T) {
 	}
 }
 
+func TestCmdVoltageDividerHandlerSweep(t *testing.T) {
+	tests := []struct {
+		name       string
+		resistors  []string
+		capacitors []string
+		sweep      string
+		format     string
+		contains   []string
+	}{
+		{"rc divider keeps the phase", []string{"1k"}, []string{"159n"}, "dec 1 100 10k", "abbr", []string{"frequency=100Hz magnitude=-0.04313005472805208dB phase=-5.705070360215296°\nfrequency=1kHz magnitude=-3.006071943492703dB phase=-44.972096663210095°\nfrequency=10kHz magnitude=-20.03483743605426dB phase=-84.28387880997566°"}},
+		{"resistive divider is flat", []string{"1k", "1k"}, nil, "lin 2 1 1Meg", "abbr", []string{"frequency=1Hz magnitude=-6.020599913279624dB phase=0°", "frequency=1MHz magnitude=-6.020599913279624dB phase=0°"}},
+		{"csv format", []string{"1k"}, []string{"159n"}, "lin 1 1k 1k", "csv", []string{"frequency,magnitude,phase\n1000,-3.006071943492703,-44.972096663210095"}},
+		{"plot format", []string{"1k"}, []string{"159n"}, "dec 10 10 100k", "plot", []string{"V(out) (dB) vs frequency (Hz)"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			multiFlags := map[string][]string{"resistance": tt.resistors}
+			if len(tt.capacitors) > 0 {
+				multiFlags["capacitance"] = tt.capacitors
+			}
+
+			cmd := test_utils.CreateTestCommand(cmd_voltage_divider_handler, map[string]string{"format": tt.format, "voltage": "1V", "sweep": tt.sweep}, multiFlags, nil)
+			test_utils.AssertContains(t, cmd_voltage_divider_handler(cmd), tt.contains...)
+		})
+	}
+
+	cmd := test_utils.CreateTestCommand(cmd_voltage_divider_handler, map[string]string{"format": "abbr", "voltage": "1V", "sweep": "dec 1 1 10", "frequency": "1k"}, map[string][]string{"resistance": {"1k"}, "capacitance": {"1n"}}, nil)
+	test_utils.ExpectPanic(t, "invalid: -frequency with -sweep - the sweep sets the frequencies", func() {
+		cmd_voltage_divider_handler(cmd)
+	})
+
+	cmd = test_utils.CreateTestCommand(cmd_voltage_divider_handler, map[string]string{"format": "plot", "voltage": "1V"}, map[string][]string{"resistance": {"1k", "1k"}}, nil)
+	test_utils.ExpectPanic(t, "unsupported: -format plot without -sweep", func() {
+		cmd_voltage_divider_handler(cmd)
+	})
+}
+
+func TestCmdVoltageDividerHandlerTaps(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		multi    map[string][]string
+		args     []string
+		contains []string
+	}{
+		{"multi-tap", nil, nil, []string{"10k", "4k7", "2k2"}, []string{"tap=1 voltage=4.899408284023668V\ntap=2 voltage=1.5621301775147929V"}},
+		{"multi-tap flags", nil, map[string][]string{"resistance": {"10k", "4k7", "2k2"}}, nil, []string{"tap=1 voltage=4.899408284023668V\ntap=2 voltage=1.5621301775147929V"}},
+		{"multi-tap json", map[string]string{"format": "json"}, nil, []string{"10k", "4k7", "2k2"}, []string{`{"taps":[{"tap":1,"voltage":4.899408284023668,"voltageAbbreviated":"4.899408284023668V"},{"tap":2,"voltage":1.5621301775147929,"voltageAbbreviated":"1.5621301775147929V"}]}`}},
+		{"multi-tap load", map[string]string{"format": "raw"}, map[string][]string{"load": {"100k"}}, []string{"10k", "4k7", "2k2"}, []string{"tap=1 voltage=4.879454695356318V\ntap=2 voltage=1.532798402173787V"}},
+		{"resistive load", map[string]string{"voltage": "3.3V"}, map[string][]string{"load": {"10k"}}, []string{"10k", "10k"}, []string{"voltage=1.0999999999999999V"}},
+		{"capacitive divider with capacitive load", nil, map[string][]string{"load": {"100μF"}}, []string{"100μF", "100μF"}, []string{"voltage=4V"}},
+		{"adc load", map[string]string{"voltage": "3.3V", "frequency": "100k"}, map[string][]string{"resistance": {"10k", "10k"}, "load": {"1M", "10pF"}}, nil, []string{"voltage=1.640989482318235V phase=-1.7904617362049084° real=1.6401883112027063V imaginary=-51.271677104261414mV"}},
+		{"rc at the corner frequency", map[string]string{"voltage": "1V", "format": "raw"}, map[string][]string{"resistance": {"1k"}, "capacitance": {"159n"}}, nil, []string{"voltage=0.7071067811865476V phase=-44.99999999999999° real=0.5000000000000001V imaginary=-0.5V"}},
+		{"rl", map[string]string{"voltage": "1V", "frequency": "1k"}, map[string][]string{"resistance": {"1k"}, "inductance": {"10m"}}, nil, []string{"voltage=62.70819398473762mV phase=86.40472622013182°"}},
+		{"rc json", map[string]string{"voltage": "1V", "frequency": "1k", "format": "json"}, map[string][]string{"resistance": {"1k"}, "capacitance": {"159n"}}, nil, []string{`{"voltage":0.7074510619274478,"voltageAbbreviated":"707.4510619274478mV","phase":-44.972096663210095,"real":0.5004870050222735,"imaginary":-0.4999997628260521}`}},
+		{"rlc at resonance", map[string]string{"voltage": "1V", "frequency": "5.0329k"}, nil, []string{"100", "10mH", "100nF"}, []string{"tap=1 voltage=26.653892086496832μV", "tap=2 voltage=3.162290986019216V"}},
+		{"sweep with load", map[string]string{"voltage": "1V", "sweep": "lin 1 1 1"}, map[string][]string{"load": {"10k"}}, []string{"10k", "10k"}, []string{"frequency=1Hz magnitude=-9.54242509439325dB phase=0°"}},
+		{"frequency of a resistive divider is ignored", map[string]string{"frequency": "1k"}, map[string][]string{"load": {"10k"}}, []string{"1k", "1k"}, []string{"voltage=5.714285714285714V"}},
+		{"lowercase units", map[string]string{"voltage": "1V", "frequency": "1k"}, nil, []string{"1k", "159nf"}, []string{"voltage=707.4510619274478mV phase=-44.972096663210095°"}},
+		{"lowercase henry", map[string]string{"voltage": "1V", "frequency": "1k"}, nil, []string{"1k", "10mh"}, []string{"voltage=62.70819398473762mV phase=86.40472622013182°"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr", "voltage": "12V"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_voltage_divider_handler, flags, tt.multi, tt.args)
+			test_utils.AssertContains(t, cmd_voltage_divider_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestCmdVoltageDividerHandlerPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		multi    map[string][]string
+		args     []string
+		expected string
+	}{
+		{"single component", nil, map[string][]string{"resistance": {"1k"}}, nil, "unsupported: voltage divider type"},
+		{"args & flags", nil, map[string][]string{"resistance": {"1k"}}, []string{"1k"}, "invalid: components as both args & flags - specify the divider with either"},
+		{"mixed without frequency", nil, map[string][]string{"resistance": {"1k"}, "capacitance": {"1n"}, "inductance": {"1m"}}, nil, "invalid: a divider of mixed components requires -frequency"},
+		{"invalid frequency", map[string]string{"frequency": "0Hz"}, nil, []string{"1k", "1nF"}, "invalid: frequency 0Hz"},
+		{"ambiguous component", map[string]string{"frequency": "1k"}, nil, []string{"1k", "100n"}, "invalid: ambiguous component 100n - suffix a capacitor with F & an inductor with H, i.e. 100nF"},
+		{"ambiguous load", nil, map[string][]string{"load": {"10p"}}, []string{"1k", "1k"}, "invalid: ambiguous component 10p - suffix a capacitor with F & an inductor with H, i.e. 10pF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr", "voltage": "12V"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_voltage_divider_handler, flags, tt.multi, tt.args)
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_voltage_divider_handler(cmd)
+			})
+		})
+	}
+}
+
+func TestCmdVoltageDividerHandlerDesign(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		expected []string
+	}{
+		{
+			"E96 within current",
+			map[string]string{"voltage": "12V", "vout": "3.3V", "series": "E96", "current-max": "100μA", "top": "2"},
+			[]string{"r1=137kΩ r2=52.3kΩ vout=3.3153724247226624V error=0.4658% current=63.391442155309036μA min=3.267598226635182V max=3.3635760640584125V\nr1=309kΩ r2=118kΩ vout=3.3161592505854802V error=0.4897% current=28.103044496487122μA"},
+		},
+		{
+			"feedback",
+			map[string]string{"vout": "5V", "vref": "0.8V", "series": "E24", "top": "1"},
+			[]string{"r1=430kΩ r2=82kΩ vout=4.995121951219512V error=-0.0976% current=9.75609756097561μA"},
+		},
+		{
+			"exact ratio keeps the divider drawing the least",
+			map[string]string{"voltage": "10V", "vout": "5V", "series": "E12", "top": "1"},
+			[]string{"r1=1MΩ r2=1MΩ vout=5V error=0% current=5.000000000000001μA min=4.5V max=5.5V"},
+		},
+		{
+			"json",
+			map[string]string{"voltage": "10V", "vout": "5V", "series": "E12", "top": "1", "format": "json"},
+			[]string{`[{"r1":1000000,"r1Abbreviated":"1MΩ","r2":1000000,"r2Abbreviated":"1MΩ","vout":5,"voutAbbreviated":"5V","error":0,"current":0.000005,"currentAbbreviated":"5.000000000000001μA","min":4.5,"max":5.5}]`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr", "design": "true"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_voltage_divider_handler, flags, nil, nil)
+			output := cmd_voltage_divider_handler(cmd)
+			for _, expected := range tt.expected {
+				test_utils.AssertContains(t, output, expected)
+			}
+		})
+	}
+}
+
+func TestCmdVoltageDividerHandlerDesignPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		args     []string
+		expected string
+	}{
+		{"components", nil, []string{"1k", "1k"}, "invalid: components with -design - the design picks the resistors"},
+		{"without vout", map[string]string{"vout": ""}, nil, "invalid: -design requires -vout"},
+		{"without voltage", map[string]string{"voltage": ""}, nil, "invalid: -design requires -voltage or -vref"},
+		{"vout above voltage", map[string]string{"vout": "15V"}, nil, "invalid: vout 15V - expected between 0 & -voltage"},
+		{"voltage & vref", map[string]string{"vref": "0.8V"}, nil, "invalid: -voltage with -vref - the feedback divider is across the output of the regulator"},
+		{"vref above vout", map[string]string{"voltage": "", "vref": "5V"}, nil, "invalid: vref 5V - expected between 0 & -vout"},
+		{"invalid series", map[string]string{"series": "E7"}, nil, "invalid or unsupported: series E7"},
+		{"invalid top", map[string]string{"top": "0"}, nil, "invalid: top 0"},
+		{"current too low", map[string]string{"current-max": "1nA"}, nil, "invalid: current-max 1nA - every E24 divider up to 1MΩ draws more"},
+		{"csv", map[string]string{"format": "csv"}, nil, "unsupported: -format csv with -design"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// an empty flag is unset
+			flags := test_utils.MergeFlags(map[string]string{"format": "abbr", "design": "true", "voltage": "12V", "vout": "3.3V", "series": "E24", "top": "5"}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_voltage_divider_handler, flags, nil, tt.args)
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_voltage_divider_handler(cmd)
+			})
+		})
+	}
+
+	cmd := test_utils.CreateTestCommand(cmd_voltage_divider_handler, map[string]string{"format": "abbr"}, nil, []string{"1k", "1k"})
+	test_utils.ExpectPanic(t, "invalid: a divider requires -voltage - the input voltage", func() {
+		cmd_voltage_divider_handler(cmd)
+	})
+}
+
 //region Voltage Divider Tests
 
 //region Helper Function Tests
@@ -717,4 +1875,26 @@ func TestCapacitanceInParallel(t *testing.T) {
 	}
 }
 
+func TestInductanceInSeries(t *testing.T) {
+	test_utils.AssertEquals(t, InductanceInSeries([]string{"4μ7H", "10μH", "4R7"}), 4.7000147000000005)
+}
+
+func TestInductanceInParallel(t *testing.T) {
+	test_utils.AssertEquals(t, InductanceInParallel([]string{"10μH", "10μH"}), 4.9999999999999996e-06)
+}
+
+func TestCoupledInductance(t *testing.T) {
+	// 2 coupled inductors in parallel - (L1L2 - M²) / (L1 + L2 ∓ 2M)
+	l1, l2, k := 10e-6, 22e-6, 0.3
+	m := k * math.Sqrt(l1*l2)
+
+	for _, sign := range []float64{1, -1} {
+		matrix := get_inductance_matrix([]float64{l1, l2}, []string{utils.FormatFloat(sign * k)})
+		expected := (l1*l2 - m*m) / (l1 + l2 - sign*2*m)
+		if got := coupled_inductance(matrix, true); math.Abs(got-expected) > 1e-18 {
+			t.Errorf("coupled_inductance() = %v, expected %v", got, expected)
+		}
+	}
+}
+
 //endregion Helper Function Tests
@@ -8,6 +8,13 @@ import (
 )
 
 func cmd_capacitance_handler(cmd *cli.Command) string {
+	if expression, ok := get_network_expression(cmd.Args); ok {
+		if cmd.IsFlagSet("circuit") {
+			panic("invalid: -circuit with a network expression - the expression connects the components")
+		}
+		return format_network(parse_network(expression, "capacitor"), "capacitor", cmd.IsFlagSet("explain"), cmd.GetFlagValue("format"))
+	}
+
 	capacitance := 0.
 	if cmd.GetFlagValue("circuit") == "parallel" {
 		capacitance = CapacitanceInParallel(cmd.Args)
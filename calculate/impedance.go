@@ -0,0 +1,173 @@
+package calculate
+
+import (
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/cli"
+	"gohm/solver"
+	"gohm/utils"
+	"math"
+	"math/cmplx"
+	"strings"
+)
+
+type impedance_point struct {
+	frequency float64
+	xc, xl    float64 // NaN without capacitors or inductors
+	impedance complex128
+}
+
+func cmd_impedance_handler(cmd *cli.Command) string {
+	components := get_components(cmd, "impedance")
+	if len(components) == 0 {
+		panic("too few arguments: [args...]")
+	}
+
+	circuit := cmd.GetFlagValue("circuit")
+	if circuit != "series" && circuit != "parallel" {
+		panic(fmt.Errorf("invalid or unsupported: circuit %s", circuit))
+	}
+
+	var frequencies []float64
+	switch {
+	case cmd.IsFlagSet("sweep") && cmd.IsFlagSet("frequency"):
+		panic("invalid: -frequency with -sweep - the sweep sets the frequencies")
+	case cmd.IsFlagSet("sweep"):
+		var err error
+		frequencies, err = solver.ParseSweep(strings.Fields(cmd.GetFlagValue("sweep")))
+		if err != nil {
+			panic(err)
+		}
+	case cmd.IsFlagSet("frequency"):
+		f := utils.ParseShorthand(cmd.GetFlagValue("frequency"), abbrvs.FREQUENCY)
+		if f <= 0 {
+			panic(fmt.Errorf("invalid: frequency %s", cmd.GetFlagValue("frequency")))
+		}
+		frequencies = []float64{f}
+	default:
+		panic("invalid: impedance requires -frequency or -sweep")
+	}
+
+	points := make([]impedance_point, len(frequencies))
+	for i, f := range frequencies {
+		points[i] = solve_impedance(components, circuit == "parallel", f)
+	}
+	return format_impedance(points, cmd.IsFlagSet("sweep"), cmd.GetFlagValue("format"))
+}
+
+// impedance_cancelled is how small the reactance of components cancelling out is relative to theirs before it is taken
+// as 0, i.e. the float noise left of an LC at resonance
+const impedance_cancelled = 1e-12
+
+// solve_impedance returns the impedance of the components in series or parallel at a frequency & the reactance of
+// their capacitors & inductors combined the same way. A parallel LC at resonance is an open circuit of an infinite
+// impedance
+func solve_impedance(components []*solver.Element, parallel bool, frequency float64) impedance_point {
+	omega := 2 * math.Pi * frequency
+
+	combine := func(kinds string) complex128 {
+		total, reactances, found := complex(0, 0), 0., false
+		for _, c := range components {
+			if !strings.ContainsRune(kinds, rune(c.Kind)) {
+				continue
+			}
+			z := component_impedance(c, omega)
+			if parallel {
+				z = 1 / z
+			}
+			total, reactances, found = total+z, reactances+math.Abs(imag(z)), true
+		}
+		if !found {
+			return cmplx.NaN()
+		}
+
+		if math.Abs(imag(total)) <= impedance_cancelled*reactances {
+			total = complex(real(total), 0)
+		}
+		if parallel && total == 0 {
+			return cmplx.Inf()
+		} else if parallel {
+			total = 1 / total
+		}
+		// + 0 turns the -0 of a pure reactance into 0, i.e. -0+37.5MjΩ
+		return complex(real(total)+0, imag(total)+0)
+	}
+
+	// reactances are magnitudes, i.e. Xc = 1/2πfC - the sign is in the impedance
+	return impedance_point{
+		frequency: frequency,
+		xc:        math.Abs(imag(combine("C"))),
+		xl:        math.Abs(imag(combine("L"))),
+		impedance: combine("RLC"),
+	}
+}
+
+// quality_factor returns the Q of an impedance - the ratio of its reactance to its resistance as an equivalent series
+// circuit, which is R/X for parallel components. The Q of a pure reactance, a short or an open circuit is NaN
+func quality_factor(z complex128) float64 {
+	if real(z) == 0 || cmplx.IsInf(z) {
+		return math.NaN()
+	}
+	return math.Abs(imag(z)) / real(z)
+}
+
+// format_impedance returns the reactances & the impedance in rectangular & polar form with its Q at every frequency
+func format_impedance(points []impedance_point, sweep bool, format string) string {
+	var sb strings.Builder
+
+	switch format {
+	case "json":
+		if sweep {
+			sb.WriteString(`{"points":[`)
+		}
+		for i, p := range points {
+			if i > 0 {
+				sb.WriteRune(',')
+			}
+			impedance := "null"
+			if !cmplx.IsInf(p.impedance) {
+				impedance = format_complex_json(p.impedance, "Ω")
+			}
+			fmt.Fprintf(&sb, `{"frequency":%s,"frequencyAbbreviated":"%sHz","xc":%s,"xl":%s,"impedance":%s,"q":%s}`,
+				utils.FormatFloat(p.frequency),
+				utils.GetAbbreviatedValue(p.frequency),
+				format_json_float(p.xc),
+				format_json_float(p.xl),
+				impedance,
+				format_json_float(quality_factor(p.impedance)),
+			)
+		}
+		if sweep {
+			sb.WriteString("]}")
+		}
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		for i, p := range points {
+			if i > 0 {
+				sb.WriteRune('\n')
+			}
+			fmt.Fprintf(&sb, "frequency=%sHz ", value(p.frequency))
+			if !math.IsNaN(p.xc) {
+				fmt.Fprintf(&sb, "xc=%sΩ ", value(p.xc))
+			}
+			if !math.IsNaN(p.xl) {
+				fmt.Fprintf(&sb, "xl=%sΩ ", value(p.xl))
+			}
+			if cmplx.IsInf(p.impedance) {
+				sb.WriteString("impedance=∞Ω")
+				continue
+			}
+			fmt.Fprintf(&sb, "impedance=%sΩ magnitude=%sΩ phase=%s°",
+				format_complex(p.impedance, value),
+				value(cmplx.Abs(p.impedance)),
+				utils.FormatFloat(phase_degrees(p.impedance)),
+			)
+			if q := quality_factor(p.impedance); !math.IsNaN(q) {
+				fmt.Fprintf(&sb, " q=%s", utils.FormatFloat(q))
+			}
+		}
+	}
+
+	return sb.String()
+}
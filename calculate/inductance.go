@@ -0,0 +1,223 @@
+package calculate
+
+import (
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/cli"
+	"gohm/utils"
+	"math"
+	"strconv"
+	"strings"
+)
+
+func cmd_inductance_handler(cmd *cli.Command) string {
+	if expression, ok := get_network_expression(cmd.Args); ok {
+		if cmd.IsFlagSet("circuit") {
+			panic("invalid: -circuit with a network expression - the expression connects the components")
+		}
+		if cmd.IsFlagSet("coupling") {
+			panic("unsupported: -coupling with a network expression")
+		}
+		return format_network(parse_network(expression, "inductor"), "inductor", cmd.IsFlagSet("explain"), cmd.GetFlagValue("format"))
+	}
+
+	parallel := cmd.GetFlagValue("circuit") == "parallel"
+
+	if cmd.ArgsLength == 0 {
+		panic("too few arguments: [args...]")
+	} else if parallel && cmd.ArgsLength < 2 {
+		panic("too few arguments: [args...] - requires at least 2")
+	}
+
+	inductance := 0.
+	if cmd.IsFlagSet("coupling") {
+		inductances := make([]float64, cmd.ArgsLength)
+		for i, v := range cmd.Args {
+			inductances[i] = utils.GetValueForRKMElseShorthand(v, abbrvs.RKM_HENRY, abbrvs.HENRY)
+		}
+		matrix := get_inductance_matrix(inductances, cmd.GetFlagValues("coupling"))
+		if !is_positive_semidefinite(matrix) {
+			panic("invalid: coupling coefficients")
+		}
+		inductance = coupled_inductance(matrix, parallel)
+	} else if parallel {
+		inductance = InductanceInParallel(cmd.Args)
+	} else {
+		inductance = InductanceInSeries(cmd.Args)
+	}
+
+	if inductance <= 0 || math.IsInf(inductance, 0) || math.IsNaN(inductance) {
+		panic("invalid inductance")
+	}
+
+	switch cmd.GetFlagValue("format") {
+	case "json":
+		return fmt.Sprintf(`{"inductance":%s,"inductanceAbbreviated":"%sH"}`, utils.FormatFloat(inductance), utils.GetAbbreviatedValue(inductance))
+	case "raw":
+		return fmt.Sprintf("inductance=%sH", utils.FormatFloat(inductance))
+	default:
+		return fmt.Sprintf("inductance=%sH", utils.GetAbbreviatedValue(inductance))
+	}
+}
+
+func InductanceInSeries(inductor_values []string) float64 {
+	inductance := 0.
+	for _, v := range inductor_values {
+		inductance += utils.GetValueForRKMElseShorthand(v, abbrvs.RKM_HENRY, abbrvs.HENRY)
+	}
+	return inductance
+}
+
+func InductanceInParallel(inductor_values []string) float64 {
+	inductance := 0.
+	for _, v := range inductor_values {
+		inductance += 1 / utils.GetValueForRKMElseShorthand(v, abbrvs.RKM_HENRY, abbrvs.HENRY)
+	}
+	return 1 / inductance
+}
+
+// get_inductance_matrix returns the inductance matrix of coupled inductors - the self inductances on the diagonal &
+// the mutual inductances M = k√(L1L2) off it
+//
+// A coupling is i:j=k between the i-th & j-th inductor (1-based) or k alone between 2 inductors. A negative k couples
+// the inductors in opposition, i.e. windings connected against their dots
+func get_inductance_matrix(inductances []float64, couplings []string) [][]float64 {
+	n := len(inductances)
+
+	matrix := make([][]float64, n)
+	for i := range matrix {
+		matrix[i] = make([]float64, n)
+		matrix[i][i] = inductances[i]
+	}
+
+	for _, c := range couplings {
+		i, j := 0, 1
+		value := c
+
+		if pair, k, ok := strings.Cut(c, "="); ok {
+			a, b, ok := strings.Cut(pair, ":")
+			if !ok {
+				panic(fmt.Errorf("invalid: coupling %s - expected i:j=k", c))
+			}
+			ai, err_a := strconv.Atoi(strings.TrimSpace(a))
+			bi, err_b := strconv.Atoi(strings.TrimSpace(b))
+			if err_a != nil || err_b != nil || ai < 1 || bi < 1 || ai > n || bi > n || ai == bi {
+				panic(fmt.Errorf("invalid: coupling %s - inductors are numbered 1 to %d", c, n))
+			}
+			i, j, value = ai-1, bi-1, k
+		} else if n != 2 {
+			panic(fmt.Errorf("invalid: coupling %s - expected i:j=k for more than 2 inductors", c))
+		}
+
+		k, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil || k < -1 || k > 1 {
+			panic(fmt.Errorf("invalid: coupling coefficient %s - expected -1 to 1", value))
+		}
+
+		m := k * math.Sqrt(inductances[i]*inductances[j])
+		matrix[i][j], matrix[j][i] = m, m
+	}
+
+	return matrix
+}
+
+// is_positive_semidefinite reports whether an inductance matrix is physical - the energy ½iᵀLi stored in coupled
+// inductors is never negative, so couplings that can not coexist, i.e. 3 inductors coupled by -0.9 pairwise, have no
+// circuit. Fully coupled inductors store no energy for some currents & are semidefinite
+//
+// Checked with a Cholesky factorisation - a pivot of 0 is a fully coupled inductor & has to leave the rest of its
+// column at 0 as well
+func is_positive_semidefinite(matrix [][]float64) bool {
+	n := len(matrix)
+
+	l := make([][]float64, n)
+	for i := range l {
+		l[i] = make([]float64, n)
+	}
+
+	for j := range n {
+		tolerance := 1e-9 * matrix[j][j]
+
+		pivot := matrix[j][j]
+		for k := range j {
+			pivot -= l[j][k] * l[j][k]
+		}
+		if pivot < -tolerance {
+			return false
+		}
+
+		for i := j + 1; i < n; i++ {
+			s := matrix[i][j]
+			for k := range j {
+				s -= l[i][k] * l[j][k]
+			}
+
+			if pivot <= tolerance {
+				if math.Abs(s) > 1e-9*math.Sqrt(matrix[i][i]*matrix[j][j]) {
+					return false
+				}
+				continue
+			}
+			l[i][j] = s / math.Sqrt(pivot)
+		}
+		if pivot > tolerance {
+			l[j][j] = math.Sqrt(pivot)
+		}
+	}
+
+	return true
+}
+
+// coupled_inductance is the equivalent inductance of coupled inductors
+//
+// In series the same current flows through every inductor, so the equivalent inductance is the sum of every entry of
+// the inductance matrix. In parallel every inductor has the same voltage, so it is 1 over the sum of every entry of the
+// inverse matrix - solved for with gaussian elimination
+func coupled_inductance(matrix [][]float64, parallel bool) float64 {
+	n := len(matrix)
+
+	if !parallel {
+		inductance := 0.
+		for i := range matrix {
+			for j := range matrix[i] {
+				inductance += matrix[i][j]
+			}
+		}
+		return inductance
+	}
+
+	// augmented matrix [L | 1]
+	a := make([][]float64, n)
+	for i := range matrix {
+		a[i] = append(append([]float64{}, matrix[i]...), 1)
+	}
+
+	for col := range n {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if math.Abs(a[row][col]) > math.Abs(a[pivot][col]) {
+				pivot = row
+			}
+		}
+		if math.Abs(a[pivot][col]) < 1e-30 {
+			panic("invalid: coupled inductors in parallel with a singular inductance matrix, i.e. a coupling of 1")
+		}
+		a[col], a[pivot] = a[pivot], a[col]
+
+		for row := range n {
+			if row == col {
+				continue
+			}
+			f := a[row][col] / a[col][col]
+			for k := col; k <= n; k++ {
+				a[row][k] -= f * a[col][k]
+			}
+		}
+	}
+
+	sum := 0.
+	for i := range n {
+		sum += a[i][n] / a[i][i]
+	}
+	return 1 / sum
+}
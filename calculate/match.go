@@ -0,0 +1,309 @@
+package calculate
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/cli"
+	"gohm/utils"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// match_tolerance_classes are the tolerance classes (%) parts are binned into, tightest first
+var match_tolerance_classes = []float64{.01, .02, .05, .1, .25, .5, 1, 2, 5, 10, 20}
+
+type match_part struct {
+	label string
+	value float64
+}
+
+type match_set struct {
+	parts  []match_part
+	mean   float64
+	spread float64 // %
+}
+
+type match_bin struct {
+	tolerance float64 // %, 0 when out of every class
+	parts     []match_part
+}
+
+func cmd_match_handler(cmd *cli.Command) string {
+	format := cmd.GetFlagValue("format")
+
+	rkm_target, targets, unit := abbrvs.RKM_RESISTOR, abbrvs.RESISTOR, "Ω"
+	switch cmd.GetFlagValue("component") {
+	case "resistor":
+	case "capacitor":
+		rkm_target, targets, unit = abbrvs.RKM_FARAD, abbrvs.FARAD, "F"
+	default:
+		panic(fmt.Errorf("invalid or unsupported: component %s", cmd.GetFlagValue("component")))
+	}
+
+	tolerance, err := strconv.ParseFloat(strings.TrimSuffix(strings.TrimSpace(cmd.GetFlagValue("tolerance")), "%"), 64)
+	if err != nil || tolerance <= 0 {
+		panic(fmt.Errorf("invalid: tolerance %s", cmd.GetFlagValue("tolerance")))
+	}
+
+	set_size, err := strconv.Atoi(cmd.GetFlagValue("set"))
+	if err != nil || set_size < 2 {
+		panic(fmt.Errorf("invalid: set %s - a set has at least 2 parts", cmd.GetFlagValue("set")))
+	}
+
+	parts := read_match_parts(cmd.GetFlagValue("input"), rkm_target, targets, unit)
+	if len(parts) < set_size {
+		panic(fmt.Errorf("too few parts: %d in %s for a set of %d", len(parts), cmd.GetFlagValue("input"), set_size))
+	}
+
+	nominal := 0.
+	if cmd.IsFlagSet("nominal") {
+		nominal = utils.GetValueForRKMElseShorthand(cmd.GetFlagValue("nominal"), rkm_target, targets)
+	} else {
+		values := make([]float64, len(parts))
+		for i, p := range parts {
+			values[i] = p.value
+		}
+		nominal = match_median(values)
+	}
+	if nominal <= 0 {
+		panic(fmt.Errorf("invalid: nominal %s", utils.FormatFloat(nominal)))
+	}
+
+	bins := get_match_bins(parts, nominal)
+	sets, unmatched := get_matched_sets(parts, set_size, tolerance)
+
+	return format_match(nominal, len(parts), bins, sets, unmatched, unit, format)
+}
+
+// read_match_parts reads the measured values of a csv or plain list, 1 part per line
+//
+// A header row selects the value column ("value") & an optional label column ("label", "id" or "part") - i.e. a csv
+// logged by gohm measure. Without a header the first column is the value & the second column the label. Unlabeled parts
+// are labeled by their position, i.e. #3
+func read_match_parts(path string, rkm_target rune, targets []string, unit string) []match_part {
+	file, err := os.Open(path)
+	if err != nil {
+		panic(fmt.Errorf("invalid: could not open input %s: %w", path, err))
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	records, err := reader.ReadAll()
+	if err != nil {
+		panic(fmt.Errorf("invalid: input %s: %w", path, err))
+	}
+
+	value_column, label_column := 0, 1
+	if len(records) > 0 && slices.ContainsFunc(records[0], func(f string) bool { return strings.EqualFold(strings.TrimSpace(f), "value") }) {
+		label_column = -1
+		for i, field := range records[0] {
+			switch strings.ToLower(strings.TrimSpace(field)) {
+			case "value":
+				value_column = i
+			case "label", "id", "part":
+				label_column = i
+			}
+		}
+		records = records[1:]
+	}
+
+	parts := []match_part{}
+	for _, record := range records {
+		if len(record) <= value_column || strings.TrimSpace(record[value_column]) == "" {
+			continue
+		}
+
+		value := utils.GetValueForRKMElseShorthand(strings.TrimSuffix(strings.TrimSpace(record[value_column]), unit), rkm_target, targets)
+		label := fmt.Sprintf("#%d", len(parts)+1)
+		if label_column != -1 && len(record) > label_column && strings.TrimSpace(record[label_column]) != "" {
+			label = strings.TrimSpace(record[label_column])
+		}
+
+		parts = append(parts, match_part{label: label, value: value})
+	}
+
+	return parts
+}
+
+func match_median(values []float64) float64 {
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	n := len(sorted)
+	if n%2 == 1 {
+		return sorted[n/2]
+	}
+	return (sorted[n/2-1] + sorted[n/2]) / 2
+}
+
+// get_match_bins puts every part into the tightest tolerance class around nominal it fits
+func get_match_bins(parts []match_part, nominal float64) []match_bin {
+	bins := make([]match_bin, len(match_tolerance_classes)+1)
+	for i, t := range match_tolerance_classes {
+		bins[i].tolerance = t
+	}
+
+	for _, p := range parts {
+		deviation := math.Abs(p.value-nominal) / nominal * 100
+
+		i := slices.IndexFunc(match_tolerance_classes, func(t float64) bool {
+			return deviation <= t
+		})
+		if i == -1 {
+			i = len(match_tolerance_classes)
+		}
+
+		bins[i].parts = append(bins[i].parts, p)
+	}
+
+	return slices.DeleteFunc(bins, func(b match_bin) bool {
+		return len(b.parts) == 0
+	})
+}
+
+// get_matched_sets repeatedly takes the set with the smallest spread out of the remaining parts, until no set is
+// within the tolerance
+//
+// The set with the smallest spread is always adjacent in value, so only windows of the sorted parts are compared
+func get_matched_sets(parts []match_part, set_size int, tolerance float64) ([]match_set, []match_part) {
+	remaining := slices.Clone(parts)
+	slices.SortStableFunc(remaining, func(a, b match_part) int {
+		if a.value < b.value {
+			return -1
+		} else if a.value > b.value {
+			return 1
+		}
+		return 0
+	})
+
+	sets := []match_set{}
+	for len(remaining) >= set_size {
+		best, best_spread := -1, math.Inf(1)
+		for i := 0; i+set_size <= len(remaining); i++ {
+			if spread := get_match_spread(remaining[i : i+set_size]); spread < best_spread {
+				best, best_spread = i, spread
+			}
+		}
+
+		if best_spread > tolerance {
+			break
+		}
+
+		set := match_set{
+			parts:  slices.Clone(remaining[best : best+set_size]),
+			spread: math.Round(best_spread*1e4) / 1e4,
+		}
+		for _, p := range set.parts {
+			set.mean += p.value / float64(set_size)
+		}
+
+		sets = append(sets, set)
+		remaining = slices.Delete(remaining, best, best+set_size)
+	}
+
+	return sets, remaining
+}
+
+// get_match_spread is the spread (%) of sorted parts relative to their mean
+func get_match_spread(parts []match_part) float64 {
+	mean := 0.
+	for _, p := range parts {
+		mean += p.value / float64(len(parts))
+	}
+	if mean == 0 {
+		panic("invalid: parts with a mean value of 0")
+	}
+
+	return (parts[len(parts)-1].value - parts[0].value) / mean * 100
+}
+
+func get_match_labels(parts []match_part) []string {
+	labels := make([]string, len(parts))
+	for i, p := range parts {
+		labels[i] = p.label
+	}
+	return labels
+}
+
+// format_json_labels returns the labels of the parts as an escaped json array
+func format_json_labels(parts []match_part) string {
+	labels, err := json.Marshal(get_match_labels(parts))
+	if err != nil {
+		panic(err)
+	}
+	return string(labels)
+}
+
+func format_match(nominal float64, count int, bins []match_bin, sets []match_set, unmatched []match_part, unit string, format string) string {
+	var sb strings.Builder
+
+	switch format {
+	case "json":
+		fmt.Fprintf(&sb, `{"nominal":%s,"nominalAbbreviated":"%s%s","parts":%d,"bins":[`, utils.FormatFloat(nominal), utils.GetAbbreviatedValue(nominal), unit, count)
+		for i, b := range bins {
+			fmt.Fprintf(&sb, `{"tolerance":%s,"parts":%s}`,
+				utils.If(b.tolerance != 0, utils.FormatFloat(b.tolerance), "null"),
+				format_json_labels(b.parts),
+			)
+			if i != len(bins)-1 {
+				sb.WriteRune(',')
+			}
+		}
+		sb.WriteString(`],"sets":[`)
+		for i, s := range sets {
+			values := make([]string, len(s.parts))
+			for j, p := range s.parts {
+				values[j] = utils.FormatFloat(p.value)
+			}
+			fmt.Fprintf(&sb, `{"parts":%s,"values":[%s],"mean":%s,"meanAbbreviated":"%s%s","spread":%s}`,
+				format_json_labels(s.parts),
+				strings.Join(values, ","),
+				utils.FormatFloat(s.mean),
+				utils.GetAbbreviatedValue(s.mean),
+				unit,
+				utils.FormatFloat(s.spread),
+			)
+			if i != len(sets)-1 {
+				sb.WriteRune(',')
+			}
+		}
+		fmt.Fprintf(&sb, `],"unmatched":%s}`, format_json_labels(unmatched))
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		fmt.Fprintf(&sb, "nominal=%s%s parts=%d", value(nominal), unit, count)
+		for _, b := range bins {
+			fmt.Fprintf(&sb, "\nbin=%s count=%d parts=%s",
+				utils.If(b.tolerance != 0, "±"+utils.FormatFloat(b.tolerance)+"%", "out"),
+				len(b.parts),
+				strings.Join(get_match_labels(b.parts), ","),
+			)
+		}
+		for i, s := range sets {
+			values := make([]string, len(s.parts))
+			for j, p := range s.parts {
+				values[j] = value(p.value) + unit
+			}
+			fmt.Fprintf(&sb, "\nset=%d parts=%s values=%s mean=%s%s spread=%s%%",
+				i+1,
+				strings.Join(get_match_labels(s.parts), ","),
+				strings.Join(values, ","),
+				value(s.mean),
+				unit,
+				utils.FormatFloat(s.spread),
+			)
+		}
+		fmt.Fprintf(&sb, "\nunmatched=%s", utils.If(len(unmatched) > 0, strings.Join(get_match_labels(unmatched), ","), "nil"))
+	}
+
+	return sb.String()
+}
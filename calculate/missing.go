@@ -0,0 +1,121 @@
+package calculate
+
+import (
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/cli"
+	"gohm/utils"
+	"math"
+)
+
+type component_kind struct {
+	quantity   string // name of the value, i.e. resistance
+	unit       string
+	rkm_target rune
+	targets    []string
+}
+
+var component_kinds = map[string]component_kind{
+	"resistor":  {"resistance", "Ω", abbrvs.RKM_RESISTOR, abbrvs.RESISTOR},
+	"capacitor": {"capacitance", "F", abbrvs.RKM_FARAD, abbrvs.FARAD},
+	"inductor":  {"inductance", "H", abbrvs.RKM_HENRY, abbrvs.HENRY},
+}
+
+func cmd_missing_handler(cmd *cli.Command) string {
+	format := cmd.GetFlagValue("format")
+	component, circuit := cmd.GetFlagValue("component"), cmd.GetFlagValue("circuit")
+
+	kind, ok := component_kinds[component]
+	if !ok {
+		panic(fmt.Errorf("invalid or unsupported: component %s", component))
+	}
+	if circuit != "series" && circuit != "parallel" {
+		panic(fmt.Errorf("invalid or unsupported: circuit %s", circuit))
+	}
+	if cmd.ArgsLength == 0 {
+		panic("too few arguments: [args...]")
+	}
+
+	target := utils.GetValueForRKMElseShorthand(cmd.GetFlagValue("target"), kind.rkm_target, kind.targets)
+	values := make([]float64, cmd.ArgsLength)
+	for i, v := range cmd.Args {
+		values[i] = utils.GetValueForRKMElseShorthand(v, kind.rkm_target, kind.targets)
+	}
+
+	reciprocal := is_reciprocal_sum(component, circuit)
+
+	exact := get_missing_value(target, values, reciprocal)
+	if exact <= 0 || math.IsInf(exact, 0) || math.IsNaN(exact) {
+		panic(fmt.Errorf("invalid %s - the %s of the args is already %s the target", kind.quantity, circuit, utils.If(reciprocal, "below", "above")))
+	}
+
+	missing, series := exact, ""
+	if cmd.IsFlagSet("series") {
+		series = cmd.GetFlagValue("series")
+		e, ok := utils.E_SERIES_MAPPING[series]
+		if !ok {
+			panic(fmt.Errorf("invalid or unsupported: series %s", series))
+		}
+		missing = utils.GetNearestESeriesValue(exact, e)
+	}
+
+	total := combine_values(append(values, missing), reciprocal)
+	// rounded to drop float noise, i.e. an error of -0.0000000000001%
+	deviation := math.Round((total-target)/target*100*1e4)/1e4 + 0
+
+	switch format {
+	case "json":
+		return fmt.Sprintf(`{"%s":%s,"%sAbbreviated":"%s%s","exact":%s,"exactAbbreviated":"%s%s","series":%s,"total":%s,"totalAbbreviated":"%s%s","error":%s}`,
+			kind.quantity,
+			utils.FormatFloat(missing),
+			kind.quantity,
+			utils.GetAbbreviatedValue(missing),
+			kind.unit,
+			utils.FormatFloat(exact),
+			utils.GetAbbreviatedValue(exact),
+			kind.unit,
+			utils.If(series != "", `"`+series+`"`, "null"),
+			utils.FormatFloat(total),
+			utils.GetAbbreviatedValue(total),
+			kind.unit,
+			utils.FormatFloat(deviation),
+		)
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		return fmt.Sprintf("%s=%s%s exact=%s%s series=%s total=%s%s error=%s%%",
+			kind.quantity,
+			value(missing),
+			kind.unit,
+			value(exact),
+			kind.unit,
+			utils.If(series != "", series, "nil"),
+			value(total),
+			kind.unit,
+			utils.FormatFloat(deviation),
+		)
+	}
+}
+
+// is_reciprocal_sum reports whether the values of a circuit add up as reciprocals - resistors & inductors in parallel
+// and capacitors in series
+func is_reciprocal_sum(component string, circuit string) bool {
+	return (component == "capacitor") != (circuit == "parallel")
+}
+
+func combine_values(values []float64, reciprocal bool) float64 {
+	total := 0.
+	for _, v := range values {
+		total += utils.If(reciprocal, 1/v, v)
+	}
+	return utils.If(reciprocal, 1/total, total)
+}
+
+// get_missing_value returns the value that completes the values to the target - negative, 0 or infinite when the values
+// already exceed it
+func get_missing_value(target float64, values []float64, reciprocal bool) float64 {
+	if reciprocal {
+		return 1 / (1/target - 1/combine_values(values, true))
+	}
+	return target - combine_values(values, false)
+}
@@ -0,0 +1,148 @@
+package calculate
+
+import (
+	"encoding/json"
+	"fmt"
+	"gohm/cli"
+	"gohm/solver"
+	"gohm/utils"
+	"os"
+	"strings"
+)
+
+func cmd_netlist_handler(cmd *cli.Command) string {
+	if cmd.ArgsLength == 0 {
+		panic("too few arguments: [args...]")
+	} else if cmd.ArgsLength > 1 {
+		panic("too many arguments: [args...]")
+	}
+
+	n := read_netlist(cmd.Args[0])
+	format := cmd.GetFlagValue("format")
+
+	analyses := n.Analyses
+	if len(analyses) == 0 {
+		analyses = []solver.Analysis{{Kind: "op"}}
+	}
+
+	results := []string{}
+	for _, a := range analyses {
+		switch a.Kind {
+		case "op":
+			op, err := solver.SolveOperatingPoint(n)
+			if err != nil {
+				panic(err)
+			}
+			results = append(results, format_operating_point(n, op, format))
+		case "ac":
+			if !cmd.IsFlagSet("node") {
+				panic("invalid: .ac requires -node")
+			}
+			results = append(results, format_ac_sweep(n, cmd.GetFlagValue("node"), solve_ac_sweep(n, strings.Join(a.Params, " ")), format))
+		case "tran":
+			params, err := solver.ParseTransient(a.Params)
+			if err != nil {
+				panic(err)
+			}
+			points, err := solver.SolveTransient(n, params, cmd.GetFlagValue("method"))
+			if err != nil {
+				panic(err)
+			}
+
+			nodes := n.Nodes
+			if cmd.IsFlagSet("node") {
+				nodes = []string{cmd.GetFlagValue("node")}
+			}
+			results = append(results, format_transient(n, nodes, points, format))
+		default:
+			panic(fmt.Errorf("unsupported: netlist analysis .%s", a.Kind))
+		}
+	}
+
+	switch {
+	case format == "json" && len(results) > 1:
+		return "[" + strings.Join(results, ",") + "]"
+	case format == "csv" || format == "plot":
+		return strings.Join(results, "\n\n")
+	default:
+		return strings.Join(results, "\n")
+	}
+}
+
+func read_netlist(path string) *solver.Netlist {
+	file, err := os.Open(path)
+	if err != nil {
+		panic(fmt.Errorf("invalid: could not open netlist %s: %w", path, err))
+	}
+	defer file.Close()
+
+	n, err := solver.ParseNetlist(file)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+// format_json_string returns a netlist title or name as an escaped json string
+func format_json_string(s string) string {
+	escaped, err := json.Marshal(s)
+	if err != nil {
+		panic(err)
+	}
+	return string(escaped)
+}
+
+func format_operating_point(n *solver.Netlist, op *solver.OperatingPoint, format string) string {
+	var sb strings.Builder
+
+	switch format {
+	case "json":
+		fmt.Fprintf(&sb, `{"analysis":"op","title":%s,"nodes":[`, format_json_string(n.Title))
+		for i, node := range n.Nodes {
+			fmt.Fprintf(&sb, `{"node":%s,"voltage":%s,"voltageAbbreviated":"%sV"}`, format_json_string(node), utils.FormatFloat(op.Voltages[node]), utils.GetAbbreviatedValue(op.Voltages[node]))
+			if i != len(n.Nodes)-1 {
+				sb.WriteRune(',')
+			}
+		}
+		sb.WriteString(`],"elements":[`)
+		for i, r := range op.Elements {
+			fmt.Fprintf(&sb, `{"element":%s,"voltage":%s,"voltageAbbreviated":"%sV","current":%s,"currentAbbreviated":"%sA","power":%s,"powerAbbreviated":"%sW"}`,
+				format_json_string(r.Element.Name),
+				utils.FormatFloat(r.Voltage),
+				utils.GetAbbreviatedValue(r.Voltage),
+				utils.FormatFloat(r.Current),
+				utils.GetAbbreviatedValue(r.Current),
+				utils.FormatFloat(r.Power),
+				utils.GetAbbreviatedValue(r.Power),
+			)
+			if i != len(op.Elements)-1 {
+				sb.WriteRune(',')
+			}
+		}
+		sb.WriteString("]}")
+	case "csv":
+		sb.WriteString("kind,name,voltage,current,power")
+		for _, node := range n.Nodes {
+			fmt.Fprintf(&sb, "\nnode,%s,%s,,", node, utils.FormatFloat(op.Voltages[node]))
+		}
+		for _, r := range op.Elements {
+			fmt.Fprintf(&sb, "\nelement,%s,%s,%s,%s", r.Element.Name, utils.FormatFloat(r.Voltage), utils.FormatFloat(r.Current), utils.FormatFloat(r.Power))
+		}
+	case "plot":
+		panic("unsupported: -format plot for the .op analysis")
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		for i, node := range n.Nodes {
+			if i > 0 {
+				sb.WriteRune('\n')
+			}
+			fmt.Fprintf(&sb, "node=%s voltage=%sV", node, value(op.Voltages[node]))
+		}
+		for _, r := range op.Elements {
+			fmt.Fprintf(&sb, "\nelement=%s voltage=%sV current=%sA power=%sW", r.Element.Name, value(r.Voltage), value(r.Current), value(r.Power))
+		}
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,225 @@
+package calculate
+
+import (
+	"fmt"
+	"gohm/utils"
+	"os"
+	"strings"
+)
+
+// network_node is a component ("" circuit) or a series or parallel combination of its children
+type network_node struct {
+	circuit  string
+	value    float64
+	children []*network_node
+}
+
+type network_parser struct {
+	expression string
+	tokens     []string
+	pos        int
+	component  string
+	kind       component_kind
+}
+
+// get_network_expression joins the args into a series-parallel expression when they contain an operator or
+// parenthesis, i.e. "(1k + 2k2) || 4k7" - the expression must be quoted as || & parentheses are shell syntax. A file
+// is never an expression, i.e. a netlist ./circuits/filter(v2).cir
+func get_network_expression(args []string) (string, bool) {
+	if len(args) == 1 {
+		if info, err := os.Stat(args[0]); err == nil && !info.IsDir() {
+			return args[0], false
+		}
+	}
+
+	expression := strings.Join(args, " ")
+	return expression, strings.ContainsAny(expression, "+|()")
+}
+
+// parse_network parses a series-parallel expression of component values into a tree
+//
+// + connects in series & || in parallel - || binds tighter than +, so 1k + 2k || 2k is 1k + (2k || 2k). Values
+// support RKM & shorthand, resistances also a lowercase k as in 4k7
+func parse_network(expression string, component string) *network_node {
+	p := &network_parser{
+		expression: expression,
+		tokens:     tokenize_network(expression),
+		component:  component,
+		kind:       component_kinds[component],
+	}
+
+	if len(p.tokens) == 0 {
+		panic(fmt.Errorf("invalid: network expression %s - empty", expression))
+	}
+
+	node := parse_network_series(p)
+	if p.pos < len(p.tokens) {
+		panic(fmt.Errorf("invalid: network expression %s - unexpected %s", expression, p.tokens[p.pos]))
+	}
+
+	return node
+}
+
+func tokenize_network(expression string) []string {
+	tokens := []string{}
+	value := strings.Builder{}
+
+	flush := func() {
+		if value.Len() > 0 {
+			tokens = append(tokens, value.String())
+			value.Reset()
+		}
+	}
+
+	for i := 0; i < len(expression); i++ {
+		switch c := expression[i]; {
+		case c == ' ' || c == '\t':
+			flush()
+		case c == '(' || c == ')' || c == '+':
+			flush()
+			tokens = append(tokens, string(c))
+		case c == '|':
+			flush()
+			// | & || are both parallel
+			if i+1 < len(expression) && expression[i+1] == '|' {
+				i++
+			}
+			tokens = append(tokens, "||")
+		default:
+			value.WriteByte(c)
+		}
+	}
+	flush()
+
+	return tokens
+}
+
+func parse_network_series(p *network_parser) *network_node {
+	return parse_network_combination(p, "series", "+", parse_network_parallel)
+}
+
+func parse_network_parallel(p *network_parser) *network_node {
+	return parse_network_combination(p, "parallel", "||", parse_network_operand)
+}
+
+// parse_network_combination parses operands joined by an operator into 1 node with a child per operand
+func parse_network_combination(p *network_parser, circuit string, operator string, parse_operand func(*network_parser) *network_node) *network_node {
+	first := parse_operand(p)
+	children := []*network_node{first}
+
+	for p.pos < len(p.tokens) && p.tokens[p.pos] == operator {
+		p.pos++
+		children = append(children, parse_operand(p))
+	}
+
+	if len(children) == 1 {
+		return first
+	}
+
+	values := make([]float64, len(children))
+	for i, c := range children {
+		values[i] = c.value
+	}
+
+	return &network_node{
+		circuit:  circuit,
+		value:    combine_values(values, is_reciprocal_sum(p.component, circuit)),
+		children: children,
+	}
+}
+
+func parse_network_operand(p *network_parser) *network_node {
+	if p.pos >= len(p.tokens) {
+		panic(fmt.Errorf("invalid: network expression %s - expected a value at the end", p.expression))
+	}
+
+	token := p.tokens[p.pos]
+	p.pos++
+
+	switch token {
+	case "(":
+		node := parse_network_series(p)
+		if p.pos >= len(p.tokens) || p.tokens[p.pos] != ")" {
+			panic(fmt.Errorf("invalid: network expression %s - expected )", p.expression))
+		}
+		p.pos++
+		return node
+	case ")", "+", "||":
+		panic(fmt.Errorf("invalid: network expression %s - unexpected %s", p.expression, token))
+	}
+
+	return &network_node{value: parse_network_value(token, p.component, p.kind)}
+}
+
+func parse_network_value(token string, component string, kind component_kind) float64 {
+	// a lowercase k is common in schematics, i.e. 4k7
+	if component == "resistor" {
+		if v, err := utils.ParseRKMCode(strings.Replace(token, "k", "K", 1), kind.rkm_target); err == nil {
+			return v
+		}
+	}
+
+	return utils.GetValueForRKMElseShorthand(strings.TrimSuffix(token, kind.unit), kind.rkm_target, kind.targets)
+}
+
+// format_network returns the value of the network and, with explain, the tree of every combination with its
+// intermediate value
+func format_network(node *network_node, component string, explain bool, format string) string {
+	kind := component_kinds[component]
+
+	switch format {
+	case "json":
+		return fmt.Sprintf(`{"%s":%s,"%sAbbreviated":"%s%s"%s}`,
+			kind.quantity,
+			utils.FormatFloat(node.value),
+			kind.quantity,
+			utils.GetAbbreviatedValue(node.value),
+			kind.unit,
+			utils.If(explain, `,"network":`+format_network_node_json(node, kind.unit), ""),
+		)
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "%s=%s%s", kind.quantity, value(node.value), kind.unit)
+		if explain {
+			write_network_tree(&sb, node, "", "", value, kind.unit)
+		}
+		return sb.String()
+	}
+}
+
+func write_network_tree(sb *strings.Builder, node *network_node, prefix string, child_prefix string, value func(float64) string, unit string) {
+	if node.circuit == "" {
+		fmt.Fprintf(sb, "\n%s%s%s", prefix, value(node.value), unit)
+		return
+	}
+
+	fmt.Fprintf(sb, "\n%s%s=%s%s", prefix, node.circuit, value(node.value), unit)
+	for i, c := range node.children {
+		if i == len(node.children)-1 {
+			write_network_tree(sb, c, child_prefix+"└─ ", child_prefix+"   ", value, unit)
+		} else {
+			write_network_tree(sb, c, child_prefix+"├─ ", child_prefix+"│  ", value, unit)
+		}
+	}
+}
+
+func format_network_node_json(node *network_node, unit string) string {
+	if node.circuit == "" {
+		return fmt.Sprintf(`{"value":%s,"valueAbbreviated":"%s%s"}`, utils.FormatFloat(node.value), utils.GetAbbreviatedValue(node.value), unit)
+	}
+
+	children := make([]string, len(node.children))
+	for i, c := range node.children {
+		children[i] = format_network_node_json(c, unit)
+	}
+
+	return fmt.Sprintf(`{"circuit":"%s","value":%s,"valueAbbreviated":"%s%s","children":[%s]}`,
+		node.circuit,
+		utils.FormatFloat(node.value),
+		utils.GetAbbreviatedValue(node.value),
+		unit,
+		strings.Join(children, ","),
+	)
+}
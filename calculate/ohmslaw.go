@@ -6,6 +6,7 @@ import (
 	"gohm/cli"
 	"gohm/utils"
 	"math"
+	"strings"
 )
 
 func cmd_ohmslaw_handler(cmd *cli.Command) string {
@@ -58,9 +59,20 @@ func cmd_ohmslaw_handler(cmd *cli.Command) string {
 		voltage = current * resistance
 	}
 
-	switch cmd.GetFlagValue("format") {
+	format := cmd.GetFlagValue("format")
+
+	package_suffix, package_json_suffix := "", ""
+	if cmd.IsFlagSet("package") {
+		p, ok := utils.GetPackage(cmd.GetFlagValue("package"))
+		if !ok {
+			panic(fmt.Errorf("invalid or unsupported: package %s", cmd.GetFlagValue("package")))
+		}
+		package_suffix, package_json_suffix = check_package_rating(p, voltage, power, format)
+	}
+
+	switch format {
 	case "json":
-		return fmt.Sprintf(`{"voltage":%s,"voltageAbbreviated":"%sV","current":%s,"currentAbbreviated":"%sA","resistance":%s,"resistanceAbbreviated":"%sΩ","power":%s,"powerAbbreviated":"%sW"}`,
+		return fmt.Sprintf(`{"voltage":%s,"voltageAbbreviated":"%sV","current":%s,"currentAbbreviated":"%sA","resistance":%s,"resistanceAbbreviated":"%sΩ","power":%s,"powerAbbreviated":"%sW"%s}`,
 			utils.FormatFloat(voltage),
 			utils.GetAbbreviatedValue(voltage),
 			utils.FormatFloat(current),
@@ -69,20 +81,55 @@ func cmd_ohmslaw_handler(cmd *cli.Command) string {
 			utils.GetAbbreviatedValue(resistance),
 			utils.FormatFloat(power),
 			utils.GetAbbreviatedValue(power),
+			package_json_suffix,
 		)
 	case "raw":
-		return fmt.Sprintf("voltage=%sV current=%sA resistance=%sΩ power=%sW",
+		return fmt.Sprintf("voltage=%sV current=%sA resistance=%sΩ power=%sW%s",
 			utils.FormatFloat(voltage),
 			utils.FormatFloat(current),
 			utils.FormatFloat(resistance),
 			utils.FormatFloat(power),
+			package_suffix,
 		)
 	default:
-		return fmt.Sprintf("voltage=%sV current=%sA resistance=%sΩ power=%sW",
+		return fmt.Sprintf("voltage=%sV current=%sA resistance=%sΩ power=%sW%s",
 			utils.GetAbbreviatedValue(voltage),
 			utils.GetAbbreviatedValue(current),
 			utils.GetAbbreviatedValue(resistance),
 			utils.GetAbbreviatedValue(power),
+			package_suffix,
 		)
 	}
 }
+
+// check_package_rating compares the power & voltage against the typical ratings of a package, returning the rating
+// & a warning when one is exceeded as a suffix of the output
+func check_package_rating(p *utils.Package, voltage float64, power float64, format string) (string, string) {
+	warnings := []string{}
+	if power > p.Power {
+		warnings = append(warnings, fmt.Sprintf("power exceeds the %sW rating of %s", utils.GetAbbreviatedValue(p.Power), p.Name))
+	}
+	if p.Voltage != 0 && math.Abs(voltage) > p.Voltage {
+		warnings = append(warnings, fmt.Sprintf("voltage exceeds the %sV rating of %s", utils.GetAbbreviatedValue(p.Voltage), p.Name))
+	}
+	warning := strings.Join(warnings, ", ")
+
+	value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+	suffix := fmt.Sprintf(" package=%s power_rating=%sW voltage_rating=%s warning=%s",
+		p.Name,
+		value(p.Power),
+		utils.If(p.Voltage != 0, value(p.Voltage)+"V", "nil"),
+		utils.If(warning != "", `"`+warning+`"`, "nil"),
+	)
+	json_suffix := fmt.Sprintf(`,"package":"%s","powerRating":%s,"powerRatingAbbreviated":"%sW","voltageRating":%s,"voltageRatingAbbreviated":%s,"warning":%s`,
+		p.Name,
+		utils.FormatFloat(p.Power),
+		utils.GetAbbreviatedValue(p.Power),
+		utils.If(p.Voltage != 0, utils.FormatFloat(p.Voltage), "null"),
+		utils.If(p.Voltage != 0, `"`+utils.GetAbbreviatedValue(p.Voltage)+`V"`, "null"),
+		utils.If(warning != "", `"`+warning+`"`, "null"),
+	)
+
+	return suffix, json_suffix
+}
@@ -8,6 +8,13 @@ import (
 )
 
 func cmd_resistance_handler(cmd *cli.Command) string {
+	if expression, ok := get_network_expression(cmd.Args); ok {
+		if cmd.IsFlagSet("circuit") {
+			panic("invalid: -circuit with a network expression - the expression connects the components")
+		}
+		return format_network(parse_network(expression, "resistor"), "resistor", cmd.IsFlagSet("explain"), cmd.GetFlagValue("format"))
+	}
+
 	resistance := 0.
 	if cmd.GetFlagValue("circuit") == "parallel" {
 		if cmd.ArgsLength < 2 {
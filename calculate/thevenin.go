@@ -0,0 +1,164 @@
+package calculate
+
+import (
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/cli"
+	"gohm/solver"
+	"gohm/utils"
+	"math"
+	"math/cmplx"
+)
+
+func cmd_thevenin_handler(cmd *cli.Command) string {
+	if cmd.ArgsLength == 0 {
+		panic("too few arguments: [args...]")
+	}
+
+	frequency := math.NaN()
+	if cmd.IsFlagSet("frequency") {
+		frequency = utils.ParseShorthand(cmd.GetFlagValue("frequency"), abbrvs.FREQUENCY)
+		if frequency <= 0 {
+			panic(fmt.Errorf("invalid: frequency %s", cmd.GetFlagValue("frequency")))
+		}
+	}
+
+	// a network expression is passive - without a source there is no voltage to transfer
+	if _, ok := get_network_expression(cmd.Args); ok {
+		panic("unsupported: a network expression - a netlist with a source is required")
+	} else if cmd.ArgsLength > 1 {
+		panic("too many arguments: [args...]")
+	}
+
+	n := read_netlist(cmd.Args[0])
+	pos, neg := get_thevenin_port(cmd)
+
+	var voltage, impedance complex128
+	var err error
+	if math.IsNaN(frequency) {
+		var v, r float64
+		v, r, err = solver.SolveThevenin(n, pos, neg)
+		voltage, impedance = complex(v, 0), complex(r, 0)
+	} else {
+		voltage, impedance, err = solver.SolveTheveninAC(n, pos, neg, frequency)
+	}
+	if err != nil {
+		panic(err)
+	}
+
+	if impedance == 0 {
+		panic("invalid: port across a voltage source - an Rth of 0Ω has no Norton equivalent")
+	}
+
+	if math.IsNaN(frequency) {
+		return format_thevenin(real(voltage), real(impedance), cmd.GetFlagValue("format"))
+	}
+	return format_thevenin_ac(frequency, voltage, impedance, cmd.GetFlagValue("format"))
+}
+
+// get_thevenin_port returns the nodes of the port - the second node is ground when left out
+func get_thevenin_port(cmd *cli.Command) (string, string) {
+	if !cmd.IsFlagSet("port") {
+		panic("invalid: a netlist requires -port - i.e. -port out for the port from out to ground")
+	}
+
+	nodes := cmd.GetFlagValues("port")
+	switch len(nodes) {
+	case 1:
+		return nodes[0], "0"
+	case 2:
+		return nodes[0], nodes[1]
+	default:
+		panic("too many arguments: -port")
+	}
+}
+
+// format_thevenin returns the Thevenin & Norton equivalent & the load of maximum power transfer - a load of Rth takes
+// Vth²/4Rth
+func format_thevenin(vth float64, rth float64, format string) string {
+	in := vth / rth
+	power := vth * vth / (4 * rth)
+
+	switch format {
+	case "json":
+		return fmt.Sprintf(`{"vth":%s,"vthAbbreviated":"%sV","rth":%s,"rthAbbreviated":"%sΩ","in":%s,"inAbbreviated":"%sA","rn":%s,"rnAbbreviated":"%sΩ","load":%s,"loadAbbreviated":"%sΩ","power":%s,"powerAbbreviated":"%sW"}`,
+			utils.FormatFloat(vth),
+			utils.GetAbbreviatedValue(vth),
+			utils.FormatFloat(rth),
+			utils.GetAbbreviatedValue(rth),
+			utils.FormatFloat(in),
+			utils.GetAbbreviatedValue(in),
+			utils.FormatFloat(rth),
+			utils.GetAbbreviatedValue(rth),
+			utils.FormatFloat(rth),
+			utils.GetAbbreviatedValue(rth),
+			utils.FormatFloat(power),
+			utils.GetAbbreviatedValue(power),
+		)
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+		return fmt.Sprintf("vth=%sV rth=%sΩ in=%sA rn=%sΩ load=%sΩ power=%sW", value(vth), value(rth), value(in), value(rth), value(rth), value(power))
+	}
+}
+
+// format_thevenin_ac returns the Thevenin & Norton equivalent at a frequency & the load of maximum power transfer -
+// a load of the conjugate of Zth takes |Vth|²/8Re(Zth), as the AC values of the sources are peak values. A port
+// without a resistive part has no maximum power - power is left out & null in json
+func format_thevenin_ac(frequency float64, vth complex128, zth complex128, format string) string {
+	in := vth / zth
+	load := cmplx.Conj(zth)
+	power := math.NaN()
+	if real(zth) > 0 {
+		power = math.Pow(cmplx.Abs(vth), 2) / (8 * real(zth))
+	}
+
+	switch format {
+	case "json":
+		return fmt.Sprintf(`{"frequency":%s,"frequencyAbbreviated":"%sHz","vth":%s,"zth":%s,"in":%s,"load":%s,"power":%s,"powerAbbreviated":%s}`,
+			utils.FormatFloat(frequency),
+			utils.GetAbbreviatedValue(frequency),
+			format_complex_json(vth, abbrvs.VOLTAGE[1]),
+			format_complex_json(zth, "Ω"),
+			format_complex_json(in, abbrvs.CURRENT[3]),
+			format_complex_json(load, "Ω"),
+			format_json_float(power),
+			utils.If(math.IsNaN(power), "null", `"`+utils.GetAbbreviatedValue(power)+`W"`),
+		)
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+		return fmt.Sprintf("frequency=%sHz vth=%sV vth_phase=%s° zth=%sΩ zth_magnitude=%sΩ zth_phase=%s° in=%sA in_phase=%s° load=%sΩ%s",
+			value(frequency),
+			value(cmplx.Abs(vth)),
+			utils.FormatFloat(phase_degrees(vth)),
+			format_complex(zth, value),
+			value(cmplx.Abs(zth)),
+			utils.FormatFloat(phase_degrees(zth)),
+			value(cmplx.Abs(in)),
+			utils.FormatFloat(phase_degrees(in)),
+			format_complex(load, value),
+			utils.If(math.IsNaN(power), "", " power="+value(power)+"W"),
+		)
+	}
+}
+
+// format_complex formats a complex value in rectangular form, i.e. 500-500j
+func format_complex(z complex128, value func(float64) string) string {
+	return fmt.Sprintf("%s%s%sj", value(real(z)), utils.If(math.Signbit(imag(z)), "-", "+"), value(math.Abs(imag(z))))
+}
+
+// format_complex_json formats a complex value as a json object of its rectangular & polar form
+func format_complex_json(z complex128, unit string) string {
+	return fmt.Sprintf(`{"real":%s,"imaginary":%s,"magnitude":%s,"magnitudeAbbreviated":"%s%s","phase":%s}`,
+		utils.FormatFloat(real(z)),
+		utils.FormatFloat(imag(z)),
+		utils.FormatFloat(cmplx.Abs(z)),
+		utils.GetAbbreviatedValue(cmplx.Abs(z)),
+		unit,
+		utils.FormatFloat(phase_degrees(z)),
+	)
+}
+
+// phase_degrees returns the phase of a complex value in degrees
+func phase_degrees(z complex128) float64 {
+	return cmplx.Phase(z) * 180 / math.Pi
+}
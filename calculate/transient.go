@@ -0,0 +1,141 @@
+package calculate
+
+import (
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/cli"
+	"gohm/solver"
+	"gohm/utils"
+	"strings"
+)
+
+// transient_default_points is the number of steps of a transient without a -step
+const transient_default_points = 500
+
+func cmd_transient_handler(cmd *cli.Command) string {
+	if cmd.ArgsLength > 0 {
+		panic("too many arguments: [args...]")
+	}
+
+	topology := cmd.GetFlagValue("topology")
+	values := get_topology_values(cmd, topology)
+
+	voltage := utils.ParseShorthand(cmd.GetFlagValue("voltage"), abbrvs.VOLTAGE)
+	stop := utils.ParseShorthand(cmd.GetFlagValue("stop"), abbrvs.TIME)
+	if stop <= 0 {
+		panic(fmt.Errorf("invalid: stop %s", cmd.GetFlagValue("stop")))
+	}
+
+	step := stop / transient_default_points
+	if cmd.IsFlagSet("step") {
+		step = utils.ParseShorthand(cmd.GetFlagValue("step"), abbrvs.TIME)
+	}
+
+	params, err := solver.ParseTransient([]string{utils.FormatFloat(step), utils.FormatFloat(stop)})
+	if err != nil {
+		panic(err)
+	}
+
+	source := &solver.Element{Name: "V1", Kind: 'V', Nodes: []string{"in", "0"}, Waveform: get_transient_waveform(cmd, voltage)}
+	n := get_topology_netlist(topology, values, source)
+
+	points, err := solver.SolveTransient(n, params, cmd.GetFlagValue("method"))
+	if err != nil {
+		panic(err)
+	}
+
+	return format_transient(n, []string{"in", "out"}, points, cmd.GetFlagValue("format"))
+}
+
+// get_transient_waveform returns the source of a built-in filter - a step at 0, a square wave with a 50% duty cycle
+// or a sine of the voltage
+func get_transient_waveform(cmd *cli.Command, voltage float64) *solver.Waveform {
+	source := cmd.GetFlagValue("source")
+
+	frequency := 0.
+	if source == "pulse" || source == "sine" {
+		if !cmd.IsFlagSet("frequency") {
+			panic(fmt.Errorf("invalid: -source %s requires -frequency", source))
+		}
+		frequency = utils.ParseShorthand(cmd.GetFlagValue("frequency"), abbrvs.FREQUENCY)
+		if frequency <= 0 {
+			panic(fmt.Errorf("invalid: frequency %s", cmd.GetFlagValue("frequency")))
+		}
+	}
+
+	switch source {
+	case "step":
+		return &solver.Waveform{Kind: "PULSE", Params: []float64{0, voltage}}
+	case "pulse":
+		return &solver.Waveform{Kind: "PULSE", Params: []float64{0, voltage, 0, 0, 0, 1 / (2 * frequency), 1 / frequency}}
+	case "sine":
+		return &solver.Waveform{Kind: "SIN", Params: []float64{0, voltage, frequency}}
+	default:
+		panic(fmt.Errorf("invalid or unsupported: source %s", source))
+	}
+}
+
+// format_transient returns the voltage of the nodes at every step of a transient
+func format_transient(n *solver.Netlist, nodes []string, points []solver.TransientPoint, format string) string {
+	for _, node := range nodes {
+		if _, ok := points[0].Voltages[node]; !ok {
+			panic(fmt.Errorf("invalid: node %s not in netlist %s", node, n.Title))
+		}
+	}
+
+	var sb strings.Builder
+
+	switch format {
+	case "json":
+		fmt.Fprintf(&sb, `{"analysis":"tran","title":%s,"points":[`, format_json_string(n.Title))
+		for i, p := range points {
+			fmt.Fprintf(&sb, `{"time":%s,"timeAbbreviated":"%ss","voltages":{`, utils.FormatFloat(p.Time), utils.GetAbbreviatedValue(p.Time))
+			for j, node := range nodes {
+				fmt.Fprintf(&sb, `%s:%s`, format_json_string(node), utils.FormatFloat(p.Voltages[node]))
+				if j != len(nodes)-1 {
+					sb.WriteRune(',')
+				}
+			}
+			sb.WriteString("}}")
+			if i != len(points)-1 {
+				sb.WriteRune(',')
+			}
+		}
+		sb.WriteString("]}")
+	case "csv":
+		sb.WriteString("time," + strings.Join(nodes, ","))
+		for _, p := range points {
+			sb.WriteString("\n" + utils.FormatFloat(p.Time))
+			for _, node := range nodes {
+				sb.WriteString("," + utils.FormatFloat(p.Voltages[node]))
+			}
+		}
+	case "plot":
+		times := make([]float64, len(points))
+		series := make([]utils.PlotSeries, len(nodes))
+		for i, node := range nodes {
+			series[i] = utils.PlotSeries{Label: node, Values: make([]float64, len(points))}
+		}
+		for i, p := range points {
+			times[i] = p.Time
+			for j, node := range nodes {
+				series[j].Values[i] = p.Voltages[node]
+			}
+		}
+		sb.WriteString(utils.Plot(times, utils.PlotAxis{Label: "time", Unit: abbrvs.TIME[0]}, utils.PlotAxis{Label: "voltage", Unit: abbrvs.VOLTAGE[1]}, series, plot_width, plot_height))
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		for i, p := range points {
+			if i > 0 {
+				sb.WriteRune('\n')
+			}
+			fmt.Fprintf(&sb, "time=%ss", value(p.Time))
+			for _, node := range nodes {
+				fmt.Fprintf(&sb, " %s=%sV", node, value(p.Voltages[node]))
+			}
+		}
+	}
+
+	return sb.String()
+}
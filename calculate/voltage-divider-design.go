@@ -0,0 +1,221 @@
+package calculate
+
+import (
+	"cmp"
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/cli"
+	"gohm/utils"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// divider_design_min & divider_design_max bound the resistors of a designed divider - below 10Ω the divider is a load
+// of its own & above 1MΩ leakage & the input current of what it feeds shift the output
+const (
+	divider_design_min = 10.
+	divider_design_max = 1e6
+)
+
+type divider_design struct {
+	r1, r2   float64 // from the input to the tap & from the tap to ground
+	vout     float64
+	error    float64 // % of the target output
+	current  float64
+	min, max float64 // worst case output with both resistors at the tolerance of the series
+}
+
+// cmd_voltage_divider_design_handler searches the resistor pairs of an E series for the divider closest to -vout - a
+// divider from -voltage or the feedback divider of a regulator, which regulates its tap to -vref
+func cmd_voltage_divider_design_handler(cmd *cli.Command) string {
+	if cmd.ArgsLength > 0 || cmd.IsFlagSet("resistance") || cmd.IsFlagSet("inductance") || cmd.IsFlagSet("capacitance") || cmd.IsFlagSet("load") || cmd.IsFlagSet("sweep") {
+		panic("invalid: components with -design - the design picks the resistors")
+	}
+	format := cmd.GetFlagValue("format")
+	if format == "csv" || format == "plot" {
+		panic(fmt.Errorf("unsupported: -format %s with -design", format))
+	}
+
+	if !cmd.IsFlagSet("vout") {
+		panic("invalid: -design requires -vout")
+	}
+	vout := utils.ParseShorthand(cmd.GetFlagValue("vout"), abbrvs.VOLTAGE)
+
+	// the output of a divider is its tap, the output of a regulator is the top of its feedback divider
+	vin, vref := math.NaN(), math.NaN()
+	top, tap := 0., 0.
+	if cmd.IsFlagSet("vref") {
+		if cmd.IsFlagSet("voltage") {
+			panic("invalid: -voltage with -vref - the feedback divider is across the output of the regulator")
+		}
+		vref = utils.ParseShorthand(cmd.GetFlagValue("vref"), abbrvs.VOLTAGE)
+		if vref <= 0 || vref >= vout {
+			panic(fmt.Errorf("invalid: vref %s - expected between 0 & -vout", cmd.GetFlagValue("vref")))
+		}
+		top, tap = vout, vref
+	} else {
+		if !cmd.IsFlagSet("voltage") {
+			panic("invalid: -design requires -voltage or -vref")
+		}
+		vin = utils.ParseShorthand(cmd.GetFlagValue("voltage"), abbrvs.VOLTAGE)
+		if vout <= 0 || vout >= vin {
+			panic(fmt.Errorf("invalid: vout %s - expected between 0 & -voltage", cmd.GetFlagValue("vout")))
+		}
+		top, tap = vin, vout
+	}
+
+	series := cmd.GetFlagValue("series")
+	e, ok := utils.E_SERIES_MAPPING[series]
+	if !ok {
+		panic(fmt.Errorf("invalid or unsupported: series %s", series))
+	}
+
+	current_max := math.Inf(1)
+	if cmd.IsFlagSet("current-max") {
+		current_max = utils.ParseShorthand(cmd.GetFlagValue("current-max"), abbrvs.CURRENT)
+		if current_max <= 0 {
+			panic(fmt.Errorf("invalid: current-max %s", cmd.GetFlagValue("current-max")))
+		}
+	}
+
+	count, err := strconv.Atoi(cmd.GetFlagValue("top"))
+	if err != nil || count < 1 {
+		panic(fmt.Errorf("invalid: top %s", cmd.GetFlagValue("top")))
+	}
+
+	designs := get_divider_designs(e, top/tap-1, current_max, func(r1 float64, r2 float64) divider_design {
+		return get_divider_design(r1, r2, vin, vref, vout, utils.GetToleranceForESeries(series))
+	})
+	if len(designs) == 0 {
+		panic(fmt.Errorf("invalid: current-max %s - every %s divider up to %sΩ draws more", cmd.GetFlagValue("current-max"), series, utils.GetAbbreviatedValue(divider_design_max)))
+	}
+
+	return format_divider_designs(designs[:min(count, len(designs))], format)
+}
+
+// get_divider_designs returns the dividers of the series closest to the ratio R1/R2 & within the current, the closest
+// first - for every R2 the values of the series either side of the exact R1. Dividers of the same ratio, i.e. 1k/2k &
+// 10k/20k, only differ in current & only the one drawing the least is kept
+func get_divider_designs(e []float64, ratio float64, current_max float64, design func(float64, float64) divider_design) []divider_design {
+	values := get_eseries_values(e)
+
+	// values of the same ratio are the same distance apart in the list & at the same place in their decade
+	best := map[[2]int]divider_design{}
+	for i, r2 := range values {
+		j, _ := slices.BinarySearch(values, r2*ratio)
+		for _, j := range []int{j - 1, j} {
+			if j < 0 || j >= len(values) {
+				continue
+			}
+			d := design(values[j], r2)
+			if d.current > current_max {
+				continue
+			}
+			// ascending R2 draws less & overwrites the dividers of the same ratio before it
+			best[[2]int{i % len(e), j - i}] = d
+		}
+	}
+
+	designs := make([]divider_design, 0, len(best))
+	for _, d := range best {
+		designs = append(designs, d)
+	}
+	slices.SortFunc(designs, func(a divider_design, b divider_design) int {
+		return cmp.Or(
+			cmp.Compare(math.Abs(a.error), math.Abs(b.error)),
+			cmp.Compare(a.current, b.current),
+			cmp.Compare(a.max-a.min, b.max-b.min),
+			cmp.Compare(a.r2, b.r2),
+		)
+	})
+	return designs
+}
+
+// get_eseries_values returns every value of the series from divider_design_min to divider_design_max, ascending
+func get_eseries_values(e []float64) []float64 {
+	// 3 figure series start at 100 & are scaled down a decade to start at 10Ω
+	divisor := utils.If(e[0] >= 100, 10., 1.)
+
+	values := []float64{}
+	for exponent := 0; ; exponent++ {
+		for _, s := range e {
+			v := s * math.Pow10(exponent) / divisor
+			if v > divider_design_max {
+				return values
+			}
+			values = append(values, v)
+		}
+	}
+}
+
+// get_divider_design returns the divider of R1 & R2 - from vin, or a feedback divider when vin is NaN
+func get_divider_design(r1 float64, r2 float64, vin float64, vref float64, target float64, tolerance float64) divider_design {
+	output := func(r1 float64, r2 float64) float64 {
+		if math.IsNaN(vin) {
+			return vref * (r1 + r2) / r2
+		}
+		return vin * r2 / (r1 + r2)
+	}
+
+	d := divider_design{r1: r1, r2: r2, vout: output(r1, r2)}
+	// rounded to drop float noise, i.e. an error of -0.0000000000001%
+	d.error = math.Round((d.vout-target)/target*100*1e4)/1e4 + 0
+	d.current = utils.If(math.IsNaN(vin), d.vout, vin) / (r1 + r2)
+
+	low, high := 1-tolerance/100, 1+tolerance/100
+	d.min, d.max = output(r1*high, r2*low), output(r1*low, r2*high)
+	if d.min > d.max {
+		d.min, d.max = d.max, d.min
+	}
+	return d
+}
+
+// format_divider_designs returns the dividers, the best first
+func format_divider_designs(designs []divider_design, format string) string {
+	var sb strings.Builder
+
+	switch format {
+	case "json":
+		sb.WriteRune('[')
+		for i, d := range designs {
+			if i > 0 {
+				sb.WriteRune(',')
+			}
+			fmt.Fprintf(&sb, `{"r1":%s,"r1Abbreviated":"%sΩ","r2":%s,"r2Abbreviated":"%sΩ","vout":%s,"voutAbbreviated":"%sV","error":%s,"current":%s,"currentAbbreviated":"%sA","min":%s,"max":%s}`,
+				utils.FormatFloat(d.r1),
+				utils.GetAbbreviatedValue(d.r1),
+				utils.FormatFloat(d.r2),
+				utils.GetAbbreviatedValue(d.r2),
+				utils.FormatFloat(d.vout),
+				utils.GetAbbreviatedValue(d.vout),
+				utils.FormatFloat(d.error),
+				utils.FormatFloat(d.current),
+				utils.GetAbbreviatedValue(d.current),
+				utils.FormatFloat(d.min),
+				utils.FormatFloat(d.max),
+			)
+		}
+		sb.WriteRune(']')
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		for i, d := range designs {
+			if i > 0 {
+				sb.WriteRune('\n')
+			}
+			fmt.Fprintf(&sb, "r1=%sΩ r2=%sΩ vout=%sV error=%s%% current=%sA min=%sV max=%sV",
+				value(d.r1),
+				value(d.r2),
+				value(d.vout),
+				utils.FormatFloat(d.error),
+				value(d.current),
+				value(d.min),
+				value(d.max),
+			)
+		}
+	}
+
+	return sb.String()
+}
@@ -4,48 +4,241 @@ import (
 	"fmt"
 	"gohm/abbrvs"
 	"gohm/cli"
+	"gohm/solver"
 	"gohm/utils"
 	"math"
+	"math/cmplx"
+	"strings"
 )
 
 func cmd_voltage_divider_handler(cmd *cli.Command) string {
+	if cmd.IsFlagSet("design") {
+		return cmd_voltage_divider_design_handler(cmd)
+	} else if !cmd.IsFlagSet("voltage") {
+		panic("invalid: a divider requires -voltage - the input voltage")
+	}
 	supply_voltage := utils.ParseShorthand(cmd.GetFlagValue("voltage"), abbrvs.VOLTAGE)
 
-	resistors := cmd.GetFlagValues("resistance")
-	len_resistors := len(resistors)
-	capacitors := cmd.GetFlagValues("capacitance")
-	len_capacitors := len(capacitors)
-
-	vout := 0.
-	if len_resistors == 2 {
-		r1 := utils.GetValueForRKMElseShorthand(resistors[0], abbrvs.RKM_RESISTOR, abbrvs.RESISTOR)
-		r2 := utils.GetValueForRKMElseShorthand(resistors[1], abbrvs.RKM_RESISTOR, abbrvs.RESISTOR)
-		vout = (r2 / (r1 + r2)) * supply_voltage
-	} else if len_capacitors == 2 {
-		c1 := utils.GetValueForRKMElseShorthand(capacitors[0], abbrvs.RKM_FARAD, abbrvs.FARAD)
-		c2 := utils.GetValueForRKMElseShorthand(capacitors[1], abbrvs.RKM_FARAD, abbrvs.FARAD)
-		vout = (c1 / (c1 + c2)) * supply_voltage
-	} else if len_resistors == 1 && len_capacitors == 1 {
-		r1 := utils.GetValueForRKMElseShorthand(resistors[0], abbrvs.RKM_RESISTOR, abbrvs.RESISTOR)
-		c1 := utils.GetValueForRKMElseShorthand(capacitors[0], abbrvs.RKM_FARAD, abbrvs.FARAD)
-
-		f := 1 / (2 * math.Pi * r1 * c1)
+	chain := get_voltage_divider_chain(cmd)
+	load := []*solver.Element{}
+	if cmd.IsFlagSet("load") {
+		for _, l := range cmd.GetFlagValues("load") {
+			load = append(load, parse_divider_component(l))
+		}
+	}
+
+	format := cmd.GetFlagValue("format")
+	if cmd.IsFlagSet("sweep") {
 		if cmd.IsFlagSet("frequency") {
-			f = utils.ParseShorthand(cmd.GetFlagValue("frequency"), abbrvs.FREQUENCY)
+			panic("invalid: -frequency with -sweep - the sweep sets the frequencies")
 		}
 
-		reactance := 1 / (2 * math.Pi * f * c1)
-		vout = supply_voltage * (reactance / (r1 + reactance))
-	} else {
+		n := get_voltage_divider_netlist(supply_voltage, chain, load)
+		return format_ac_sweep(n, "out", solve_ac_sweep(n, cmd.GetFlagValue("sweep")), format)
+	} else if format == "csv" || format == "plot" {
+		panic(fmt.Errorf("unsupported: -format %s without -sweep", format))
+	}
+
+	omega, phasors := get_voltage_divider_omega(cmd, chain, load)
+	return format_voltage_divider(solve_voltage_divider(supply_voltage, chain, load, omega), phasors, format)
+}
+
+// get_voltage_divider_chain returns the components of the divider from the input to ground
+func get_voltage_divider_chain(cmd *cli.Command) []*solver.Element {
+	chain := get_components(cmd, "divider")
+	if len(chain) < 2 {
 		panic("unsupported: voltage divider type")
 	}
+	return chain
+}
+
+// get_components returns the components of a divider or impedance - the args in order or the -resistance,
+// -inductance & -capacitance flags, resistors first & capacitors last, i.e. an RC or RLC low-pass
+func get_components(cmd *cli.Command, of string) []*solver.Element {
+	components := []*solver.Element{}
 
-	switch cmd.GetFlagValue("format") {
+	if cmd.ArgsLength > 0 {
+		if cmd.IsFlagSet("resistance") || cmd.IsFlagSet("inductance") || cmd.IsFlagSet("capacitance") {
+			panic(fmt.Errorf("invalid: components as both args & flags - specify the %s with either", of))
+		}
+		for _, arg := range cmd.Args {
+			components = append(components, parse_divider_component(arg))
+		}
+		return components
+	}
+
+	for _, kind := range []byte{'R', 'L', 'C'} {
+		flag := map[byte]string{'R': "resistance", 'C': "capacitance", 'L': "inductance"}[kind]
+		if !cmd.IsFlagSet(flag) {
+			continue
+		}
+		component := map[byte]string{'R': "resistor", 'C': "capacitor", 'L': "inductor"}[kind]
+		for _, v := range cmd.GetFlagValues(flag) {
+			components = append(components, &solver.Element{Kind: kind, Value: parse_network_value(v, component, component_kinds[component])})
+		}
+	}
+	return components
+}
+
+// parse_divider_component parses a component of a divider or its load - a value with an F (or f) suffix is a capacitor,
+// with an H (or h) suffix an inductor & a resistor otherwise, i.e. 4k7, 100nF or 10mH. A value without a unit & with a
+// prefix below 1, i.e. 100n, is rejected as it is far more likely a capacitor or an inductor than a resistor
+func parse_divider_component(value string) *solver.Element {
+	component, kind := "resistor", byte('R')
+	switch {
+	case strings.HasSuffix(value, "F") || strings.HasSuffix(value, "f"):
+		component, kind = "capacitor", 'C'
+	case strings.HasSuffix(value, "H") || strings.HasSuffix(value, "h"):
+		component, kind = "inductor", 'L'
+	case !strings.HasSuffix(value, "Ω") && strings.ContainsAny(value, "pnuμm"):
+		panic(fmt.Errorf("invalid: ambiguous component %s - suffix a capacitor with F & an inductor with H, i.e. %sF", value, value))
+	}
+
+	if kind != 'R' {
+		value = value[:len(value)-1] + component_kinds[component].unit
+	}
+	return &solver.Element{Kind: kind, Value: parse_network_value(value, component, component_kinds[component])}
+}
+
+// get_voltage_divider_omega returns the angular frequency of the divider & whether its tap voltages are phasors. A
+// divider of 1 kind of component, its load included, divides the same at any frequency. A resistor <-> capacitor
+// divider without -frequency is solved at its corner frequency
+func get_voltage_divider_omega(cmd *cli.Command, chain []*solver.Element, load []*solver.Element) (float64, bool) {
+	kinds := map[byte]bool{}
+	for _, c := range append(append([]*solver.Element{}, chain...), load...) {
+		kinds[c.Kind] = true
+	}
+
+	switch {
+	case len(kinds) == 1:
+		return 1, false
+	case cmd.IsFlagSet("frequency"):
+		f := utils.ParseShorthand(cmd.GetFlagValue("frequency"), abbrvs.FREQUENCY)
+		if f <= 0 {
+			panic(fmt.Errorf("invalid: frequency %s", cmd.GetFlagValue("frequency")))
+		}
+		return 2 * math.Pi * f, true
+	case len(chain) == 2 && len(load) == 0 && kinds['R'] && kinds['C']:
+		return 1 / (chain[0].Value * chain[1].Value), true
+	default:
+		panic("invalid: a divider of mixed components requires -frequency")
+	}
+}
+
+// component_impedance returns the impedance of a component at the angular frequency omega
+func component_impedance(c *solver.Element, omega float64) complex128 {
+	switch c.Kind {
+	case 'C':
+		return complex(0, -1/(omega*c.Value))
+	case 'L':
+		return complex(0, omega*c.Value)
+	default:
+		return complex(c.Value, 0)
+	}
+}
+
+// solve_voltage_divider returns the voltage of every tap of the divider - the tap after each component but the last,
+// the load across the last component
+func solve_voltage_divider(supply_voltage float64, chain []*solver.Element, load []*solver.Element, omega float64) []complex128 {
+	// the impedance below every tap, summed from ground up
+	below := make([]complex128, len(chain))
+	for i := len(chain) - 1; i >= 0; i-- {
+		z := component_impedance(chain[i], omega)
+		if i == len(chain)-1 {
+			for _, l := range load {
+				zl := component_impedance(l, omega)
+				z = z * zl / (z + zl)
+			}
+		} else {
+			z += below[i+1]
+		}
+		below[i] = z
+	}
+
+	taps := make([]complex128, len(chain)-1)
+	for i := range taps {
+		taps[i] = (below[i+1] / below[0]) * complex(supply_voltage, 0)
+	}
+	return taps
+}
+
+// format_voltage_divider returns the voltage of every tap - its magnitude, phase & real & imaginary part for phasors
+func format_voltage_divider(taps []complex128, phasors bool, format string) string {
+	var sb strings.Builder
+
+	switch format {
 	case "json":
-		return fmt.Sprintf(`{"voltage":%s,"voltageAbbreviated":"%sV"}`, utils.FormatFloat(vout), utils.GetAbbreviatedValue(vout))
-	case "raw":
-		return fmt.Sprintf("voltage=%sV", utils.FormatFloat(vout))
+		if len(taps) > 1 {
+			sb.WriteString(`{"taps":[`)
+		}
+		for i, v := range taps {
+			if len(taps) > 1 {
+				fmt.Fprintf(&sb, `{"tap":%d,`, i+1)
+			} else {
+				sb.WriteRune('{')
+			}
+			// a divider of 1 kind of component has no phase shift
+			voltage := utils.If(phasors, cmplx.Abs(v), real(v))
+			fmt.Fprintf(&sb, `"voltage":%s,"voltageAbbreviated":"%sV"`, utils.FormatFloat(voltage), utils.GetAbbreviatedValue(voltage))
+			if phasors {
+				fmt.Fprintf(&sb, `,"phase":%s,"real":%s,"imaginary":%s`, utils.FormatFloat(phase_degrees(v)), utils.FormatFloat(real(v)), utils.FormatFloat(imag(v)))
+			}
+			sb.WriteRune('}')
+			if i != len(taps)-1 {
+				sb.WriteRune(',')
+			}
+		}
+		if len(taps) > 1 {
+			sb.WriteString("]}")
+		}
 	default:
-		return fmt.Sprintf("voltage=%sV", utils.GetAbbreviatedValue(vout))
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		for i, v := range taps {
+			if i > 0 {
+				sb.WriteRune('\n')
+			}
+			if len(taps) > 1 {
+				fmt.Fprintf(&sb, "tap=%d ", i+1)
+			}
+			if !phasors {
+				fmt.Fprintf(&sb, "voltage=%sV", value(real(v)))
+				continue
+			}
+			fmt.Fprintf(&sb, "voltage=%sV phase=%s° real=%sV imaginary=%sV", value(cmplx.Abs(v)), utils.FormatFloat(phase_degrees(v)), value(real(v)), value(imag(v)))
+		}
+	}
+
+	return sb.String()
+}
+
+// get_voltage_divider_netlist builds the divider driven by an AC source of the supply voltage - the components from in
+// through the taps tap1, tap2, ... to the output node out & the load from out to ground
+func get_voltage_divider_netlist(supply_voltage float64, chain []*solver.Element, load []*solver.Element) *solver.Netlist {
+	elements := []*solver.Element{{Name: "V1", Kind: 'V', Nodes: []string{"in", "0"}, AC: complex(supply_voltage, 0)}}
+
+	count := map[byte]int{}
+	from := "in"
+	for i, c := range chain {
+		to := fmt.Sprintf("tap%d", i+1)
+		switch i {
+		case len(chain) - 2:
+			to = "out"
+		case len(chain) - 1:
+			to = "0"
+		}
+
+		count[c.Kind]++
+		elements = append(elements, &solver.Element{Name: fmt.Sprintf("%c%d", c.Kind, count[c.Kind]), Kind: c.Kind, Nodes: []string{from, to}, Value: c.Value})
+		from = to
+	}
+	for i, l := range load {
+		elements = append(elements, &solver.Element{Name: fmt.Sprintf("%cLOAD%d", l.Kind, i+1), Kind: l.Kind, Nodes: []string{"out", "0"}, Value: l.Value})
+	}
+
+	n, err := solver.NewNetlist("voltage-divider", elements)
+	if err != nil {
+		panic(err)
 	}
+	return n
 }
@@ -0,0 +1,45 @@
+package chart
+
+import (
+	"gohm/cli"
+	"gohm/identify"
+)
+
+func GetCommand() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "chart",
+		Description: "Print reference charts for component codes",
+	}
+
+	cmd.AddSubcommand(get_command_resistor())
+
+	return cmd
+}
+
+func get_command_resistor() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "resistor",
+		Description: "Print the IEC 60062 resistor color code, tolerance letter & temperature coefficient letter tables",
+		Handler:     cmd_resistor_handler,
+		Examples: []cli.Example{
+			{
+				Command: "gohm chart resistor",
+				Output: `  color   abbr digit multiplier tolerance letter tempco    letter
+      ▌ black   bk   0     ×1         -         -      250ppm/K  U
+      ▌ brown   bn   1     ×10        ±1%       F      100ppm/K  S
+      ...`,
+			},
+		},
+	}
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json"},
+	})
+	return cmd
+}
+
+func cmd_resistor_handler(cmd *cli.Command) string {
+	return identify.GetResistorChart(cmd.GetFlagValue("format"))
+}
@@ -22,6 +22,7 @@ type Flag struct {
 	Default        string
 	PossibleValues []string
 	IsMulti        bool
+	IsBoolean      bool // never consumes the next arg as its value
 	Required       bool
 	Value          string
 	Values         []string // all values when IsMulti is true
@@ -44,6 +45,7 @@ type Command struct {
 	Args        []string
 	ArgsLength  int
 	Handler     func(*Command) string
+	ExitCode    int // set by the handler to report a result, i.e. a failed check, through the process exit code
 	parent      *Command
 }
 
@@ -53,6 +55,7 @@ type CLI struct {
 	Version     string
 	Description string
 	Root        *Command
+	executed    *Command
 }
 
 func NewCLI(name, version, description string) *CLI {
@@ -160,6 +163,14 @@ func (c *CLI) Run(args []string) string {
 	return c.execute_command(c.Root, args)
 }
 
+// ExitCode returns the exit code reported by the handler of the last executed command
+func (c *CLI) ExitCode() int {
+	if c.executed == nil {
+		return 0
+	}
+	return c.executed.ExitCode
+}
+
 // parse_flags parses flags from args and returns remaining positional args
 // Flags must come either all before or all after positional args - no mixing allowed
 func (cmd *Command) parse_flags(args []string) []string {
@@ -178,10 +189,6 @@ func (cmd *Command) parse_flags(args []string) []string {
 		if strings.HasPrefix(arg, "-") {
 			flagName := strings.TrimLeft(arg, "-")
 
-			if state == stateFlagsAfterArgs {
-				panic("invalid: flags and arguments cannot be mixed - place all flags before or after arguments")
-			}
-
 			switch state {
 			case stateArgs:
 				state = stateFlagsAfterArgs
@@ -207,7 +214,7 @@ func (cmd *Command) parse_flags(args []string) []string {
 
 			// -flag value format
 			if f := cmd.GetFlag(flagName); f != nil {
-				if i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
+				if !f.IsBoolean && i+1 < len(args) && !strings.HasPrefix(args[i+1], "-") {
 					if f.IsSet && !f.IsMulti {
 						panic(fmt.Errorf("invalid: flag -%s specified multiple times but does not support multiple values", flagName))
 					}
@@ -405,6 +412,7 @@ func (c *CLI) find_target_command(cmd *Command, args []string) (*Command, []stri
 func (c *CLI) execute_command(cmd *Command, args []string) string {
 	if len(args) == 0 {
 		if cmd.Handler != nil {
+			c.executed = cmd
 			return cmd.Handler(cmd)
 		}
 		cmd.print_help()
@@ -419,6 +427,7 @@ func (c *CLI) execute_command(cmd *Command, args []string) string {
 	targetCmd.ArgsLength = len(targetCmd.Args)
 
 	if targetCmd.Handler != nil {
+		c.executed = targetCmd
 		return targetCmd.Handler(targetCmd)
 	}
 
@@ -0,0 +1,250 @@
+package identify
+
+import (
+	"fmt"
+	"gohm/utils"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// capacitor_voltage_mapping maps the letter of an EIA voltage code to its mantissa - the leading digit is the power of 10, i.e. 1H = 5 x 10^1 = 50V
+var capacitor_voltage_mapping = map[byte]float64{
+	'A': 1,
+	'B': 1.25,
+	'C': 1.6,
+	'D': 2,
+	'E': 2.5,
+	'F': 3.15,
+	'G': 4,
+	'H': 5,
+	'J': 6.3,
+	'K': 8,
+	'L': 5.5,
+	'P': 2.2,
+	'Q': 1.1,
+	'V': 3.5,
+	'W': 4.5,
+	'Z': 1.8,
+}
+
+// class 2 dielectric (EIA RS-198) - 1st character is the minimum temperature in °C
+var dielectric_class_2_temp_min_mapping = map[byte]int{
+	'X': -55,
+	'Y': -30,
+	'Z': 10,
+}
+
+// class 2 dielectric (EIA RS-198) - 2nd character is the maximum temperature in °C
+var dielectric_class_2_temp_max_mapping = map[byte]int{
+	'2': 45,
+	'4': 65,
+	'5': 85,
+	'6': 105,
+	'7': 125,
+	'8': 150,
+	'9': 200,
+}
+
+// class 2 dielectric (EIA RS-198) - 3rd character is the maximum capacitance change over the temperature range in %
+var dielectric_class_2_change_mapping = map[byte]letter_tolerance{
+	'A': {min: utils.Tolerance{Value: 1}, max: utils.Tolerance{Value: 1}},
+	'B': {min: utils.Tolerance{Value: 1.5}, max: utils.Tolerance{Value: 1.5}},
+	'C': {min: utils.Tolerance{Value: 2.2}, max: utils.Tolerance{Value: 2.2}},
+	'D': {min: utils.Tolerance{Value: 3.3}, max: utils.Tolerance{Value: 3.3}},
+	'E': {min: utils.Tolerance{Value: 4.7}, max: utils.Tolerance{Value: 4.7}},
+	'F': {min: utils.Tolerance{Value: 7.5}, max: utils.Tolerance{Value: 7.5}},
+	'P': {min: utils.Tolerance{Value: 10}, max: utils.Tolerance{Value: 10}},
+	'R': {min: utils.Tolerance{Value: 15}, max: utils.Tolerance{Value: 15}},
+	'S': {min: utils.Tolerance{Value: 22}, max: utils.Tolerance{Value: 22}},
+	'T': {min: utils.Tolerance{Value: 33}, max: utils.Tolerance{Value: 22}},
+	'U': {min: utils.Tolerance{Value: 56}, max: utils.Tolerance{Value: 22}},
+	'V': {min: utils.Tolerance{Value: 82}, max: utils.Tolerance{Value: 22}},
+}
+
+// class 1 dielectric (EIA RS-198) - 1st character is the significant figure of the temperature coefficient in ppm/K
+var dielectric_class_1_significant_mapping = map[byte]float64{
+	'C': 0,
+	'B': .3,
+	'L': .8,
+	'A': .9,
+	'M': 1,
+	'P': 1.5,
+	'R': 2.2,
+	'S': 3.3,
+	'T': 4.7,
+	'V': 5.6,
+	'U': 7.5,
+}
+
+// class 1 dielectric (EIA RS-198) - 2nd character is the multiplier of the temperature coefficient
+var dielectric_class_1_multiplier_mapping = map[byte]float64{
+	'0': -1,
+	'1': -10,
+	'2': -100,
+	'3': -1_000,
+	'4': -10_000,
+	'5': 1,
+	'6': 10,
+	'7': 100,
+	'8': 1_000,
+	'9': 10_000,
+}
+
+// class 1 dielectric (EIA RS-198) - 3rd character is the tolerance of the temperature coefficient in ppm/K
+var dielectric_class_1_tolerance_mapping = map[byte]float64{
+	'G': 30,
+	'H': 60,
+	'J': 120,
+	'K': 250,
+	'L': 500,
+	'M': 1_000,
+	'N': 2_500,
+}
+
+var dielectric_aliases = map[string]string{
+	"NP0": "C0G",
+	"NPO": "C0G",
+}
+
+type capacitor_dielectric struct {
+	code     string
+	class    int
+	temp_min int
+	temp_max int
+
+	// class 2 only - capacitance change in %
+	change *letter_tolerance
+
+	// class 1 only - temperature coefficient in ppm/K
+	temp_ce           float64
+	temp_ce_tolerance float64
+}
+
+func get_capacitor_voltage(code string) float64 {
+	if len(code) != 2 || !utils.IsDigit(code[0]) {
+		panic(fmt.Errorf("invalid: capacitor voltage code %s", code))
+	}
+
+	mantissa, ok := capacitor_voltage_mapping[code[1]]
+	if !ok {
+		panic(fmt.Errorf("invalid or unsupported: capacitor voltage identifier %s", string(code[1])))
+	}
+
+	voltage, _ := strconv.ParseFloat(strconv.FormatFloat(mantissa*math.Pow10(int(code[0]-'0')), 'g', 6, 64), 64)
+	return voltage
+}
+
+func get_capacitor_dielectric(code string) *capacitor_dielectric {
+	if alias, ok := dielectric_aliases[code]; ok {
+		code = alias
+	}
+
+	if len(code) != 3 {
+		panic(fmt.Errorf("invalid: capacitor dielectric code %s", code))
+	}
+
+	if temp_min, ok := dielectric_class_2_temp_min_mapping[code[0]]; ok {
+		temp_max, ok := dielectric_class_2_temp_max_mapping[code[1]]
+		if !ok {
+			panic(fmt.Errorf("invalid or unsupported: class 2 dielectric maximum temperature identifier %s", string(code[1])))
+		}
+
+		change, ok := dielectric_class_2_change_mapping[code[2]]
+		if !ok {
+			panic(fmt.Errorf("invalid or unsupported: class 2 dielectric capacitance change identifier %s", string(code[2])))
+		}
+
+		return &capacitor_dielectric{
+			code:     code,
+			class:    2,
+			temp_min: temp_min,
+			temp_max: temp_max,
+			change:   &change,
+		}
+	}
+
+	significant, ok := dielectric_class_1_significant_mapping[code[0]]
+	if !ok {
+		panic(fmt.Errorf("invalid or unsupported: dielectric identifier %s", string(code[0])))
+	}
+
+	multiplier, ok := dielectric_class_1_multiplier_mapping[code[1]]
+	if !ok {
+		panic(fmt.Errorf("invalid or unsupported: class 1 dielectric multiplier identifier %s", string(code[1])))
+	}
+
+	tolerance, ok := dielectric_class_1_tolerance_mapping[code[2]]
+	if !ok {
+		panic(fmt.Errorf("invalid or unsupported: class 1 dielectric tolerance identifier %s", string(code[2])))
+	}
+
+	temp_ce := 0.
+	if significant != 0 {
+		temp_ce, _ = strconv.ParseFloat(strconv.FormatFloat(significant*multiplier, 'g', 6, 64), 64)
+	}
+
+	return &capacitor_dielectric{
+		code:              code,
+		class:             1,
+		temp_min:          -55,
+		temp_max:          125,
+		temp_ce:           temp_ce,
+		temp_ce_tolerance: tolerance,
+	}
+}
+
+// get_capacitor_ratings decodes the voltage & dielectric codes that may follow a capacitance code and returns them formatted as a suffix for the capacitance output
+func get_capacitor_ratings(codes []string, format string) string {
+	voltage := -1.
+	var dielectric *capacitor_dielectric = nil
+
+	for _, code := range codes {
+		if len(code) == 2 {
+			if voltage != -1 {
+				panic(fmt.Errorf("invalid: multiple capacitor voltage codes %s", code))
+			}
+			voltage = get_capacitor_voltage(code)
+		} else {
+			if dielectric != nil {
+				panic(fmt.Errorf("invalid: multiple capacitor dielectric codes %s", code))
+			}
+			dielectric = get_capacitor_dielectric(code)
+		}
+	}
+
+	var sb strings.Builder
+
+	switch format {
+	case "json":
+		if voltage != -1 {
+			fmt.Fprintf(&sb, `,"voltage":%s,"voltageAbbreviated":"%sV"`, utils.FormatFloat(voltage), utils.GetAbbreviatedValue(voltage))
+		}
+		if dielectric != nil {
+			fmt.Fprintf(&sb, `,"dielectric":{"code":"%s","class":%d,"temperatureMin":%d,"temperatureMax":%d`, dielectric.code, dielectric.class, dielectric.temp_min, dielectric.temp_max)
+			if dielectric.class == 2 {
+				fmt.Fprintf(&sb, `,"capacitanceChangeMin":%s,"capacitanceChangeMax":%s}`, utils.FormatFloat(-dielectric.change.min.Value), utils.FormatFloat(dielectric.change.max.Value))
+			} else {
+				fmt.Fprintf(&sb, `,"temperatureCoefficient":%s,"temperatureCoefficientTolerance":%s}`, utils.FormatFloat(dielectric.temp_ce), utils.FormatFloat(dielectric.temp_ce_tolerance))
+			}
+		}
+	default:
+		if voltage != -1 {
+			fmt.Fprintf(&sb, " voltage=%sV", utils.If(format == "raw", utils.FormatFloat(voltage), utils.GetAbbreviatedValue(voltage)))
+		}
+		if dielectric != nil {
+			fmt.Fprintf(&sb, " dielectric=%s class=%d temp_range=%d°C..%d°C", dielectric.code, dielectric.class, dielectric.temp_min, dielectric.temp_max)
+			if dielectric.class == 2 {
+				if dielectric.change.min.Value == dielectric.change.max.Value {
+					fmt.Fprintf(&sb, " capacitance_change=±%s%%", utils.FormatFloat(dielectric.change.max.Value))
+				} else {
+					fmt.Fprintf(&sb, " capacitance_change=+%s%%/-%s%%", utils.FormatFloat(dielectric.change.max.Value), utils.FormatFloat(dielectric.change.min.Value))
+				}
+			} else {
+				fmt.Fprintf(&sb, " temp_coefficient=%s±%sppm/K", utils.FormatFloat(dielectric.temp_ce), utils.FormatFloat(dielectric.temp_ce_tolerance))
+			}
+		}
+	}
+
+	return sb.String()
+}
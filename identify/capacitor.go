@@ -2,27 +2,196 @@ package identify
 
 import (
 	"fmt"
+	"gohm/abbrvs"
 	"gohm/cli"
+	"gohm/measure"
 	"gohm/utils"
 	"math"
+	"slices"
+	"strconv"
+	"strings"
 )
 
+const (
+	CONVENTION_2_DIGIT            = "2-digit"
+	CONVENTION_3_DIGIT            = "3-digit"
+	CONVENTION_3_DIGIT_FRACTIONAL = "3-digit-fractional"
+	CONVENTION_R_DECIMAL          = "R-decimal"
+	CONVENTION_EIA_198            = "EIA-198"
+	CONVENTION_DATE_CODE          = "date-code"
+	CONVENTION_SMD_MARKING        = "smd-marking"
+)
+
+var capacitor_conventions = []string{
+	CONVENTION_2_DIGIT,
+	CONVENTION_3_DIGIT,
+	CONVENTION_3_DIGIT_FRACTIONAL,
+	CONVENTION_R_DECIMAL,
+	CONVENTION_EIA_198,
+	CONVENTION_DATE_CODE,
+	CONVENTION_SMD_MARKING,
+}
+
+// capacitor_candidate is 1 interpretation of a capacitor code
+//
+// date-code & smd-marking candidates do not encode a capacitance and are only ever offered as alternatives to a decodable value, unless explicitly forced
+type capacitor_candidate struct {
+	convention string
+	confidence float64
+	has_value  bool
+	nominal    float64 // F
+	tolerance  *letter_tolerance
+}
+
 func cmd_capacitor_handler(cmd *cli.Command) string {
 	format := cmd.GetFlagValue("format")
 
 	if cmd.IsFlagSet("eiac") {
-		return get_capacitance_from_eia(cmd.GetFlagValue("eiac"), format)
+		measured := new_measured_check(cmd, abbrvs.RKM_FARAD, abbrvs.FARAD, measure.QUANTITY_CAPACITANCE)
+		defer set_measured_exit_code(cmd, measured)
+
+		return get_capacitance_from_eia(cmd.GetFlagValue("eiac"), cmd.GetFlagValue("convention"), cmd.IsFlagSet("candidates"), measured, format)
+	}
+
+	if cmd.IsFlagSet("encode") {
+		capacitance := utils.GetValueForRKMElseShorthand(cmd.GetFlagValue("encode"), abbrvs.RKM_FARAD, abbrvs.FARAD)
+		return get_eia_from_capacitance(capacitance, cmd.GetFlagValue("tolerance"), format)
 	}
 
 	panic("unsupported: identify capacitor flags")
 }
 
-func get_capacitance_from_eia(val string, format string) string {
-	len_val := len(val)
+// get_capacitance_from_eia decodes the capacitance code and any optional voltage & dielectric codes that follow it - i.e. 104K 2A X7R
+//
+// Every plausible interpretation of the capacitance code is returned with its convention & confidence, ranked by
+// confidence, unless a convention is forced. Text lists only the most confident one without them unless all is set.
+// A measured value is checked against every candidate with a value - the most confident one decides the result
+func get_capacitance_from_eia(marking string, convention string, all bool, measured *measured_check, format string) string {
+	fields := strings.Fields(marking)
+	val := ""
+	if len(fields) > 0 {
+		val = fields[0]
+	}
+
+	candidates, err := get_capacitor_candidates(val)
+	if err != nil {
+		panic(err)
+	}
+
+	if convention != "" {
+		if !slices.Contains(capacitor_conventions, convention) {
+			panic(fmt.Errorf("invalid or unsupported: capacitor convention %s", convention))
+		}
 
-	result_pf := 0.
-	var result_tolerance_min *utils.Tolerance = nil
-	var result_tolerance_max *utils.Tolerance = nil
+		candidates = slices.DeleteFunc(candidates, func(c capacitor_candidate) bool {
+			return c.convention != convention
+		})
+		if len(candidates) == 0 {
+			panic(fmt.Errorf("invalid: %s is not a %s capacitor code", val, convention))
+		}
+		candidates[0].confidence = 1
+	}
+
+	ratings := get_capacitor_ratings(fields[min(1, len(fields)):], format)
+	if format != "json" && !all {
+		candidates = candidates[:1]
+	}
+
+	var sb strings.Builder
+
+	if format == "json" {
+		sb.WriteRune('[')
+	}
+
+	for i, c := range candidates {
+		nominal, actual_min, actual_max := trim_float_noise(c.nominal), 0., 0.
+		if c.tolerance != nil {
+			actual_min = trim_float_noise(c.nominal * (1 - c.tolerance.min.Value/100))
+			actual_max = trim_float_noise(c.nominal * (1 + c.tolerance.max.Value/100))
+		}
+
+		measured_suffix, measured_json_suffix := "", ""
+		if c.has_value {
+			tolerance := utils.If(c.tolerance != nil, c.tolerance, &letter_tolerance{min: default_tolerance, max: default_tolerance})
+			measured_suffix, measured_json_suffix = verify_measured(measured, c.nominal, trim_float_noise(c.nominal*(1-tolerance.min.Value/100)), trim_float_noise(c.nominal*(1+tolerance.max.Value/100)), format)
+		}
+
+		switch format {
+		case "json":
+			fmt.Fprintf(&sb, `{"nominal":%s,"nominalAbbreviated":%s,"actualMin":%s,"actualMinAbbreviated":%s,"actualMax":%s,"actualMaxAbbreviated":%s,"convention":"%s","confidence":%s%s}`,
+				utils.If(c.has_value, utils.FormatFloat(nominal), "null"),
+				utils.If(c.has_value, `"`+abbreviate_trimmed(nominal)+`F"`, "null"),
+				utils.If(c.tolerance != nil, utils.FormatFloat(actual_min), "null"),
+				utils.If(c.tolerance != nil, `"`+abbreviate_trimmed(actual_min)+`F"`, "null"),
+				utils.If(c.tolerance != nil, utils.FormatFloat(actual_max), "null"),
+				utils.If(c.tolerance != nil, `"`+abbreviate_trimmed(actual_max)+`F"`, "null"),
+				c.convention,
+				utils.FormatFloat(c.confidence),
+				measured_json_suffix+ratings,
+			)
+			if i != len(candidates)-1 {
+				sb.WriteRune(',')
+			}
+		case "raw":
+			fmt.Fprintf(&sb, "nominal=%s min=%s max=%s%s%s",
+				utils.If(c.has_value, utils.FormatFloat(nominal)+"F", "nil"),
+				utils.If(c.tolerance != nil, utils.FormatFloat(actual_min)+"F", "nil"),
+				utils.If(c.tolerance != nil, utils.FormatFloat(actual_max)+"F", "nil"),
+				utils.If(all, format_capacitor_candidate(c), ""),
+				measured_suffix+ratings,
+			)
+			if i != len(candidates)-1 {
+				sb.WriteRune('\n')
+			}
+		default:
+			fmt.Fprintf(&sb, "nominal=%s min=%s max=%s%s%s",
+				utils.If(c.has_value, abbreviate_trimmed(nominal)+"F", "nil"),
+				utils.If(c.tolerance != nil, abbreviate_trimmed(actual_min)+"F", "nil"),
+				utils.If(c.tolerance != nil, abbreviate_trimmed(actual_max)+"F", "nil"),
+				utils.If(all, format_capacitor_candidate(c), ""),
+				measured_suffix+ratings,
+			)
+			if i != len(candidates)-1 {
+				sb.WriteRune('\n')
+			}
+		}
+	}
+
+	if format == "json" {
+		sb.WriteRune(']')
+	}
+
+	return sb.String()
+}
+
+// format_capacitor_candidate returns the convention & confidence of a candidate for the text formats
+func format_capacitor_candidate(c capacitor_candidate) string {
+	return fmt.Sprintf(" convention=%s confidence=%s", c.convention, utils.FormatFloat(c.confidence))
+}
+
+// trim_float_noise rounds a value to 12 significant figures so a tolerance of 12nF is 12.012nF and not
+// 12.011999999999999nF
+func trim_float_noise(f float64) float64 {
+	trimmed, _ := strconv.ParseFloat(strconv.FormatFloat(f, 'g', 12, 64), 64)
+	return trimmed
+}
+
+// abbreviate_trimmed abbreviates a value & trims the float noise the scaling to its si prefix adds back
+func abbreviate_trimmed(f float64) string {
+	abbr := utils.GetAbbreviatedValue(f)
+	end := strings.LastIndexFunc(abbr, utils.IsDigit[rune]) + 1
+	v, err := strconv.ParseFloat(abbr[:end], 64)
+	if err != nil {
+		return abbr
+	}
+
+	return utils.FormatFloat(trim_float_noise(v)) + abbr[end:]
+}
+
+// get_capacitor_candidates returns every interpretation of a capacitor code ranked by confidence
+func get_capacitor_candidates(val string) ([]capacitor_candidate, error) {
+	candidates := []capacitor_candidate{}
+	len_val := len(val)
 
 	switch len_val {
 	case 2:
@@ -30,243 +199,230 @@ func get_capacitance_from_eia(val string, format string) string {
 		v2 := val[1]
 
 		if utils.IsLetter(v1) && utils.IsDigit(v2) {
-			// 2 digit EIA-198
-			switch v1 {
-			case 'A':
-				result_pf = 1
-			case 'B':
-				result_pf = 1.1
-			case 'C':
-				result_pf = 1.2
-			case 'D':
-				result_pf = 1.3
-			case 'E':
-				result_pf = 1.5
-			case 'F':
-				result_pf = 1.6
-			case 'G':
-				result_pf = 1.8
-			case 'H':
-				result_pf = 2
-			case 'J':
-				result_pf = 2.2
-			case 'K':
-				result_pf = 2.4
-			case 'L':
-				result_pf = 2.7
-			case 'M':
-				result_pf = 3
-			case 'N':
-				result_pf = 3.3
-			case 'P':
-				result_pf = 3.6
-			case 'Q':
-				result_pf = 3.9
-			case 'R':
-				result_pf = 4.3
-			case 'S':
-				result_pf = 4.7
-			case 'T':
-				result_pf = 5.1
-			case 'U':
-				result_pf = 5.6
-			case 'V':
-				result_pf = 6.2
-			case 'W':
-				result_pf = 6.8
-			case 'X':
-				result_pf = 7.5
-			case 'Y':
-				result_pf = 8.2
-			case 'Z':
-				result_pf = 9.1
-			case 'a':
-				result_pf = 2.6
-			case 'b':
-				result_pf = 3.5
-			case 'd':
-				result_pf = 4
-			case 'e':
-				result_pf = 4.5
-			case 'f':
-				result_pf = 5
-			case 'm':
-				result_pf = 6
-			case 'n':
-				result_pf = 7
-			case 't':
-				result_pf = 8
-			case 'y':
-				result_pf = 9
-			default:
-				panic(fmt.Errorf("invalid or unsupported: EIA-198 identifier %s", string(v1)))
+			c, err := decode_eia_198(val)
+			if err != nil {
+				return nil, err
 			}
 
-			result_pf = result_pf * math.Pow10(int(v2-'0'))
+			candidates = append(candidates, *c, capacitor_candidate{
+				convention: CONVENTION_SMD_MARKING,
+				confidence: .2,
+			})
 		} else if utils.IsDigit(v1) && utils.IsDigit(v2) {
-			result_pf = float64(int(v1-'0')*10 + int(v2-'0'))
+			candidates = append(candidates, capacitor_candidate{
+				convention: CONVENTION_2_DIGIT,
+				confidence: .7,
+				has_value:  true,
+				nominal:    float64(int(v1-'0')*10+int(v2-'0')) * utils.MATH_POW_PICO,
+			}, capacitor_candidate{
+				convention: CONVENTION_DATE_CODE,
+				confidence: .3,
+			})
 		} else {
-			panic(fmt.Errorf("invalid: capacitor value %s", val))
+			return nil, fmt.Errorf("invalid: capacitor value %s", val)
 		}
 	case 3, 4:
-		v1 := val[0]
-		v2 := val[1]
-		v3 := val[2]
+		c, err := decode_eia_3_digit(val)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, *c)
 
-		if !utils.IsDigit(v1) || !utils.IsDigit(v3) {
-			panic(fmt.Errorf("invalid: capacitor value %s", val))
+		// some manufacturers use a multiplier of 8 for x0.01 & 9 for x0.1 - 109 is far more likely 1pF than 10mF
+		if c.convention == CONVENTION_3_DIGIT && (val[2] == '8' || val[2] == '9') {
+			candidates[0].confidence = .2
+			candidates = append(candidates, capacitor_candidate{
+				convention: CONVENTION_3_DIGIT_FRACTIONAL,
+				confidence: .8,
+				has_value:  true,
+				nominal:    float64(int(val[0]-'0')*10+int(val[1]-'0')) / math.Pow10(int('9'-val[2])+1) * utils.MATH_POW_PICO,
+				tolerance:  c.tolerance,
+			})
 		}
+	default:
+		return nil, fmt.Errorf("unsupported: %d digit codes", len_val)
+	}
 
-		if utils.IsLetter(v2) {
-			if v2 != 'R' {
-				panic(fmt.Errorf("invalid or unsupported: capacitor decimal identifier %s", string(v2)))
-			}
+	total := 0.
+	for _, c := range candidates {
+		total += c.confidence
+	}
+	for i := range candidates {
+		candidates[i].confidence = math.Round(candidates[i].confidence/total*100) / 100
+	}
 
-			result_pf = float64(int(v1-'0')) + float64(int(v3-'0'))/10
-		} else if !utils.IsDigit(v2) {
-			panic(fmt.Errorf("invalid: capacitor identifier %s", string(v2)))
-		} else {
-			result_pf = float64(int(v1-'0')*10+int(v2-'0')) * math.Pow10(int(v3-'0'))
+	slices.SortStableFunc(candidates, func(a, b capacitor_candidate) int {
+		if a.confidence > b.confidence {
+			return -1
+		} else if a.confidence < b.confidence {
+			return 1
 		}
+		return 0
+	})
 
-		if len_val == 3 {
-			break
+	return candidates, nil
+}
+
+// decode_eia_198 decodes a 2 digit EIA-198 code - lowercase letters missing from the table are retried as uppercase with a lower confidence
+func decode_eia_198(val string) (*capacitor_candidate, error) {
+	confidence := .8
+	mantissa, ok := utils.EIA_198_MAPPING[val[0]]
+	if !ok && utils.IsLowerLetter(val[0]) {
+		mantissa, ok = utils.EIA_198_MAPPING[val[0]-'a'+'A']
+		confidence = .4
+	}
+	if !ok {
+		return nil, fmt.Errorf("invalid or unsupported: EIA-198 identifier %s", string(val[0]))
+	}
+
+	return &capacitor_candidate{
+		convention: CONVENTION_EIA_198,
+		confidence: confidence,
+		has_value:  true,
+		nominal:    mantissa * math.Pow10(int(val[1]-'0')) * utils.MATH_POW_PICO,
+	}, nil
+}
+
+// decode_eia_3_digit decodes a 3 digit or R-decimal code with an optional tolerance letter
+func decode_eia_3_digit(val string) (*capacitor_candidate, error) {
+	v1 := val[0]
+	v2 := val[1]
+	v3 := val[2]
+
+	if !utils.IsDigit(v1) || !utils.IsDigit(v3) {
+		return nil, fmt.Errorf("invalid: capacitor value %s", val)
+	}
+
+	c := &capacitor_candidate{
+		convention: CONVENTION_3_DIGIT,
+		confidence: 1,
+		has_value:  true,
+	}
+
+	if utils.IsLetter(v2) {
+		if v2 != 'R' {
+			return nil, fmt.Errorf("invalid or unsupported: capacitor decimal identifier %s", string(v2))
 		}
 
-		v4 := val[3]
+		c.convention = CONVENTION_R_DECIMAL
+		c.nominal = (float64(int(v1-'0')) + float64(int(v3-'0'))/10) * utils.MATH_POW_PICO
+	} else if !utils.IsDigit(v2) {
+		return nil, fmt.Errorf("invalid: capacitor identifier %s", string(v2))
+	} else {
+		c.nominal = float64(int(v1-'0')*10+int(v2-'0')) * math.Pow10(int(v3-'0')) * utils.MATH_POW_PICO
+	}
 
-		switch v4 {
-		case 'B':
-			result_tolerance_min = &utils.Tolerance{
-				Value:    .1,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-			result_tolerance_max = &utils.Tolerance{
-				Value:    .1,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-		case 'C':
-			result_tolerance_min = &utils.Tolerance{
-				Value:    .25,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-			result_tolerance_max = &utils.Tolerance{
-				Value:    .25,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-		case 'D':
-			result_tolerance_min = &utils.Tolerance{
-				Value:    .5,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-			result_tolerance_max = &utils.Tolerance{
-				Value:    .5,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-		case 'F':
-			result_tolerance_min = &utils.Tolerance{
-				Value:    1,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-			result_tolerance_max = &utils.Tolerance{
-				Value:    1,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-		case 'G':
-			result_tolerance_min = &utils.Tolerance{
-				Value:    2,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-			result_tolerance_max = &utils.Tolerance{
-				Value:    2,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-		case 'J':
-			result_tolerance_min = &utils.Tolerance{
-				Value:    5,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-			result_tolerance_max = &utils.Tolerance{
-				Value:    5,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-		case 'K':
-			result_tolerance_min = &utils.Tolerance{
-				Value:    10,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-			result_tolerance_max = &utils.Tolerance{
-				Value:    10,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-		case 'M':
-			result_tolerance_min = &utils.Tolerance{
-				Value:    20,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-			result_tolerance_max = &utils.Tolerance{
-				Value:    20,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-		case 'Z':
-			result_tolerance_min = &utils.Tolerance{
-				Value:    20,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-			result_tolerance_max = &utils.Tolerance{
-				Value:    80,
-				UnitType: utils.UNIT_TYPE_PERCENT,
-			}
-		default:
-			panic(fmt.Errorf("invalid or unsupported: capacitor tolerance identifier: %s", string(v4)))
+	if len(val) == 3 {
+		return c, nil
+	}
+
+	tolerance, ok := get_letter_tolerance(val[3])
+	if !ok {
+		return nil, fmt.Errorf("invalid or unsupported: capacitor tolerance identifier: %s", string(val[3]))
+	}
+	c.tolerance = &tolerance
+
+	return c, nil
+}
+
+type eia_marking struct {
+	code       string
+	convention string
+}
+
+// get_eia_from_capacitance is the inverse of get_capacitance_from_eia and returns every marking that decodes back to the given capacitance
+func get_eia_from_capacitance(val float64, tolerance string, format string) string {
+	if val <= 0 || math.IsInf(val, 0) || math.IsNaN(val) {
+		panic(fmt.Errorf("invalid: capacitance %s", utils.FormatFloat(val)))
+	}
+
+	if tolerance != "" {
+		if _, ok := get_letter_tolerance(tolerance[0]); len(tolerance) != 1 || !ok {
+			panic(fmt.Errorf("invalid or unsupported: capacitor tolerance identifier: %s", tolerance))
 		}
-	default:
-		panic(fmt.Errorf("unsupported: %d digit codes", len_val))
 	}
 
-	result_pf = result_pf * utils.MATH_POW_PICO
-	result_tolerance_min_pf, result_tolerance_max_pf := 0., 0.
+	// trim float noise so 4.7nF is treated as 4700pF and not 4700.000000000001pF
+	pf, _ := strconv.ParseFloat(strconv.FormatFloat(val/utils.MATH_POW_PICO, 'g', 9, 64), 64)
+	markings := []eia_marking{}
 
-	if result_tolerance_min != nil {
-		if result_tolerance_min.UnitType == utils.UNIT_TYPE_PERCENT {
-			result_tolerance_min_pf = result_pf * (1 - result_tolerance_min.Value/100)
-		} else {
-			result_tolerance_min_pf = result_pf - result_tolerance_min.Value
+	if pf >= 10 {
+		exp := int(math.Floor(math.Log10(pf))) - 1
+		mantissa := pf / math.Pow10(exp)
+		if exp <= 9 && is_whole_number(mantissa) {
+			markings = append(markings, eia_marking{
+				code:       fmt.Sprintf("%02d%d%s", int(math.Round(mantissa)), exp, tolerance),
+				convention: CONVENTION_3_DIGIT,
+			})
 		}
 	}
 
-	if result_tolerance_max != nil {
-		if result_tolerance_max.UnitType == utils.UNIT_TYPE_PERCENT {
-			result_tolerance_max_pf = result_pf * (1 - result_tolerance_max.Value/100)
-		} else {
-			result_tolerance_max_pf = result_pf - result_tolerance_max.Value
+	if pf < 10 && is_whole_number(pf*10) {
+		tenths := int(math.Round(pf * 10))
+		markings = append(markings, eia_marking{
+			code:       fmt.Sprintf("%dR%d%s", tenths/10, tenths%10, tolerance),
+			convention: CONVENTION_R_DECIMAL,
+		})
+	}
+
+	if pf >= 10 && pf < 100 && is_whole_number(pf) {
+		markings = append(markings, eia_marking{
+			code:       strconv.Itoa(int(math.Round(pf))),
+			convention: CONVENTION_2_DIGIT,
+		})
+	}
+
+	if pf >= 1 {
+		exp := int(math.Floor(math.Log10(pf)))
+		mantissa, _ := strconv.ParseFloat(strconv.FormatFloat(pf/math.Pow10(exp), 'f', 2, 64), 64)
+		if exp <= 9 {
+			for letter, v := range utils.EIA_198_MAPPING {
+				if v == mantissa {
+					markings = append(markings, eia_marking{
+						code:       fmt.Sprintf("%s%d", string(letter), exp),
+						convention: CONVENTION_EIA_198,
+					})
+					break
+				}
+			}
 		}
 	}
 
-	result_tolerance_min_pf *= utils.MATH_POW_PICO
-	result_tolerance_max_pf *= utils.MATH_POW_PICO
+	if len(markings) == 0 {
+		panic(fmt.Errorf("unsupported: no eia marking for capacitance %sF", utils.GetAbbreviatedValue(val)))
+	}
+
+	var sb strings.Builder
 
 	switch format {
 	case "json":
-		return fmt.Sprintf(`{"nominal":%s,"nominalAbbreviated":"%sF","actualMin":%s,"actualMinAbbreviated":"%s","actualMax":%s,"actualMaxAbbreviated":"%s"}`,
-			utils.FormatFloat(result_pf),
-			utils.GetAbbreviatedValue(result_pf),
-			utils.If(result_tolerance_min != nil, utils.FormatFloat(result_tolerance_min_pf), "null"),
-			utils.If(result_tolerance_min != nil, utils.GetAbbreviatedValue(result_tolerance_min_pf)+"F", "null"),
-			utils.If(result_tolerance_min != nil, utils.FormatFloat(result_tolerance_max_pf), "null"),
-			utils.If(result_tolerance_min != nil, utils.GetAbbreviatedValue(result_tolerance_max_pf)+"F", "null"),
-		)
+		sb.WriteRune('[')
+		for i, m := range markings {
+			fmt.Fprintf(&sb, `{"eiac":"%s","convention":"%s","nominal":%s,"nominalAbbreviated":"%sF"}`, m.code, m.convention, utils.FormatFloat(val), utils.GetAbbreviatedValue(val))
+			if i != len(markings)-1 {
+				sb.WriteRune(',')
+			}
+		}
+		sb.WriteRune(']')
 	case "raw":
-		return fmt.Sprintf("nominal=%sF min=%s max=%s",
-			utils.FormatFloat(result_pf),
-			utils.If(result_tolerance_min != nil, utils.FormatFloat(result_tolerance_min_pf)+"F", "nil"),
-			utils.If(result_tolerance_min != nil, utils.FormatFloat(result_tolerance_max_pf)+"F", "nil"),
-		)
+		for i, m := range markings {
+			fmt.Fprintf(&sb, "eiac=%s convention=%s nominal=%sF", m.code, m.convention, utils.FormatFloat(val))
+			if i != len(markings)-1 {
+				sb.WriteRune('\n')
+			}
+		}
 	default:
-		return fmt.Sprintf("nominal=%sF min=%s max=%s",
-			utils.GetAbbreviatedValue(result_pf),
-			utils.If(result_tolerance_min != nil, utils.GetAbbreviatedValue(result_tolerance_min_pf)+"F", "nil"),
-			utils.If(result_tolerance_min != nil, utils.GetAbbreviatedValue(result_tolerance_max_pf)+"F", "nil"),
-		)
+		for i, m := range markings {
+			fmt.Fprintf(&sb, "eiac=%s convention=%s nominal=%sF", m.code, m.convention, utils.GetAbbreviatedValue(val))
+			if i != len(markings)-1 {
+				sb.WriteRune('\n')
+			}
+		}
 	}
+
+	return sb.String()
+}
+
+func is_whole_number(f float64) bool {
+	return math.Abs(f-math.Round(f)) < 1e-9
 }
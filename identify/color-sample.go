@@ -0,0 +1,67 @@
+package identify
+
+import (
+	"fmt"
+	"gohm/utils"
+	"math"
+	"strings"
+)
+
+type color_sample_match struct {
+	sample  string
+	color   string
+	delta_e float64
+}
+
+// resolve_color_samples replaces every hex or rgb() band color with its nearest EIA color by CIELAB ΔE - other args are returned as is
+func resolve_color_samples(args []string) ([]string, []color_sample_match) {
+	resolved := make([]string, len(args))
+	matches := []color_sample_match{}
+
+	for i, arg := range args {
+		if !utils.IsColorSample(arg) {
+			resolved[i] = arg
+			continue
+		}
+
+		rgb, err := utils.ParseColorSample(arg)
+		if err != nil {
+			panic(err)
+		}
+
+		nearest := utils.ClassifyColor(rgb.ToLab())[0]
+		resolved[i] = nearest.Name
+		matches = append(matches, color_sample_match{
+			sample:  arg,
+			color:   nearest.Name,
+			delta_e: math.Round(nearest.DeltaE*100) / 100,
+		})
+	}
+
+	return resolved, matches
+}
+
+// format_color_sample_matches returns the matches as a suffix for the abbr/raw output & as a fragment for the json output
+func format_color_sample_matches(matches []color_sample_match) (string, string) {
+	if len(matches) == 0 {
+		return "", ""
+	}
+
+	var sb, json strings.Builder
+	sb.WriteString(" matches=")
+	json.WriteString(`,"matches":[`)
+
+	for i, m := range matches {
+		fmt.Fprintf(&sb, "%s→%s(ΔE=%s)", m.sample, m.color, utils.FormatFloat(m.delta_e))
+		fmt.Fprintf(&json, `{"sample":%q,"color":"%s","deltaE":%s}`, m.sample, m.color, utils.FormatFloat(m.delta_e))
+
+		if i != len(matches)-1 {
+			sb.WriteRune(',')
+			json.WriteRune(',')
+		}
+	}
+
+	json.WriteRune(']')
+
+	return sb.String(), json.String()
+}
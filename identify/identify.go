@@ -2,14 +2,107 @@ package identify
 
 import (
 	"gohm/cli"
+	"gohm/measure"
 	"gohm/utils"
 )
 
+// default_tolerance is the IEC 60062 tolerance of a part without a tolerance band or letter
 var default_tolerance = utils.Tolerance{
 	Value:    20,
 	UnitType: utils.UNIT_TYPE_PERCENT,
 }
 
+type letter_tolerance struct {
+	min utils.Tolerance
+	max utils.Tolerance
+}
+
+// tolerance_letter_mapping maps the IEC 60062 tolerance letters printed on resistors, capacitors & inductors to their min/max tolerance
+var tolerance_letter_mapping = map[byte]letter_tolerance{
+	'E': {
+		min: utils.Tolerance{Value: .005, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: .005, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'L': {
+		min: utils.Tolerance{Value: .01, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: .01, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'P': {
+		min: utils.Tolerance{Value: .02, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: .02, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'W': {
+		min: utils.Tolerance{Value: .05, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: .05, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'B': {
+		min: utils.Tolerance{Value: .1, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: .1, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'C': {
+		min: utils.Tolerance{Value: .25, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: .25, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'D': {
+		min: utils.Tolerance{Value: .5, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: .5, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'F': {
+		min: utils.Tolerance{Value: 1, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: 1, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'G': {
+		min: utils.Tolerance{Value: 2, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: 2, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'H': {
+		min: utils.Tolerance{Value: 3, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: 3, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'J': {
+		min: utils.Tolerance{Value: 5, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: 5, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'K': {
+		min: utils.Tolerance{Value: 10, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: 10, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'M': {
+		min: utils.Tolerance{Value: 20, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: 20, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'N': {
+		min: utils.Tolerance{Value: 30, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: 30, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'Q': {
+		min: utils.Tolerance{Value: 10, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: 30, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'T': {
+		min: utils.Tolerance{Value: 10, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: 50, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'S': {
+		min: utils.Tolerance{Value: 20, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: 50, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+	'Z': {
+		min: utils.Tolerance{Value: 20, UnitType: utils.UNIT_TYPE_PERCENT},
+		max: utils.Tolerance{Value: 80, UnitType: utils.UNIT_TYPE_PERCENT},
+	},
+}
+
+// get_letter_tolerance looks up an IEC 60062 tolerance letter case-insensitively
+func get_letter_tolerance(letter byte) (letter_tolerance, bool) {
+	if utils.IsLowerLetter(letter) {
+		letter -= 'a' - 'A'
+	}
+
+	t, ok := tolerance_letter_mapping[letter]
+	return t, ok
+}
+
 func GetCommand() *cli.Command {
 	cmd := &cli.Command{
 		Name:        "identify",
@@ -18,6 +111,9 @@ func GetCommand() *cli.Command {
 	}
 
 	cmd.AddSubcommand(get_command_capacitor())
+	cmd.AddSubcommand(get_command_inductor())
+	cmd.AddSubcommand(get_command_package())
+	cmd.AddSubcommand(get_command_part())
 	cmd.AddSubcommand(get_command_resistor())
 
 	return cmd
@@ -34,6 +130,12 @@ func get_command_capacitor() *cli.Command {
 				Description: "2 digit code",
 				Output:      "nominal=21pF min=nil max=nil",
 			},
+			{
+				Command:     "gohm identify capacitor -eiac 21 -candidates",
+				Description: "every interpretation of an ambiguous code",
+				Output: `nominal=21pF min=nil max=nil convention=2-digit confidence=0.7
+      nominal=nil min=nil max=nil convention=date-code confidence=0.3`,
+			},
 			{
 				Command:     "gohm identify capacitor -eiac A8",
 				Description: "2 digit eia-198 code",
@@ -56,20 +158,159 @@ func get_command_capacitor() *cli.Command {
 			{
 				Command:     "gohm identify capacitor -eiac 123B",
 				Description: "4 digit code",
-				Output:      "nominal=12nF min=11.988zF max=11.988zF",
+				Output:      "nominal=12nF min=11.988nF max=12.012nF",
 			},
 			{
 				Command:     "gohm identify capacitor -eiac 6R7K",
 				Description: "4 digit decimal",
-				Output:      "nominal=6.7pF min=6.029999999999999yF max=6.029999999999999yF",
+				Output:      "nominal=6.7pF min=6.03pF max=7.37pF",
+			},
+			{
+				Command:     "gohm identify capacitor -eiac 10 -convention 2-digit",
+				Description: "force a single convention for an ambiguous code",
+				Output:      "nominal=10pF min=nil max=nil",
+			},
+			{
+				Command:     `gohm identify capacitor -eiac "103 2A X7R"`,
+				Description: "full marking with voltage & dielectric codes",
+				Output:      "nominal=10nF min=nil max=nil voltage=100V dielectric=X7R class=2 temp_range=-55°C..125°C capacitance_change=±15%",
+			},
+			{
+				Command:     "gohm identify capacitor -eiac 6R7K -measured 7.5p",
+				Description: "check a measured value against the tolerance - exits with 1 when it fails",
+				Output:      "nominal=6.7pF min=6.03pF max=7.37pF measured=7.5pF result=fail deviation=11.94% deviation_sigma=3.58σ",
+			},
+			{
+				Command:     "gohm identify capacitor -encode 4.7nF -tolerance K",
+				Description: "encode a capacitance into every valid marking",
+				Output: `eiac=472K convention=3-digit nominal=4.7nF
+      eiac=S3 convention=EIA-198 nominal=4.7nF`,
 			},
 		},
 	}
 	cmd.AddFlag(&cli.Flag{
 		Name:        "eiac",
 		Aliases:     []string{"code"},
-		Description: "The eia code or identifier - supports 2-4 digit codes including SMD & EIA-198",
+		Description: "The eia code or identifier - supports 2-4 digit codes including SMD & EIA-198 - optionally followed by voltage (2A) & dielectric (X7R) codes",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "convention",
+		Description:    "Force a single interpretation of the eia code instead of listing every candidate - used only with -eiac",
+		PossibleValues: capacitor_conventions,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "candidates",
+		IsBoolean:   true,
+		Description: "List every interpretation of an ambiguous eia code with its convention & confidence instead of the most confident one - used only with -eiac",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "encode",
+		Description: "Capacitance to encode into eia markings - RKM & shorthand supported",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "measured",
+		Description: "Measured capacitance, or an instrument address to take the reading from, to check against the decoded tolerance, ±20% without a tolerance letter - used only with -eiac - the exit code is 1 when it is out of tolerance",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "tolerance",
+		Description: "Tolerance letter appended to encoded 3-digit & R-decimal markings - used only with -encode",
+	})
+	cmd.AddFlag(timeout_flag())
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json"},
+	})
+	return cmd
+}
+
+func get_command_inductor() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "inductor",
+		Description: "Identify inductor value from mil-spec color bands or SMD markings - color bands are n args passed in",
+		Handler:     cmd_inductor_handler,
+		Examples: []cli.Example{
+			{
+				Command: "gohm identify inductor brown black red silver",
+				Output:  "\u001b[38;5;172m▌\u001b[30m▌\u001b[91m▌\033[0m \u001b[37m▌\033[0m nominal=1mH min=900μH max=1.1mH",
+			},
+			{
+				Command:     "gohm identify inductor silver red gold violet gold",
+				Description: "wide silver mil-spec band with a gold decimal point band",
+				Output:      "\u001b[37m█ \u001b[91m▌\u001b[43m▌\u001b[95m▌\033[0m \u001b[43m▌\033[0m nominal=2.7μH min=2.565μH max=2.835μH",
+			},
+			{
+				Command:     "gohm identify inductor -smd 102K",
+				Description: "SMD code in μH",
+				Output:      "nominal=1mH min=900μH max=1.1mH",
+			},
+		},
+	}
+	cmd.AddFlag(&cli.Flag{
+		Name:        "lang",
+		Description: "Language of the color names - en, de, fr, es or a language added in the user color alias config",
+		Default:     utils.COLOR_ALIAS_DEFAULT_LANGUAGE,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "smd",
+		Description: "SMD inductor marking in μH - supports RKM & 3 digit codes with an optional tolerance letter",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json"},
+	})
+	return cmd
+}
+
+func get_command_package() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "package",
+		Description: "Look up the body dimensions, typical ratings & recommended land pattern of a passive or discrete package - the package is the arg passed in",
+		Handler:     cmd_package_handler,
+		Examples: []cli.Example{
+			{
+				Command: "gohm identify package 0603",
+				Output:  "package=0603 aliases=1608M type=chip size=1.6x0.8x0.45mm power=100mW voltage=75V pads=2 pad=0.9x0.95mm pitch=nil span=1.55mm",
+			},
+			{
+				Command: "gohm identify package SOT-23",
+				Output:  "package=SOT-23 aliases=TO-236,SOT-23-3 type=transistor size=2.9x1.3x1mm power=349.99999999999994mW voltage=nil pads=3 pad=1x0.6mm pitch=0.95mm span=2.2mm",
+			},
+			{
+				Command:     "gohm identify package 1005M",
+				Description: "metric chip sizes have an M suffix",
+				Output:      "package=0402 aliases=1005M type=chip size=1x0.5x0.35mm power=62.5mW voltage=50V pads=2 pad=0.5x0.6mm pitch=nil span=0.95mm",
+			},
+		},
+	}
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json"},
 	})
+	return cmd
+}
+
+func get_command_part() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "part",
+		Description: "Decode a manufacturer part number of a resistor or capacitor - the part number is the arg passed in",
+		Handler:     cmd_part_handler,
+		Examples: []cli.Example{
+			{
+				Command: "gohm identify part RC0603FR-0710KL",
+				Output:  `manufacturer=Yageo series=RC component=resistor package=0603 nominal=10kΩ min=9.9kΩ max=10.1kΩ tolerance=±1% temp_coefficient=nil voltage=nil dielectric=nil packaging="paper tape, 7in reel"`,
+			},
+			{
+				Command: "gohm identify part GRM188R71H104KA93D",
+				Output:  `manufacturer=Murata series=GRM component=capacitor package=0603 nominal=99.99999999999999nF min=90nF max=110nF tolerance=±10% temp_coefficient=nil voltage=50V dielectric=X7R packaging="paper tape, 180mm reel"`,
+			},
+		},
+	}
 	cmd.AddFlag(&cli.Flag{
 		Name:           "format",
 		Description:    "Output format",
@@ -82,7 +323,7 @@ func get_command_capacitor() *cli.Command {
 func get_command_resistor() *cli.Command {
 	cmd := &cli.Command{
 		Name:        "resistor",
-		Description: "Identify resistor value from color bands - color bands are n args passed in",
+		Description: "Identify resistor value from color bands - color bands are n args passed in as names or hex/rgb() color samples",
 		Handler:     cmd_resistor_handler,
 		Examples: []cli.Example{
 			{
@@ -94,8 +335,82 @@ func get_command_resistor() *cli.Command {
 				Description: "EIA Shorthand",
 				Output:      "\u001b[91m▌▌\u001b[38;5;172m▌ \u001b[91m▌\033[0m nominal=220Ω min=215.6Ω max=224.4Ω temp_coefficient=nil",
 			},
+			{
+				Command:     "gohm identify resistor gold _ yellow violet yellow -either-direction",
+				Description: "band order unknown - the gap is marked with _",
+				Output: `\u001b[93m▌\u001b[95m▌\u001b[93m▌\033[0m \u001b[43m▌\033[0m nominal=470kΩ min=446.5kΩ max=493.5kΩ temp_coefficient=nil direction=reverse score=1 e_series=E24 gap=fits
+      direction=forward score=0 error="invalid: significant digit band color can not be gold"`,
+			},
+			{
+				Command:     "gohm identify resistor -lang de gelb violett rot gold",
+				Description: "localized color names",
+				Output:      "\u001b[93m▌\u001b[95m▌\u001b[91m▌\033[0m \u001b[43m▌\033[0m nominal=4.7kΩ min=4.465kΩ max=4.935kΩ temp_coefficient=nil",
+			},
+			{
+				Command:     "gohm identify resistor -image resistor.jpg",
+				Description: "read the bands from a photo - the most likely readings come first",
+				Output: `\u001b[93m▌\u001b[95m▌\u001b[91m▌\033[0m \u001b[43m▌\033[0m nominal=4.7kΩ min=4.465kΩ max=4.935kΩ temp_coefficient=nil bands=yellow,violet,red,gold confidence=0.95
+      \u001b[93m▌\u001b[94m▌\u001b[91m▌\033[0m \u001b[43m▌\033[0m nominal=4.6kΩ min=4.37kΩ max=4.83kΩ temp_coefficient=nil bands=yellow,blue,red,gold confidence=0.05`,
+			},
+			{
+				Command:     `gohm identify resistor "#8B4513" black "rgb(200,35,35)" gold`,
+				Description: "color samples from a color picker",
+				Output:      "\u001b[38;5;172m▌\u001b[30m▌\u001b[91m▌\033[0m \u001b[43m▌\033[0m nominal=1kΩ min=950Ω max=1.05kΩ temp_coefficient=nil matches=#8B4513→brown(ΔE=16.43),rgb(200,35,35)→red(ΔE=0)",
+			},
+			{
+				Command:     "gohm identify resistor yellow violet red gold -measured 4.68k",
+				Description: "check a measured value against the tolerance - exits with 1 when it fails",
+				Output:      "\u001b[93m▌\u001b[95m▌\u001b[91m▌\033[0m \u001b[43m▌\033[0m nominal=4.7kΩ min=4.465kΩ max=4.935kΩ temp_coefficient=nil measured=4.68kΩ result=pass deviation=-0.43% deviation_sigma=-0.26σ",
+			},
+			{
+				Command:     "gohm identify resistor black",
+				Description: "0Ω jumper - a single black band",
+				Output:      "\u001b[30m▌\033[0m nominal=0Ω min=0Ω max=0Ω temp_coefficient=nil",
+			},
+			{
+				Command:     "gohm identify resistor -code 4K7JS",
+				Description: "IEC 60062 letter code with tolerance & temperature coefficient letters",
+				Output:      "nominal=4.7kΩ min=4.465kΩ max=4.935kΩ temp_coefficient=100 ppm/K",
+			},
+			{
+				Command:     "gohm identify resistor -smd 472",
+				Description: "3 digit SMD code",
+				Output:      "nominal=4.7kΩ min=4.465kΩ max=4.935kΩ convention=3-digit",
+			},
+			{
+				Command:     "gohm identify resistor -smd 01C",
+				Description: "EIA-96 SMD code",
+				Output:      "nominal=10kΩ min=9.9kΩ max=10.1kΩ convention=EIA-96",
+			},
 		},
 	}
+	cmd.AddFlag(&cli.Flag{
+		Name:        "code",
+		Description: "IEC 60062 letter & digit code with optional tolerance & temperature coefficient letters, i.e. 4K7JS",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "either-direction",
+		IsBoolean:   true,
+		Description: "Decode the color bands in both directions & rank the readings by plausibility - an _ arg marks the gap between bands",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "image",
+		Description: "Photo (jpeg or png) of a resistor on a plain background to read the color bands from",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "lang",
+		Description: "Language of the color names - en, de, fr, es or a language added in the user color alias config",
+		Default:     utils.COLOR_ALIAS_DEFAULT_LANGUAGE,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "measured",
+		Description: "Measured resistance, or an instrument address to take the reading from, i.e. tcp://192.168.1.50:5025, to check against the decoded tolerance - the exit code is 1 when it is out of tolerance",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "smd",
+		Description: "SMD resistor marking - supports 3 digit, 4 digit, RKM & EIA-96 codes",
+	})
+	cmd.AddFlag(timeout_flag())
 	cmd.AddFlag(&cli.Flag{
 		Name:           "format",
 		Description:    "Output format",
@@ -104,3 +419,10 @@ func get_command_resistor() *cli.Command {
 	})
 	return cmd
 }
+
+// timeout_flag returns the -timeout flag of a -measured instrument address
+func timeout_flag() *cli.Flag {
+	f := measure.NewTimeoutFlag()
+	f.Description = "Timeout of connecting to & reading from the -measured instrument"
+	return f
+}
@@ -1,11 +1,34 @@
 package identify
 
 import (
+	"gohm/cli"
 	"gohm/test_utils"
+	"gohm/utils"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
 	"strings"
 	"testing"
 )
 
+// TestMain points the user color alias config at a missing file so the tests never load the real one
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "gohm")
+	if err != nil {
+		panic(err)
+	}
+	os.Setenv(utils.COLOR_ALIAS_CONFIG_ENV, filepath.Join(dir, "colors.json"))
+
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
+
 // region Capacitance Tests
 func TestCmdCapacitanceHandler(t *testing.T) {
 	tests := []struct {
@@ -66,7 +89,7 @@ func TestCmdCapacitanceHandler(t *testing.T) {
 			name:     "4 digit with tolerance J",
 			eiaValue: "104J",
 			format:   "abbr",
-			contains: []string{"nominal=", "min=", "max="},
+			contains: []string{"nominal=", "min=", "max=105nF"},
 		},
 		{
 			name:     "4 digit with tolerance K",
@@ -210,7 +233,7 @@ func TestGetCapacitanceFromEIA(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result := get_capacitance_from_eia(tt.input, "raw")
+			result := get_capacitance_from_eia(tt.input, "", false, nil, "raw")
 			if !strings.Contains(result, "nominal=") {
 				t.Errorf("expected result to contain nominal value for input %s", tt.input)
 			}
@@ -218,8 +241,253 @@ func TestGetCapacitanceFromEIA(t *testing.T) {
 	}
 }
 
+func TestGetCapacitorCandidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected []capacitor_candidate
+	}{
+		{"2 digit or date code", "10", []capacitor_candidate{
+			{convention: CONVENTION_2_DIGIT, confidence: .7, has_value: true, nominal: 10e-12},
+			{convention: CONVENTION_DATE_CODE, confidence: .3},
+		}},
+		{"EIA-198 or smd marking", "A8", []capacitor_candidate{
+			{convention: CONVENTION_EIA_198, confidence: .8, has_value: true, nominal: 1e-4},
+			{convention: CONVENTION_SMD_MARKING, confidence: .2},
+		}},
+		{"lowercase EIA-198", "b1", []capacitor_candidate{
+			{convention: CONVENTION_EIA_198, confidence: .8, has_value: true, nominal: 35e-12},
+			{convention: CONVENTION_SMD_MARKING, confidence: .2},
+		}},
+		{"lowercase EIA-198 missing from table", "c1", []capacitor_candidate{
+			{convention: CONVENTION_EIA_198, confidence: .67, has_value: true, nominal: 12e-12},
+			{convention: CONVENTION_SMD_MARKING, confidence: .33},
+		}},
+		{"3 digit", "104", []capacitor_candidate{
+			{convention: CONVENTION_3_DIGIT, confidence: 1, has_value: true, nominal: 100e-9},
+		}},
+		{"3 digit fractional multiplier", "109", []capacitor_candidate{
+			{convention: CONVENTION_3_DIGIT_FRACTIONAL, confidence: .8, has_value: true, nominal: 1e-12},
+			{convention: CONVENTION_3_DIGIT, confidence: .2, has_value: true, nominal: 10e-3},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			candidates, err := get_capacitor_candidates(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			test_utils.AssertEquals(t, len(candidates), len(tt.expected))
+			for i, c := range candidates {
+				test_utils.AssertEquals(t, c.convention, tt.expected[i].convention)
+				test_utils.AssertEquals(t, c.confidence, tt.expected[i].confidence)
+				test_utils.AssertEquals(t, c.has_value, tt.expected[i].has_value)
+				if math.Abs(c.nominal-tt.expected[i].nominal) > 1e-9*tt.expected[i].nominal {
+					t.Errorf("expected nominal %v, got %v", tt.expected[i].nominal, c.nominal)
+				}
+			}
+		})
+	}
+}
+
+func TestCmdCapacitanceHandlerCandidates(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		expected string
+	}{
+		{"most confident only", map[string]string{"format": "abbr"}, "nominal=21pF min=nil max=nil"},
+		{"raw", map[string]string{"format": "raw"}, "nominal=0.000000000021F min=nil max=nil"},
+		{"candidates", map[string]string{"format": "abbr", "candidates": "true"}, "nominal=21pF min=nil max=nil convention=2-digit confidence=0.7\nnominal=nil min=nil max=nil convention=date-code confidence=0.3"},
+		{"json lists every candidate", map[string]string{"format": "json"}, `[{"nominal":0.000000000021,"nominalAbbreviated":"21pF","actualMin":null,"actualMinAbbreviated":null,"actualMax":null,"actualMaxAbbreviated":null,"convention":"2-digit","confidence":0.7},{"nominal":null,"nominalAbbreviated":null,"actualMin":null,"actualMinAbbreviated":null,"actualMax":null,"actualMaxAbbreviated":null,"convention":"date-code","confidence":0.3}]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(cmd_capacitor_handler, test_utils.MergeFlags(map[string]string{"eiac": "21"}, tt.flags), nil, nil)
+			test_utils.AssertEquals(t, cmd_capacitor_handler(cmd), tt.expected)
+		})
+	}
+
+	// the tolerance is rounded to drop float noise
+	cmd := test_utils.CreateTestCommand(cmd_capacitor_handler, map[string]string{"format": "abbr", "eiac": "123B"}, nil, nil)
+	test_utils.AssertEquals(t, cmd_capacitor_handler(cmd), "nominal=12nF min=11.988nF max=12.012nF")
+}
+
+func TestCmdCapacitanceHandlerConvention(t *testing.T) {
+	tests := []struct {
+		name       string
+		eiaValue   string
+		convention string
+		contains   []string
+	}{
+		{"force 2 digit", "10", CONVENTION_2_DIGIT, []string{"nominal=10pF min=nil max=nil convention=2-digit confidence=1"}},
+		{"force date code", "10", CONVENTION_DATE_CODE, []string{"nominal=nil min=nil max=nil convention=date-code confidence=1"}},
+		{"force 3 digit over fractional", "109", CONVENTION_3_DIGIT, []string{"nominal=10mF", "convention=3-digit confidence=1"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(
+				cmd_capacitor_handler,
+				map[string]string{
+					"format":     "abbr",
+					"eiac":       tt.eiaValue,
+					"convention": tt.convention,
+					"candidates": "true",
+				},
+				nil,
+				nil,
+			)
+			result := cmd_capacitor_handler(cmd)
+			test_utils.AssertContains(t, result, tt.contains...)
+			test_utils.AssertEquals(t, strings.Count(result, "\n"), 0)
+		})
+	}
+
+	t.Run("convention not matching code", func(t *testing.T) {
+		test_utils.ExpectPanic(t, "invalid: 104 is not a EIA-198 capacitor code", func() {
+			get_capacitance_from_eia("104", CONVENTION_EIA_198, false, nil, "abbr")
+		})
+	})
+
+	t.Run("unknown convention", func(t *testing.T) {
+		test_utils.ExpectPanic(t, "invalid or unsupported: capacitor convention 5-digit", func() {
+			get_capacitance_from_eia("104", "5-digit", false, nil, "abbr")
+		})
+	})
+}
+
+func TestCmdCapacitanceHandlerRatings(t *testing.T) {
+	tests := []struct {
+		name     string
+		marking  string
+		format   string
+		contains []string
+	}{
+		{"voltage only", "104 1H", "abbr", []string{"voltage=50V"}},
+		{"voltage 6.3V", "475 0J", "raw", []string{"voltage=6.3V"}},
+		{"class 2 dielectric", "104K 2A X7R", "abbr", []string{"voltage=100V", "dielectric=X7R class=2 temp_range=-55°C..125°C capacitance_change=±15%"}},
+		{"any order", "104K X5R 1C", "abbr", []string{"voltage=16V", "dielectric=X5R class=2 temp_range=-55°C..85°C"}},
+		{"asymmetric change", "105 Y5V", "abbr", []string{"dielectric=Y5V class=2 temp_range=-30°C..85°C capacitance_change=+22%/-82%"}},
+		{"Z5U", "104 Z5U", "abbr", []string{"temp_range=10°C..85°C capacitance_change=+22%/-56%"}},
+		{"class 1 alias", "2R2 NP0", "abbr", []string{"dielectric=C0G class=1", "temp_coefficient=0±30ppm/K"}},
+		{"class 1 negative", "103 U2J", "abbr", []string{"dielectric=U2J class=1 temp_range=-55°C..125°C temp_coefficient=-750±120ppm/K"}},
+		{"json", "104K 2A X7R", "json", []string{`"voltage":100`, `"dielectric":{"code":"X7R","class":2,"temperatureMin":-55,"temperatureMax":125,"capacitanceChangeMin":-15,"capacitanceChangeMax":15}}`}},
+		{"json class 1", "101 C0G", "json", []string{`"dielectric":{"code":"C0G","class":1,"temperatureMin":-55,"temperatureMax":125,"temperatureCoefficient":0,"temperatureCoefficientTolerance":30}}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_utils.AssertContains(t, get_capacitance_from_eia(tt.marking, "", false, nil, tt.format), tt.contains...)
+		})
+	}
+}
+
+func TestCmdCapacitanceHandlerRatingsPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		marking  string
+		expected string
+	}{
+		{"invalid voltage code", "104 AA", "invalid: capacitor voltage code AA"},
+		{"invalid voltage identifier", "104 1X", "invalid or unsupported: capacitor voltage identifier X"},
+		{"multiple voltages", "104 1H 2A", "invalid: multiple capacitor voltage codes 2A"},
+		{"invalid dielectric", "104 X7RR", "invalid: capacitor dielectric code X7RR"},
+		{"invalid class 2 max temperature", "104 X3R", "invalid or unsupported: class 2 dielectric maximum temperature identifier 3"},
+		{"invalid class 2 change", "104 X7Q", "invalid or unsupported: class 2 dielectric capacitance change identifier Q"},
+		{"invalid class 1", "104 Q0G", "invalid or unsupported: dielectric identifier Q"},
+		{"multiple dielectrics", "104 X7R C0G", "invalid: multiple capacitor dielectric codes C0G"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				get_capacitance_from_eia(tt.marking, "", false, nil, "abbr")
+			})
+		})
+	}
+}
+
 //endregion Capacitance Tests
 
+//region Capacitance Encode Tests
+
+func TestGetEIAFromCapacitance(t *testing.T) {
+	tests := []struct {
+		name        string
+		capacitance float64
+		tolerance   string
+		contains    []string
+	}{
+		{"3 digit with tolerance", 4.7e-9, "K", []string{"eiac=472K convention=3-digit", "eiac=S3 convention=EIA-198"}},
+		{"3 digit without tolerance", 100e-9, "", []string{"eiac=104 convention=3-digit", "eiac=A5 convention=EIA-198"}},
+		{"R decimal", 4.7e-12, "", []string{"eiac=4R7 convention=R-decimal", "eiac=S0 convention=EIA-198"}},
+		{"R decimal below 1pF", .5e-12, "J", []string{"eiac=0R5J convention=R-decimal"}},
+		{"2 digit", 47e-12, "", []string{"eiac=470 convention=3-digit", "eiac=47 convention=2-digit", "eiac=S1 convention=EIA-198"}},
+		{"EIA-198 lowercase", 35e-12, "", []string{"eiac=b1 convention=EIA-198"}},
+		{"no EIA-198 mantissa", 22e-9, "", []string{"eiac=223 convention=3-digit"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := get_eia_from_capacitance(tt.capacitance, tt.tolerance, "abbr")
+			test_utils.AssertContains(t, result, tt.contains...)
+		})
+	}
+}
+
+func TestGetEIAFromCapacitanceRoundTrip(t *testing.T) {
+	for letter, mantissa := range utils.EIA_198_MAPPING {
+		for exp := range 10 {
+			code := string(letter) + strconv.Itoa(exp)
+			t.Run(code, func(t *testing.T) {
+				capacitance := mantissa * math.Pow10(exp) * utils.MATH_POW_PICO
+				test_utils.AssertContains(t, get_eia_from_capacitance(capacitance, "", "abbr"), "eiac="+code+" ")
+				test_utils.AssertContains(t, get_capacitance_from_eia(code, "", false, nil, "abbr"), "nominal="+abbreviate_trimmed(capacitance)+"F")
+			})
+		}
+	}
+}
+
+func TestGetEIAFromCapacitancePanics(t *testing.T) {
+	tests := []struct {
+		name        string
+		capacitance float64
+		tolerance   string
+		expected    string
+	}{
+		{"invalid tolerance", 4.7e-9, "X", "invalid or unsupported: capacitor tolerance identifier: X"},
+		{"zero", 0, "", "invalid: capacitance 0"},
+		{"no marking", 1.234e-9, "", "unsupported: no eia marking for capacitance 1.234nF"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				get_eia_from_capacitance(tt.capacitance, tt.tolerance, "abbr")
+			})
+		})
+	}
+}
+
+func TestCmdCapacitanceHandlerEncode(t *testing.T) {
+	cmd := test_utils.CreateTestCommand(
+		cmd_capacitor_handler,
+		map[string]string{
+			"format":    "json",
+			"encode":    "4n7",
+			"tolerance": "K",
+		},
+		nil,
+		nil,
+	)
+	test_utils.AssertContains(t, cmd_capacitor_handler(cmd), `{"eiac":"472K","convention":"3-digit"`, `{"eiac":"S3","convention":"EIA-198"`)
+}
+
+//endregion Capacitance Encode Tests
+
 //region Resistance Tests
 
 func TestCmdResistorHandler(t *testing.T) {
@@ -328,8 +596,8 @@ func TestCmdResistorHandlerPanics(t *testing.T) {
 		},
 		{
 			name:     "invalid color name",
-			bands:    []string{"purple", "black", "red", "gold"},
-			expected: "invalid: significant digit band color purple",
+			bands:    []string{"magenta", "black", "red", "gold"},
+			expected: "invalid: significant digit band color magenta",
 		},
 		{
 			name:     "invalid multiplier color",
@@ -448,3 +716,778 @@ func TestResistorTempCoefficientValues(t *testing.T) {
 }
 
 //endregion Resistance Tests
+
+//region IEC 60062 Tests
+
+func TestResistorIEC60062Bands(t *testing.T) {
+	tests := []struct {
+		name     string
+		bands    []string
+		contains []string
+	}{
+		{"single black band", []string{"black"}, []string{"nominal=0Ω", "min=0Ω", "max=0Ω"}},
+		{"upper case", []string{"BROWN", "Black", "RED", "GOLD"}, []string{"nominal=1kΩ", "min=950Ω", "max=1.05kΩ"}},
+		{"upper case abbreviations", []string{"YE", "VT", "Og", "SR"}, []string{"nominal=47kΩ", "min=42.3kΩ"}},
+		{"none tolerance band", []string{"brown", "black", "red", "none"}, []string{"nominal=1kΩ", "min=800Ω", "max=1.2kΩ"}},
+		{"no band tolerance band", []string{"brown", "black", "red", "No Band"}, []string{"min=800Ω", "max=1.2kΩ"}},
+		{"upper case temp coefficient", []string{"brown", "black", "black", "brown", "brown", "RED"}, []string{"temp_coefficient=50 ppm/K"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(
+				cmd_resistor_handler,
+				map[string]string{"format": "abbr"},
+				nil,
+				tt.bands,
+			)
+			test_utils.AssertContains(t, cmd_resistor_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestResistorIEC60062BandsPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		bands    []string
+		expected string
+	}{
+		{"single non black band", []string{"brown"}, "invalid: single band resistor color brown - only black (0Ω) is allowed"},
+		{"two bands", []string{"brown", "black"}, "too few arguments: [args...]"},
+		{"none as significant digit", []string{"none", "black", "red"}, "invalid: significant digit band color can not be none"},
+		{"none as multiplier", []string{"brown", "black", "none", "gold"}, "invalid: multiplier band color none"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_resistor_handler(test_utils.CreateTestCommand(cmd_resistor_handler, map[string]string{"format": "abbr"}, nil, tt.bands))
+			})
+		})
+	}
+}
+
+func TestGetResistanceFromCode(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		format   string
+		contains []string
+	}{
+		{"kilo", "4K7", "abbr", []string{"nominal=4.7kΩ", "min=3.76kΩ", "max=5.64kΩ", "temp_coefficient=nil"}},
+		{"tolerance letter", "4K7J", "abbr", []string{"nominal=4.7kΩ", "min=4.465kΩ", "max=4.935kΩ"}},
+		{"tolerance & temp coefficient letters", "4K7JS", "abbr", []string{"nominal=4.7kΩ", "temp_coefficient=100 ppm/K"}},
+		{"lower case", "4k7js", "abbr", []string{"nominal=4.7kΩ", "min=4.465kΩ", "temp_coefficient=100 ppm/K"}},
+		{"mega", "1M0F", "abbr", []string{"nominal=1MΩ", "min=990kΩ", "max=1.01MΩ"}},
+		{"milli", "15L", "abbr", []string{"nominal=15mΩ"}},
+		{"asymmetric tolerance", "47KQ", "abbr", []string{"nominal=47kΩ", "min=42.3kΩ", "max=61.1kΩ"}},
+		{"raw format", "10KF", "raw", []string{"nominal=10000Ω", "min=9900Ω", "max=10100Ω"}},
+		{"json format", "10KFU", "json", []string{`"nominal":10000`, `"actualMin":9900`, `"temperatureCoefficient":250`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(
+				cmd_resistor_handler,
+				map[string]string{
+					"format": tt.format,
+					"code":   tt.code,
+				},
+				nil,
+				nil,
+			)
+			test_utils.AssertContains(t, cmd_resistor_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestGetResistanceFromCodePanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+	}{
+		{"digits only", "470", "invalid: resistor letter code 470"},
+		{"invalid multiplier", "4X7", "invalid or unsupported: resistor letter code multiplier X"},
+		{"invalid tolerance", "4K7X", "invalid or unsupported: resistor tolerance identifier: X"},
+		{"invalid temp coefficient", "4K7JX", "invalid or unsupported: resistor temperature coefficient identifier: X"},
+		{"suffix too long", "4K7JSU", "invalid: resistor letter code suffix JSU"},
+		{"padded invalid multiplier", " 4x7", "invalid or unsupported: resistor letter code multiplier X"},
+		{"padded invalid tolerance", "  4k7x", "invalid or unsupported: resistor tolerance identifier: X"},
+		{"padded invalid temp coefficient", " 4k7jx", "invalid or unsupported: resistor temperature coefficient identifier: X"},
+		{"padded suffix too long", " 4k7jsu", "invalid: resistor letter code suffix JSU"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				get_resistance_from_code(tt.code, nil, "abbr")
+			})
+		})
+	}
+}
+
+func TestGetLetterTolerance(t *testing.T) {
+	for _, letter := range []byte{'E', 'L', 'P', 'W', 'B', 'C', 'D', 'F', 'G', 'H', 'J', 'K', 'M', 'N', 'Q', 'S', 'T', 'Z'} {
+		upper, ok := get_letter_tolerance(letter)
+		if !ok {
+			t.Errorf("expected tolerance letter %s to be supported", string(letter))
+		}
+		if lower, _ := get_letter_tolerance(letter + 'a' - 'A'); lower != upper {
+			t.Errorf("expected tolerance letter %s to be case-insensitive", string(letter))
+		}
+	}
+
+	if _, ok := get_letter_tolerance('X'); ok {
+		t.Error("expected tolerance letter X to be unsupported")
+	}
+}
+
+func TestGetResistorChart(t *testing.T) {
+	test_utils.AssertContains(t, GetResistorChart("abbr"),
+		"black   bk   0     ×1         -         -      250ppm/K  U",
+		"gold    gd   -     ×0.1       ±5%       J      -         -",
+		"none    -    -     -          ±20%      M      -         -",
+		"Z=+80%/-20%",
+		"S=100ppm/K",
+	)
+	test_utils.AssertContains(t, GetResistorChart("raw"), "×1000000000")
+	test_utils.AssertContains(t, GetResistorChart("json"),
+		`{"color":"brown","abbreviation":"bn","significant":1,"multiplier":10,"tolerance":1,"toleranceLetter":"F","temperatureCoefficient":100,"temperatureCoefficientLetter":"S"}`,
+		`{"letter":"Q","toleranceMin":-10,"toleranceMax":30}`,
+	)
+}
+
+//endregion IEC 60062 Tests
+
+//region Resistance Either Direction Tests
+
+func TestGetResistanceEitherDirection(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		format   string
+		contains []string
+	}{
+		{"reverse only valid", []string{"gold", "_", "yellow", "violet", "yellow"}, "abbr", []string{"nominal=470kΩ", "direction=reverse score=1 e_series=E24 gap=fits", `direction=forward score=0 error="invalid: significant digit band color can not be gold"`}},
+		{"forward ranked first", []string{"yellow", "violet", "black", "_", "brown"}, "abbr", []string{"nominal=47Ω", "direction=forward score=0.8 e_series=E6 gap=fits", "nominal=100MΩ", "direction=reverse score=0.55 e_series=E192 gap=misfit"}},
+		{"no gap", []string{"brown", "black", "black", "brown", "brown"}, "abbr", []string{"direction=forward score=1 e_series=E96 gap=nil", "direction=reverse score=1"}},
+		{"raw format", []string{"gold", "_", "yellow", "violet", "yellow"}, "raw", []string{"nominal=470000Ω"}},
+		{"json format", []string{"gold", "_", "yellow", "violet", "yellow"}, "json", []string{`[{"nominal":470000`, `"direction":"reverse","score":1,"eSeries":"E24","gap":"fits"}`, `{"direction":"forward","score":0,"error":"invalid: significant digit band color can not be gold"}]`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(
+				cmd_resistor_handler,
+				map[string]string{
+					"format":           tt.format,
+					"either-direction": "true",
+				},
+				nil,
+				tt.args,
+			)
+			test_utils.AssertContains(t, cmd_resistor_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestGetResistanceEitherDirectionOrder(t *testing.T) {
+	result := get_resistance_either_direction([]string{"yellow", "violet", "black", "_", "brown"}, "", "", "abbr")
+	if lines := strings.Split(result, "\n"); len(lines) != 2 || !strings.Contains(lines[0], "direction=forward") {
+		t.Errorf("expected the forward reading to be ranked first, got %s", result)
+	}
+}
+
+func TestGetResistanceEitherDirectionPanics(t *testing.T) {
+	test_utils.ExpectPanic(t, "too few arguments: [args...]", func() {
+		get_resistance_either_direction([]string{"brown", "_", "black"}, "", "", "abbr")
+	})
+}
+
+//endregion Resistance Either Direction Tests
+
+//region Color Alias Tests
+
+func TestResistorColorAliases(t *testing.T) {
+	tests := []struct {
+		name     string
+		lang     string
+		bands    []string
+		contains []string
+	}{
+		{"gray & purple", "", []string{"gray", "purple", "red", "gold"}, []string{"nominal=8.7kΩ", "min=8.265kΩ"}},
+		{"digits", "", []string{"4", "7", "2", "gold"}, []string{"nominal=4.7kΩ"}},
+		{"digit 0Ω jumper", "", []string{"0"}, []string{"nominal=0Ω"}},
+		{"german", "de", []string{"Gelb", "Violett", "Rot", "Gold"}, []string{"nominal=4.7kΩ", "min=4.465kΩ"}},
+		{"german umlaut", "de", []string{"braun", "schwarz", "grün", "silber"}, []string{"nominal=1MΩ", "min=900kΩ"}},
+		{"german no band", "de", []string{"braun", "schwarz", "rot", "keine"}, []string{"nominal=1kΩ", "min=800Ω"}},
+		{"french", "fr", []string{"jaune", "violet", "rouge", "or"}, []string{"nominal=4.7kΩ", "min=4.465kΩ"}},
+		{"spanish", "es", []string{"amarillo", "morado", "rojo", "plata"}, []string{"nominal=4.7kΩ", "min=4.23kΩ"}},
+		{"english within another language", "es", []string{"yellow", "violet", "rojo", "gold"}, []string{"nominal=4.7kΩ"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(
+				cmd_resistor_handler,
+				map[string]string{
+					"format": "abbr",
+					"lang":   tt.lang,
+				},
+				nil,
+				tt.bands,
+			)
+			test_utils.AssertContains(t, cmd_resistor_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestInductorColorAliases(t *testing.T) {
+	cmd := test_utils.CreateTestCommand(
+		cmd_inductor_handler,
+		map[string]string{
+			"format": "abbr",
+			"lang":   "fr",
+		},
+		nil,
+		[]string{"marron", "noir", "rouge", "argent"},
+	)
+	test_utils.AssertContains(t, cmd_inductor_handler(cmd), "nominal=1mH", "max=1.1mH")
+}
+
+func TestColorAliasesPanics(t *testing.T) {
+	test_utils.ExpectPanic(t, "invalid or unsupported: language xx", func() {
+		cmd_resistor_handler(test_utils.CreateTestCommand(cmd_resistor_handler, map[string]string{"format": "abbr", "lang": "xx"}, nil, []string{"red", "red", "red"}))
+	})
+	test_utils.ExpectPanic(t, "invalid: significant digit band color rouge", func() {
+		cmd_resistor_handler(test_utils.CreateTestCommand(cmd_resistor_handler, map[string]string{"format": "abbr", "lang": "de"}, nil, []string{"rouge", "red", "red"}))
+	})
+}
+
+//endregion Color Alias Tests
+
+//region Color Sample Tests
+
+func TestResistorColorSamples(t *testing.T) {
+	tests := []struct {
+		name     string
+		bands    []string
+		format   string
+		contains []string
+	}{
+		{"hex", []string{"#8B4513", "#232323", "#C82323", "gold"}, "abbr", []string{"nominal=1kΩ", "min=950Ω", "matches=#8B4513→brown(ΔE=16.43),#232323→black(ΔE=0),#C82323→red(ΔE=0)"}},
+		{"short hex & rgb", []string{"#f00", "rgb(255, 0, 0)", "rgb(35,35,35)"}, "abbr", []string{"nominal=22Ω", "#f00→red(ΔE=31.66)", "rgb(255, 0, 0)→red(ΔE=31.66)", "rgb(35,35,35)→black(ΔE=0)"}},
+		{"mixed with names", []string{"yellow", "violet", "#ff0000", "gold"}, "abbr", []string{"nominal=4.7kΩ", "matches=#ff0000→red"}},
+		{"no samples", []string{"yellow", "violet", "red", "gold"}, "abbr", []string{"temp_coefficient=nil"}},
+		{"json format", []string{"#8B4513", "black", "red"}, "json", []string{`"matches":[{"sample":"#8B4513","color":"brown","deltaE":16.43}]}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(
+				cmd_resistor_handler,
+				map[string]string{"format": tt.format},
+				nil,
+				tt.bands,
+			)
+			test_utils.AssertContains(t, cmd_resistor_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestResistorColorSamplesEitherDirection(t *testing.T) {
+	cmd := test_utils.CreateTestCommand(
+		cmd_resistor_handler,
+		map[string]string{
+			"format":           "abbr",
+			"either-direction": "true",
+		},
+		nil,
+		[]string{"#B99646", "_", "yellow", "violet", "yellow"},
+	)
+	test_utils.AssertContains(t, cmd_resistor_handler(cmd), "nominal=470kΩ", "direction=reverse score=1 e_series=E24 gap=fits matches=#B99646→gold(ΔE=0)")
+}
+
+func TestResistorColorSamplesPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		bands    []string
+		expected string
+	}{
+		{"invalid hex", []string{"#12345", "black", "red"}, "invalid: hex color #12345"},
+		{"invalid hex digits", []string{"#12345g", "black", "red"}, "invalid: hex color #12345g"},
+		{"invalid rgb", []string{"rgb(1,2)", "black", "red"}, "invalid: rgb color rgb(1,2)"},
+		{"rgb out of range", []string{"rgb(256,0,0)", "black", "red"}, "invalid: rgb color rgb(256,0,0)"},
+		{"sample matching gold", []string{"#B99646", "black", "red"}, "invalid: significant digit band color can not be gold"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_resistor_handler(test_utils.CreateTestCommand(cmd_resistor_handler, map[string]string{"format": "abbr"}, nil, tt.bands))
+			})
+		})
+	}
+}
+
+//endregion Color Sample Tests
+
+//region Measured Tests
+
+func TestMeasured(t *testing.T) {
+	tests := []struct {
+		name      string
+		handler   func(*cli.Command) string
+		flags     map[string]string
+		args      []string
+		contains  []string
+		exit_code int
+	}{
+		{"resistor bands pass", cmd_resistor_handler, map[string]string{"measured": "4.68k"}, []string{"yellow", "violet", "red", "gold"}, []string{"measured=4.68kΩ result=pass deviation=-0.43% deviation_sigma=-0.26σ"}, 0},
+		{"resistor bands fail", cmd_resistor_handler, map[string]string{"measured": "5k"}, []string{"yellow", "violet", "red", "gold"}, []string{"measured=5kΩ result=fail deviation=6.38% deviation_sigma=3.83σ"}, 1},
+		{"resistor rkm measured", cmd_resistor_handler, map[string]string{"measured": "4K7"}, []string{"yellow", "violet", "red", "gold"}, []string{"result=pass deviation=0% deviation_sigma=0σ"}, 0},
+		{"resistor 0Ω jumper", cmd_resistor_handler, map[string]string{"measured": "0"}, []string{"black"}, []string{"result=pass deviation=nil deviation_sigma=nil"}, 0},
+		{"resistor code", cmd_resistor_handler, map[string]string{"code": "4K7F", "measured": "4.6k"}, nil, []string{"result=fail deviation=-2.13% deviation_sigma=-6.38σ"}, 1},
+		{"resistor smd", cmd_resistor_handler, map[string]string{"smd": "01C", "measured": "10.05k"}, nil, []string{"convention=EIA-96 measured=10.05kΩ result=pass deviation=0.5% deviation_sigma=1.5σ"}, 0},
+		{"resistor json", cmd_resistor_handler, map[string]string{"measured": "5k", "format": "json"}, []string{"yellow", "violet", "red", "gold"}, []string{`"measured":5000,"measuredAbbreviated":"5kΩ","pass":false,"deviation":6.38,"deviationSigma":3.83`}, 1},
+		{"capacitor pass", cmd_capacitor_handler, map[string]string{"eiac": "6R7K", "measured": "6.5p"}, nil, []string{"measured=6.5pF result=pass deviation=-2.99% deviation_sigma=-0.9σ"}, 0},
+		{"capacitor fail", cmd_capacitor_handler, map[string]string{"eiac": "6R7K", "measured": "7.5p"}, nil, []string{"measured=7.5pF result=fail deviation=11.94% deviation_sigma=3.58σ"}, 1},
+		{"capacitor asymmetric tolerance", cmd_capacitor_handler, map[string]string{"eiac": "105Z", "measured": "1.6μ"}, nil, []string{"result=pass deviation=60% deviation_sigma=2.25σ"}, 0},
+		{"capacitor without tolerance letter", cmd_capacitor_handler, map[string]string{"eiac": "100", "measured": "13p"}, nil, []string{"nominal=10pF min=nil max=nil measured=13pF result=fail deviation=30% deviation_sigma=4.5σ"}, 1},
+		{"capacitor date code candidate", cmd_capacitor_handler, map[string]string{"eiac": "21", "measured": "22p", "candidates": "true"}, nil, []string{"convention=2-digit confidence=0.7 measured=22pF result=pass", "\nnominal=nil min=nil max=nil convention=date-code confidence=0.3"}, 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, ok := tt.flags["format"]; !ok {
+				tt.flags["format"] = "abbr"
+			}
+			cmd := test_utils.CreateTestCommand(tt.handler, tt.flags, nil, tt.args)
+			test_utils.AssertContains(t, tt.handler(cmd), tt.contains...)
+			test_utils.AssertEquals(t, cmd.ExitCode, tt.exit_code)
+		})
+	}
+}
+
+func TestMeasuredInstrument(t *testing.T) {
+	address := test_utils.StartFakeSCPIServer(t, map[string][]string{
+		"MEAS:RES?": {"+4.68000000E+03"},
+		"MEAS:CAP?": {"+7.50000000E-12"},
+	})
+
+	cmd := test_utils.CreateTestCommand(cmd_resistor_handler, map[string]string{"format": "abbr", "measured": address, "timeout": "1s"}, nil, []string{"yellow", "violet", "red", "gold"})
+	test_utils.AssertContains(t, cmd_resistor_handler(cmd), "measured=4.68kΩ result=pass")
+	test_utils.AssertEquals(t, cmd.ExitCode, 0)
+
+	cmd = test_utils.CreateTestCommand(cmd_capacitor_handler, map[string]string{"format": "abbr", "eiac": "6R7K", "measured": address, "timeout": "1s"}, nil, nil)
+	test_utils.AssertContains(t, cmd_capacitor_handler(cmd), "measured=7.5pF result=fail")
+	test_utils.AssertEquals(t, cmd.ExitCode, 1)
+
+	// an instrument that does not answer times out after -timeout
+	silent := test_utils.StartFakeSCPIServer(t, map[string][]string{})
+	cmd = test_utils.CreateTestCommand(cmd_resistor_handler, map[string]string{"format": "abbr", "measured": silent, "timeout": "50ms"}, nil, []string{"yellow", "violet", "red", "gold"})
+	test_utils.ExpectPanicContains(t, "invalid: no response to MEAS:RES? from instrument", func() {
+		cmd_resistor_handler(cmd)
+	})
+}
+
+func TestMeasuredPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		args     []string
+		expected string
+	}{
+		{"either direction", map[string]string{"format": "abbr", "measured": "4.7k", "either-direction": "true"}, []string{"yellow", "violet", "red", "gold"}, "unsupported: -measured with -either-direction"},
+		{"image", map[string]string{"format": "abbr", "measured": "4.7k", "image": "resistor.jpg"}, nil, "unsupported: -measured with -image"},
+		{"invalid timeout", map[string]string{"format": "abbr", "measured": "tcp://127.0.0.1:5025", "timeout": "0s"}, []string{"yellow", "violet", "red", "gold"}, "invalid: timeout 0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_resistor_handler(test_utils.CreateTestCommand(cmd_resistor_handler, tt.flags, nil, tt.args))
+			})
+		})
+	}
+}
+
+//endregion Measured Tests
+
+//region Image Resistance Tests
+
+type test_image_band struct {
+	from, to float64 // fraction of the body length
+	color    string
+}
+
+// write_test_resistor_image draws a resistor with leads on a plain background, rotated by angle degrees
+func write_test_resistor_image(t *testing.T, name string, angle float64, bands []test_image_band) string {
+	t.Helper()
+
+	background := color.RGBA{245, 245, 240, 255}
+	lead := color.RGBA{150, 150, 155, 255}
+	body := color.RGBA{215, 190, 150, 255}
+
+	w, h := 600, 400
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	a := angle * math.Pi / 180
+	for y := range h {
+		for x := range w {
+			dx, dy := float64(x)-float64(w)/2, float64(y)-float64(h)/2
+			along, across := dx*math.Cos(a)+dy*math.Sin(a), -dx*math.Sin(a)+dy*math.Cos(a)
+
+			c := background
+			if math.Abs(along) < 250 && math.Abs(across) < 3 {
+				c = lead
+			}
+			if math.Abs(along) < 110 && math.Abs(across) < 28 {
+				c = body
+				f := (along + 110) / 220
+				for _, band := range bands {
+					if f >= band.from && f < band.to {
+						rgb := utils.EIA_COLOR_MAPPING[band.color].Rgb
+						c = color.RGBA{rgb.R, rgb.G, rgb.B, 255}
+					}
+				}
+			}
+			img.Set(x, y, c)
+		}
+	}
+
+	path := filepath.Join(t.TempDir(), name)
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+
+	if strings.HasSuffix(name, ".jpg") {
+		err = jpeg.Encode(f, img, &jpeg.Options{Quality: 80})
+	} else {
+		err = png.Encode(f, img)
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return path
+}
+
+func TestGetResistanceFromImage(t *testing.T) {
+	four_band := []test_image_band{{.10, .17, "yellow"}, {.24, .31, "violet"}, {.38, .45, "red"}, {.80, .87, "gold"}}
+	five_band := []test_image_band{{.10, .16, "brown"}, {.22, .28, "black"}, {.34, .40, "black"}, {.46, .52, "red"}, {.82, .88, "brown"}}
+
+	tests := []struct {
+		name     string
+		file     string
+		angle    float64
+		bands    []test_image_band
+		format   string
+		contains []string
+	}{
+		{"horizontal png", "4-band.png", 0, four_band, "abbr", []string{"nominal=4.7kΩ", "bands=yellow,violet,red,gold confidence=0.9"}},
+		{"rotated jpeg", "4-band.jpg", 30, four_band, "abbr", []string{"nominal=4.7kΩ", "bands=yellow,violet,red,gold"}},
+		{"upside down", "4-band-reversed.png", 180, four_band, "abbr", []string{"nominal=4.7kΩ", "bands=yellow,violet,red,gold"}},
+		{"vertical", "4-band-vertical.png", 90, four_band, "abbr", []string{"nominal=4.7kΩ"}},
+		{"5 band", "5-band.png", 0, five_band, "abbr", []string{"nominal=10kΩ", "min=9.9kΩ", "bands=brown,black,black,red,brown"}},
+		{"alternatives", "4-band-alternatives.png", 0, four_band, "abbr", []string{"\n", "bands=yellow,blue,red,gold"}},
+		{"json format", "4-band-json.png", 0, four_band, "json", []string{`[{"nominal":4700`, `"bands":["yellow","violet","red","gold"],"confidence":0.9`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(
+				cmd_resistor_handler,
+				map[string]string{
+					"format": tt.format,
+					"image":  write_test_resistor_image(t, tt.file, tt.angle, tt.bands),
+				},
+				nil,
+				nil,
+			)
+			test_utils.AssertContains(t, cmd_resistor_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestGetResistanceFromImagePanics(t *testing.T) {
+	plain := filepath.Join(t.TempDir(), "plain.png")
+	f, _ := os.Create(plain)
+	png.Encode(f, image.NewRGBA(image.Rect(0, 0, 200, 100)))
+	f.Close()
+	test_utils.ExpectPanic(t, "invalid: no resistor found in image", func() {
+		get_resistance_from_image(plain, "abbr")
+	})
+
+	not_an_image := filepath.Join(t.TempDir(), "resistor.png")
+	os.WriteFile(not_an_image, []byte("not an image"), 0o644)
+	test_utils.ExpectPanicContains(t, "invalid or unsupported: image", func() {
+		get_resistance_from_image(not_an_image, "abbr")
+	})
+
+	missing := filepath.Join(t.TempDir(), "missing.png")
+	test_utils.ExpectPanicContains(t, "invalid: could not open image "+missing, func() {
+		get_resistance_from_image(missing, "abbr")
+	})
+}
+
+//endregion Image Resistance Tests
+
+//region Package Tests
+
+func TestCmdPackageHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		pkg      string
+		format   string
+		contains []string
+	}{
+		{"chip", "0603", "abbr", []string{"package=0603 aliases=1608M type=chip size=1.6x0.8x0.45mm power=100mW voltage=75V pads=2 pad=0.9x0.95mm pitch=nil span=1.55mm"}},
+		{"metric chip", "3216M", "abbr", []string{"package=1206", "power=250mW voltage=200V"}},
+		{"discrete", "SOT-23", "abbr", []string{"package=SOT-23 aliases=TO-236,SOT-23-3 type=transistor size=2.9x1.3x1mm", "voltage=nil pads=3 pad=1x0.6mm pitch=0.95mm span=2.2mm"}},
+		{"case insensitive", "sot-223", "abbr", []string{"package=SOT-223", "pads=4"}},
+		{"raw format", "0402", "raw", []string{"power=0.0625W voltage=50V"}},
+		{"json format", "SOT-23", "json", []string{`{"package":"SOT-23","aliases":["TO-236","SOT-23-3"],"type":"transistor","length":2.9,"width":1.3,"height":1,"power":0.35,`, `"voltage":null,"voltageAbbreviated":null,"landPattern":{"pads":3,"padLength":1,"padWidth":0.6,"pitch":0.95,"span":2.2}}`}},
+		{"json format without aliases", "SOD-123", "json", []string{`"aliases":[]`, `"pitch":null`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(cmd_package_handler, map[string]string{"format": tt.format}, nil, []string{tt.pkg})
+			test_utils.AssertContains(t, cmd_package_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestCmdPackageHandlerPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"no package", nil, "too few arguments: [args...]"},
+		{"2 packages", []string{"0603", "0805"}, "too many arguments: [args...]"},
+		{"unknown package", []string{"0608"}, "invalid or unsupported: package 0608"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_package_handler(test_utils.CreateTestCommand(cmd_package_handler, map[string]string{"format": "abbr"}, nil, tt.args))
+			})
+		})
+	}
+}
+
+//endregion Package Tests
+//region Part Number Tests
+
+func TestPartNumberRuleExamples(t *testing.T) {
+	for _, rule := range part_number_rules {
+		t.Run(rule.Manufacturer+" "+rule.Series, func(t *testing.T) {
+			p := decode_part_number(rule.Example)
+			test_utils.AssertEquals(t, p.rule, rule)
+		})
+	}
+}
+
+func TestCmdPartHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		part     string
+		format   string
+		contains []string
+	}{
+		{"yageo", "RC0603FR-0710KL", "abbr", []string{`manufacturer=Yageo series=RC component=resistor package=0603 nominal=10kΩ min=9.9kΩ max=10.1kΩ tolerance=±1% temp_coefficient=nil voltage=nil dielectric=nil packaging="paper tape, 7in reel"`}},
+		{"yageo jumper", "RC0603JR-070RL", "abbr", []string{"nominal=0Ω", "tolerance=±5%"}},
+		{"vishay", "CRCW060310K0FKEA", "abbr", []string{"manufacturer=Vishay series=CRCW", "nominal=10kΩ", "temp_coefficient=100 ppm/K", `packaging="paper tape, 5000 pcs"`}},
+		{"panasonic 4 digit", "ERJ-3EKF1002V", "abbr", []string{"manufacturer=Panasonic series=ERJ component=resistor package=0603 nominal=10kΩ", "tolerance=±1%"}},
+		{"panasonic 3 digit", "ERJ-3GEYJ103V", "abbr", []string{"package=0603 nominal=10kΩ min=9.5kΩ max=10.5kΩ tolerance=±5%"}},
+		{"panasonic RKM without dash", "ERJ2RKF4R70X", "abbr", []string{"package=0402 nominal=4.7Ω", `packaging="paper tape, 2mm pitch"`}},
+		{"murata", "GRM188R71H104KA93D", "abbr", []string{"manufacturer=Murata series=GRM component=capacitor package=0603", "min=90nF max=110nF tolerance=±10% temp_coefficient=nil voltage=50V dielectric=X7R"}},
+		{"murata R-decimal", "GRM1555C1H1R0CA01D", "abbr", []string{"package=0402 nominal=1pF", "dielectric=C0G"}},
+		{"samsung", "CL21A106KOQNNNE", "abbr", []string{"manufacturer=Samsung series=CL component=capacitor package=0805 nominal=10μF min=9μF max=11μF tolerance=±10% temp_coefficient=nil voltage=16V dielectric=X5R", `packaging="embossed tape, 7in reel"`}},
+		{"kemet", "C0603C103K5RAC7411", "abbr", []string{"manufacturer=KEMET series=C component=capacitor package=0603 nominal=10nF min=9nF max=11nF", "voltage=50V dielectric=X7R", `packaging="tape, 13in reel"`}},
+		{"kemet bulk", "C1206C473J1GAC", "abbr", []string{"voltage=100V dielectric=C0G", `packaging="bulk"`}},
+		{"kemet fractional multiplier", "C0402C109C5GACTU", "abbr", []string{"package=0402 nominal=1pF", "voltage=50V dielectric=C0G"}},
+		{"case & whitespace insensitive", "cl10b104kb8nnnc", "abbr", []string{"manufacturer=Samsung", "voltage=50V"}},
+		{"samsung 500V", "CL31B103KGFNNNE", "abbr", []string{"manufacturer=Samsung", "voltage=500V"}},
+		{"samsung 630V", "CL31B103KHFNNNE", "abbr", []string{"manufacturer=Samsung", "voltage=630V"}},
+		{"raw format", "RC0603FR-0710KL", "raw", []string{"nominal=10000Ω min=9900Ω max=10100Ω"}},
+		{"json format", "CL10B104KB8NNNC", "json", []string{`{"manufacturer":"Samsung","series":"CL","component":"capacitor","package":"0603"`, `"toleranceMin":-10,"toleranceMax":10,"temperatureCoefficient":null,"voltage":50,"voltageAbbreviated":"50V","dielectric":"X7R","packaging":"paper tape, 7in reel"}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(cmd_part_handler, map[string]string{"format": tt.format}, nil, []string{tt.part})
+			test_utils.AssertContains(t, cmd_part_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestCmdPartHandlerPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		expected string
+	}{
+		{"no part number", nil, "too few arguments: [args...]"},
+		{"2 part numbers", []string{"RC0603FR-0710KL", "RC0603FR-0710KL"}, "too many arguments: [args...]"},
+		{"unknown part number", []string{"XYZ123"}, "invalid or unsupported: part number XYZ123"},
+		{"unknown packaging", []string{"RC0603FR-0910KL"}, "invalid or unsupported: Yageo packaging code R-09"},
+		{"unknown tolerance", []string{"RC0603XR-0710KL"}, "invalid or unsupported: Yageo tolerance code X"},
+		{"unknown voltage", []string{"CL10B104KZ8NNNC"}, "invalid or unsupported: Samsung voltage code Z"},
+		{"unknown temperature coefficient", []string{"CRCW060310K0FXEA"}, "invalid or unsupported: Vishay temperature coefficient code X"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_part_handler(test_utils.CreateTestCommand(cmd_part_handler, map[string]string{"format": "abbr"}, nil, tt.args))
+			})
+		})
+	}
+}
+
+//endregion Part Number Tests
+
+//region SMD Resistance Tests
+
+func TestGetResistanceFromSMD(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		format   string
+		contains []string
+	}{
+		{"3 digit", "472", "abbr", []string{"nominal=4.7kΩ", "min=4.465kΩ", "max=4.935kΩ", "convention=3-digit"}},
+		{"4 digit", "4702", "abbr", []string{"nominal=47kΩ", "min=46.53kΩ", "max=47.47kΩ", "convention=4-digit"}},
+		{"RKM decimal", "4R7", "abbr", []string{"nominal=4.7Ω", "convention=RKM"}},
+		{"RKM 4 digit", "10R0", "abbr", []string{"nominal=10Ω", "min=9.9Ω", "max=10.1Ω", "convention=RKM"}},
+		{"EIA-96 C", "01C", "abbr", []string{"nominal=10kΩ", "min=9.9kΩ", "max=10.1kΩ", "convention=EIA-96"}},
+		{"EIA-96 X", "68X", "abbr", []string{"nominal=49.9Ω", "convention=EIA-96"}},
+		{"EIA-96 R", "47R", "abbr", []string{"nominal=3.01Ω", "convention=EIA-96"}},
+		{"EIA-96 H", "96H", "abbr", []string{"nominal=9.76kΩ", "convention=EIA-96"}},
+		{"jumper", "000", "abbr", []string{"nominal=0Ω", "min=0Ω", "max=0Ω", "convention=jumper"}},
+		{"raw format", "103", "raw", []string{"nominal=10000Ω", "min=9500Ω", "max=10500Ω"}},
+		{"json format", "01C", "json", []string{`"nominal":10000`, `"nominalAbbreviated":"10kΩ"`, `"convention":"EIA-96"`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(
+				cmd_resistor_handler,
+				map[string]string{
+					"format": tt.format,
+					"smd":    tt.code,
+				},
+				nil,
+				nil,
+			)
+			test_utils.AssertContains(t, cmd_resistor_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestGetResistanceFromSMDPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		code     string
+		expected string
+	}{
+		{"EIA-96 code out of range", "97A", "invalid: EIA-96 code 97"},
+		{"EIA-96 code zero", "00A", "invalid: EIA-96 code 00"},
+		{"EIA-96 invalid multiplier", "01Q", "invalid or unsupported: EIA-96 multiplier Q"},
+		{"invalid digits", "4x2", "invalid: smd resistor code 4x2"},
+		{"too long", "47021", "unsupported: 5 digit smd codes"},
+		{"invalid RKM", "4R7R", "invalid: smd resistor code 4R7R"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				get_resistance_from_smd(tt.code, nil, "abbr")
+			})
+		})
+	}
+}
+
+//endregion SMD Resistance Tests
+
+//region Inductance Tests
+
+func TestCmdInductorHandler(t *testing.T) {
+	tests := []struct {
+		name     string
+		bands    []string
+		smd      string
+		format   string
+		contains []string
+	}{
+		{"4 band", []string{"brown", "black", "red", "silver"}, "", "abbr", []string{"nominal=1mH", "max=1.1mH"}},
+		{"3 band default tolerance", []string{"yellow", "violet", "black"}, "", "raw", []string{"nominal=0.000047H", "min=0.0000376H"}},
+		{"gold multiplier", []string{"yellow", "violet", "gold", "gold"}, "", "abbr", []string{"nominal=4.7μH", "min=4.465μH"}},
+		{"gold decimal point 2nd band", []string{"red", "gold", "violet", "gold"}, "", "abbr", []string{"nominal=2.7μH", "min=2.565μH", "max=2.835μH"}},
+		{"gold decimal point 1st band", []string{"gold", "red", "red", "black"}, "", "raw", []string{"nominal=0.00000022H"}},
+		{"wide silver mil-spec band", []string{"silver", "red", "gold", "violet", "gold"}, "", "abbr", []string{"█", "nominal=2.7μH"}},
+		{"abbreviated color names", []string{"bn", "bk", "rd", "og"}, "", "abbr", []string{"nominal=1mH", "min=970μH", "max=1.03mH"}},
+		{"smd RKM", nil, "4R7", "abbr", []string{"nominal=4.7μH", "min=nil", "max=nil"}},
+		{"smd 3 digit with tolerance", nil, "102K", "abbr", []string{"nominal=1mH", "max=1.1mH"}},
+		{"smd json", nil, "4R7J", "json", []string{`"nominal":0.0000047`, `"nominalAbbreviated":"4.7μH"`, `"actualMax":0.000004935`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(
+				cmd_inductor_handler,
+				map[string]string{
+					"format": tt.format,
+					"smd":    tt.smd,
+				},
+				nil,
+				tt.bands,
+			)
+			test_utils.AssertContains(t, cmd_inductor_handler(cmd), tt.contains...)
+		})
+	}
+}
+
+func TestCmdInductorHandlerPanics(t *testing.T) {
+	tests := []struct {
+		name     string
+		bands    []string
+		smd      string
+		expected string
+	}{
+		{"too few bands", []string{"brown", "black"}, "", "too few arguments: [args...]"},
+		{"too many bands", []string{"brown", "black", "red", "gold", "gold"}, "", "too many arguments: [args...]"},
+		{"invalid color", []string{"brown", "magenta", "red"}, "", "invalid: inductor band color magenta"},
+		{"silver significant digit", []string{"brown", "silver", "red"}, "", "invalid: significant digit band color can not be silver"},
+		{"2 decimal points", []string{"gold", "gold", "red"}, "", "invalid: inductor can only have 1 decimal point band"},
+		{"decimal point with silver digit", []string{"red", "gold", "silver"}, "", "invalid: significant digit band color can not be silver"},
+		{"invalid tolerance", []string{"brown", "black", "red", "green"}, "", "invalid: tolerance band color green"},
+		{"smd invalid tolerance", nil, "102X", "invalid or unsupported: inductor tolerance identifier: X"},
+		{"smd invalid code", nil, "1x2", "invalid: inductor code 1x2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cmd := test_utils.CreateTestCommand(
+				cmd_inductor_handler,
+				map[string]string{
+					"format": "abbr",
+					"smd":    tt.smd,
+				},
+				nil,
+				tt.bands,
+			)
+			test_utils.ExpectPanic(t, tt.expected, func() {
+				cmd_inductor_handler(cmd)
+			})
+		})
+	}
+}
+
+//endregion Inductance Tests
@@ -0,0 +1,267 @@
+package identify
+
+import (
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/cli"
+	"gohm/utils"
+	"math"
+	"strconv"
+	"strings"
+)
+
+type inductor_band struct {
+	utils.ColorBand
+
+	tolerance *utils.Tolerance
+}
+
+// inductor_band_mapping follows the mil-spec inductor color code - values are in μH
+var inductor_band_mapping = map[string]*inductor_band{
+	"black": {
+		ColorBand: utils.EIA_COLOR_MAPPING["black"],
+		tolerance: &utils.Tolerance{
+			Value:    20,
+			UnitType: utils.UNIT_TYPE_PERCENT,
+		},
+	},
+	"brown": {
+		ColorBand: utils.EIA_COLOR_MAPPING["brown"],
+		tolerance: &utils.Tolerance{
+			Value:    1,
+			UnitType: utils.UNIT_TYPE_PERCENT,
+		},
+	},
+	"red": {
+		ColorBand: utils.EIA_COLOR_MAPPING["red"],
+		tolerance: &utils.Tolerance{
+			Value:    2,
+			UnitType: utils.UNIT_TYPE_PERCENT,
+		},
+	},
+	"orange": {
+		ColorBand: utils.EIA_COLOR_MAPPING["orange"],
+		tolerance: &utils.Tolerance{
+			Value:    3,
+			UnitType: utils.UNIT_TYPE_PERCENT,
+		},
+	},
+	"yellow": {
+		ColorBand: utils.EIA_COLOR_MAPPING["yellow"],
+		tolerance: &utils.Tolerance{
+			Value:    4,
+			UnitType: utils.UNIT_TYPE_PERCENT,
+		},
+	},
+	"green": {
+		ColorBand: utils.EIA_COLOR_MAPPING["green"],
+		tolerance: nil,
+	},
+	"blue": {
+		ColorBand: utils.EIA_COLOR_MAPPING["blue"],
+		tolerance: nil,
+	},
+	"violet": {
+		ColorBand: utils.EIA_COLOR_MAPPING["violet"],
+		tolerance: nil,
+	},
+	"grey": {
+		ColorBand: utils.EIA_COLOR_MAPPING["grey"],
+		tolerance: nil,
+	},
+	"white": {
+		ColorBand: utils.EIA_COLOR_MAPPING["white"],
+		tolerance: nil,
+	},
+	"gold": {
+		ColorBand: utils.EIA_COLOR_MAPPING["gold"],
+		tolerance: &utils.Tolerance{
+			Value:    5,
+			UnitType: utils.UNIT_TYPE_PERCENT,
+		},
+	},
+	"silver": {
+		ColorBand: utils.EIA_COLOR_MAPPING["silver"],
+		tolerance: &utils.Tolerance{
+			Value:    10,
+			UnitType: utils.UNIT_TYPE_PERCENT,
+		},
+	},
+}
+
+func init() {
+	inductor_band_mapping[abbrvs.SI_SILVER] = inductor_band_mapping["silver"]
+	inductor_band_mapping[abbrvs.SI_GOLD] = inductor_band_mapping["gold"]
+	inductor_band_mapping[abbrvs.SI_BLACK] = inductor_band_mapping["black"]
+	inductor_band_mapping[abbrvs.SI_BROWN] = inductor_band_mapping["brown"]
+	inductor_band_mapping[abbrvs.SI_RED] = inductor_band_mapping["red"]
+	inductor_band_mapping[abbrvs.SI_ORANGE] = inductor_band_mapping["orange"]
+	inductor_band_mapping[abbrvs.SI_YELLOW] = inductor_band_mapping["yellow"]
+	inductor_band_mapping[abbrvs.SI_GREEN] = inductor_band_mapping["green"]
+	inductor_band_mapping[abbrvs.SI_BLUE] = inductor_band_mapping["blue"]
+	inductor_band_mapping[abbrvs.SI_VIOLET] = inductor_band_mapping["violet"]
+	inductor_band_mapping[abbrvs.SI_GREY] = inductor_band_mapping["grey"]
+	inductor_band_mapping[abbrvs.SI_WHITE] = inductor_band_mapping["white"]
+}
+
+func cmd_inductor_handler(cmd *cli.Command) string {
+	format := cmd.GetFlagValue("format")
+
+	if cmd.IsFlagSet("smd") {
+		return get_inductance_from_smd(cmd.GetFlagValue("smd"), format)
+	}
+
+	return get_inductance_from_bands(utils.ResolveColorAliases(cmd.Args, cmd.GetFlagValue("lang")), format)
+}
+
+// get_inductance_from_bands decodes the mil-spec 4 band inductor color code
+//
+// A leading (wide) silver band only identifies a mil-spec part and is skipped.
+// A gold band in the 1st or 2nd position is a decimal point, in which case the remaining 2 bands are significant digits and there is no multiplier
+func get_inductance_from_bands(args []string, format string) string {
+	bands := args
+	bands_visual := strings.Builder{}
+
+	if len(bands) > 0 && inductor_band_mapping[strings.ToLower(bands[0])] == inductor_band_mapping["silver"] {
+		bands_visual.WriteString(utils.EIA_COLOR_MAPPING["silver"].Ansi)
+		bands_visual.WriteString("█ ")
+		bands = bands[1:]
+	}
+
+	if len(bands) < 3 {
+		panic("too few arguments: [args...]")
+	} else if len(bands) > 4 {
+		panic("too many arguments: [args...]")
+	}
+
+	value_bands := make([]*inductor_band, 3)
+	decimal_index := -1
+	for i := range value_bands {
+		band, ok := inductor_band_mapping[strings.ToLower(bands[i])]
+		if !ok {
+			panic(fmt.Errorf("invalid: inductor band color %s", bands[i]))
+		}
+
+		if i < 2 && band == inductor_band_mapping["gold"] {
+			if decimal_index != -1 {
+				panic("invalid: inductor can only have 1 decimal point band")
+			}
+			decimal_index = i
+		} else if i < 2 && band.SignificantNumeral < 0 {
+			panic(fmt.Errorf("invalid: significant digit band color can not be %s", bands[i]))
+		}
+
+		value_bands[i] = band
+		bands_visual.WriteString(band.Ansi)
+		bands_visual.WriteString("▌")
+	}
+
+	value_uh := 0.
+	switch decimal_index {
+	case 0, 1:
+		digits := []string{}
+		for i, band := range value_bands {
+			if i == decimal_index {
+				continue
+			}
+			if band.SignificantNumeral < 0 {
+				panic(fmt.Errorf("invalid: significant digit band color can not be %s", bands[i]))
+			}
+			digits = append(digits, strconv.Itoa(band.SignificantNumeral))
+		}
+
+		if decimal_index == 0 {
+			value_uh, _ = strconv.ParseFloat("0."+digits[0]+digits[1], 64)
+		} else {
+			value_uh, _ = strconv.ParseFloat(digits[0]+"."+digits[1], 64)
+		}
+	default:
+		value_uh = float64(value_bands[0].SignificantNumeral*10 + value_bands[1].SignificantNumeral)
+		if value_bands[2].Multiplier < 0 {
+			value_uh /= math.Pow10(-value_bands[2].Multiplier)
+		} else {
+			value_uh *= math.Pow10(value_bands[2].Multiplier)
+		}
+	}
+
+	tolerance := default_tolerance
+	if len(bands) == 4 {
+		tolerance_band, ok := inductor_band_mapping[strings.ToLower(bands[3])]
+		if !ok || tolerance_band.tolerance == nil {
+			panic(fmt.Errorf("invalid: tolerance band color %s", bands[3]))
+		}
+		tolerance = *tolerance_band.tolerance
+
+		bands_visual.WriteString(utils.ANSI_RESET)
+		bands_visual.WriteString(" ")
+		bands_visual.WriteString(tolerance_band.Ansi)
+		bands_visual.WriteString("▌")
+	}
+
+	bands_visual.WriteString(utils.ANSI_RESET)
+
+	return format_inductance(bands_visual.String()+" ", value_uh/utils.MATH_POW_MEGA, &letter_tolerance{min: tolerance, max: tolerance}, format)
+}
+
+// get_inductance_from_smd decodes RKM (4R7) & 3 digit (101) inductor markings - values are in μH - with an optional tolerance letter suffix
+func get_inductance_from_smd(val string, format string) string {
+	var tolerance *letter_tolerance = nil
+
+	if len_val := len(val); len_val > 2 && val[len_val-1] != abbrvs.RKM_RESISTOR && utils.IsLetter(val[len_val-1]) {
+		t, ok := get_letter_tolerance(val[len_val-1])
+		if !ok {
+			panic(fmt.Errorf("invalid or unsupported: inductor tolerance identifier: %s", string(val[len_val-1])))
+		}
+		tolerance = &t
+		val = val[:len_val-1]
+	}
+
+	value_uh := 0.
+	if strings.ContainsRune(val, abbrvs.RKM_RESISTOR) {
+		v, err := utils.ParseRKMCode(val, abbrvs.RKM_RESISTOR)
+		if err != nil {
+			panic(err)
+		}
+		value_uh = v
+	} else if len(val) == 3 && utils.IsDigit(val[0]) && utils.IsDigit(val[1]) && utils.IsDigit(val[2]) {
+		value_uh = float64(int(val[0]-'0')*10+int(val[1]-'0')) * math.Pow10(int(val[2]-'0'))
+	} else {
+		panic(fmt.Errorf("invalid: inductor code %s", val))
+	}
+
+	return format_inductance("", value_uh/utils.MATH_POW_MEGA, tolerance, format)
+}
+
+func format_inductance(prefix string, nominal_value float64, tolerance *letter_tolerance, format string) string {
+	actual_min, actual_max := 0., 0.
+	if tolerance != nil {
+		actual_min = nominal_value * (1 - tolerance.min.Value/100)
+		actual_max = nominal_value * (1 + tolerance.max.Value/100)
+	}
+
+	switch format {
+	case "json":
+		return fmt.Sprintf(`{"nominal":%s,"nominalAbbreviated":"%sH","actualMin":%s,"actualMinAbbreviated":%s,"actualMax":%s,"actualMaxAbbreviated":%s}`,
+			utils.FormatFloat(nominal_value),
+			utils.GetAbbreviatedValue(nominal_value),
+			utils.If(tolerance != nil, utils.FormatFloat(actual_min), "null"),
+			utils.If(tolerance != nil, `"`+utils.GetAbbreviatedValue(actual_min)+`H"`, "null"),
+			utils.If(tolerance != nil, utils.FormatFloat(actual_max), "null"),
+			utils.If(tolerance != nil, `"`+utils.GetAbbreviatedValue(actual_max)+`H"`, "null"),
+		)
+	case "raw":
+		return fmt.Sprintf("%snominal=%sH min=%s max=%s",
+			prefix,
+			utils.FormatFloat(nominal_value),
+			utils.If(tolerance != nil, utils.FormatFloat(actual_min)+"H", "nil"),
+			utils.If(tolerance != nil, utils.FormatFloat(actual_max)+"H", "nil"),
+		)
+	default:
+		return fmt.Sprintf("%snominal=%sH min=%s max=%s",
+			prefix,
+			utils.GetAbbreviatedValue(nominal_value),
+			utils.If(tolerance != nil, utils.GetAbbreviatedValue(actual_min)+"H", "nil"),
+			utils.If(tolerance != nil, utils.GetAbbreviatedValue(actual_max)+"H", "nil"),
+		)
+	}
+}
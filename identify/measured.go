@@ -0,0 +1,97 @@
+package identify
+
+import (
+	"fmt"
+	"gohm/cli"
+	"gohm/measure"
+	"gohm/utils"
+	"math"
+)
+
+// measured_sigmas is the number of standard deviations covered by a tolerance band
+const measured_sigmas = 3.
+
+// measured_check holds a measured value & the result of the check against the first reading it is verified against
+type measured_check struct {
+	value    float64
+	unit     string
+	verified bool
+	pass     bool
+}
+
+// new_measured_check parses the measured flag - nil when it is not set
+//
+// An instrument address, i.e. tcp://192.168.1.50:5025, takes a single reading of the quantity from the instrument within
+// the -timeout
+func new_measured_check(cmd *cli.Command, rkm_target rune, targets []string, quantity string) *measured_check {
+	if !cmd.IsFlagSet("measured") {
+		return nil
+	}
+
+	val := cmd.GetFlagValue("measured")
+	unit := measure.SCPI_QUANTITY_MAPPING[quantity].Unit
+
+	if measure.IsInstrumentAddress(val) {
+		value, err := measure.MeasureOnce(val, quantity, measure.GetTimeout(cmd))
+		if err != nil {
+			panic(err)
+		}
+		return &measured_check{value: value, unit: unit}
+	}
+
+	return &measured_check{
+		value: utils.GetValueForRKMElseShorthand(val, rkm_target, targets),
+		unit:  unit,
+	}
+}
+
+// set_measured_exit_code reports a failed check through the exit code of the command
+func set_measured_exit_code(cmd *cli.Command, m *measured_check) {
+	if m != nil && m.verified && !m.pass {
+		cmd.ExitCode = 1
+	}
+}
+
+// verify_measured checks the measured value against the min/max of a reading & returns the result as a suffix for the
+// abbr/raw output & as a fragment for the json output
+//
+// The deviation in σ takes the tolerance on the side of the deviation as ±3σ
+func verify_measured(m *measured_check, nominal float64, actual_min float64, actual_max float64, format string) (string, string) {
+	if m == nil {
+		return "", ""
+	}
+
+	pass := m.value >= actual_min && m.value <= actual_max
+	if !m.verified {
+		m.verified, m.pass = true, pass
+	}
+
+	deviation, sigma := math.NaN(), math.NaN()
+	if nominal != 0 {
+		deviation = math.Round((m.value-nominal)/nominal*100*100) / 100
+
+		if half_width := utils.If(m.value >= nominal, actual_max-nominal, nominal-actual_min); half_width > 0 {
+			sigma = math.Round((m.value-nominal)/(half_width/measured_sigmas)*100) / 100
+		}
+	}
+
+	switch format {
+	case "json":
+		return "", fmt.Sprintf(`,"measured":%s,"measuredAbbreviated":"%s%s","pass":%t,"deviation":%s,"deviationSigma":%s`,
+			utils.FormatFloat(m.value),
+			utils.GetAbbreviatedValue(m.value),
+			m.unit,
+			pass,
+			utils.If(math.IsNaN(deviation), "null", utils.FormatFloat(deviation)),
+			utils.If(math.IsNaN(sigma), "null", utils.FormatFloat(sigma)),
+		)
+	default:
+		return fmt.Sprintf(" measured=%s%s result=%s deviation=%s deviation_sigma=%s",
+			utils.If(format == "raw", utils.FormatFloat(m.value), utils.GetAbbreviatedValue(m.value)),
+			m.unit,
+			utils.If(pass, "pass", "fail"),
+			utils.If(math.IsNaN(deviation), "nil", utils.FormatFloat(deviation)+"%"),
+			utils.If(math.IsNaN(sigma), "nil", utils.FormatFloat(sigma)+"σ"),
+		), ""
+	}
+}
@@ -0,0 +1,71 @@
+package identify
+
+import (
+	"fmt"
+	"gohm/cli"
+	"gohm/utils"
+	"strings"
+)
+
+func cmd_package_handler(cmd *cli.Command) string {
+	if cmd.ArgsLength == 0 {
+		panic("too few arguments: [args...]")
+	} else if cmd.ArgsLength > 1 {
+		panic("too many arguments: [args...]")
+	}
+
+	p, ok := utils.GetPackage(cmd.Args[0])
+	if !ok {
+		panic(fmt.Errorf("invalid or unsupported: package %s", cmd.Args[0]))
+	}
+
+	return format_package(p, cmd.GetFlagValue("format"))
+}
+
+func format_package(p *utils.Package, format string) string {
+	land := p.LandPattern
+
+	switch format {
+	case "json":
+		aliases := "[]"
+		if len(p.Aliases) > 0 {
+			aliases = `["` + strings.Join(p.Aliases, `","`) + `"]`
+		}
+
+		return fmt.Sprintf(`{"package":"%s","aliases":%s,"type":"%s","length":%s,"width":%s,"height":%s,"power":%s,"powerAbbreviated":"%sW","voltage":%s,"voltageAbbreviated":%s,"landPattern":{"pads":%d,"padLength":%s,"padWidth":%s,"pitch":%s,"span":%s}}`,
+			p.Name,
+			aliases,
+			p.Type,
+			utils.FormatFloat(p.Length),
+			utils.FormatFloat(p.Width),
+			utils.FormatFloat(p.Height),
+			utils.FormatFloat(p.Power),
+			utils.GetAbbreviatedValue(p.Power),
+			utils.If(p.Voltage != 0, utils.FormatFloat(p.Voltage), "null"),
+			utils.If(p.Voltage != 0, `"`+utils.GetAbbreviatedValue(p.Voltage)+`V"`, "null"),
+			land.Pads,
+			utils.FormatFloat(land.PadLength),
+			utils.FormatFloat(land.PadWidth),
+			utils.If(land.Pitch != 0, utils.FormatFloat(land.Pitch), "null"),
+			utils.FormatFloat(land.Span),
+		)
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		return fmt.Sprintf("package=%s aliases=%s type=%s size=%sx%sx%smm power=%sW voltage=%s pads=%d pad=%sx%smm pitch=%s span=%smm",
+			p.Name,
+			utils.If(len(p.Aliases) > 0, strings.Join(p.Aliases, ","), "nil"),
+			p.Type,
+			utils.FormatFloat(p.Length),
+			utils.FormatFloat(p.Width),
+			utils.FormatFloat(p.Height),
+			value(p.Power),
+			utils.If(p.Voltage != 0, value(p.Voltage)+"V", "nil"),
+			land.Pads,
+			utils.FormatFloat(land.PadLength),
+			utils.FormatFloat(land.PadWidth),
+			utils.If(land.Pitch != 0, utils.FormatFloat(land.Pitch)+"mm", "nil"),
+			utils.FormatFloat(land.Span),
+		)
+	}
+}
@@ -0,0 +1,229 @@
+package identify
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/cli"
+	"gohm/utils"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// parts.json holds the part number decode rules, tried in order - a rule matches the part number against a pattern whose
+// named groups are the fields of the part:
+//
+//	package, value, tolerance, voltage, dielectric, temp_coefficient & packaging
+//
+// Any other group is matched but not decoded. A field map translates the manufacturer code of a field into its
+// standard code (i.e. the Samsung voltage code B into the EIA voltage code 1H) or, for package & packaging, into its
+// description. Fields without a map are read as standard codes: the IEC 60062 tolerance & temperature coefficient
+// letters and the EIA voltage & dielectric codes. The value is decoded as an RKM ("rkm") or EIA ("eia") code
+//
+//go:embed parts.json
+var parts_json []byte
+
+type part_number_rule struct {
+	Manufacturer string                       `json:"manufacturer"`
+	Series       string                       `json:"series"`
+	Component    string                       `json:"component"`
+	Example      string                       `json:"example"`
+	Pattern      string                       `json:"pattern"`
+	Value        string                       `json:"value"`
+	Fields       map[string]map[string]string `json:"fields"`
+	pattern      *regexp.Regexp
+}
+
+type part_number struct {
+	rule       *part_number_rule
+	package_   string
+	nominal    float64
+	tolerance  *letter_tolerance
+	temp_ce    int     // ppm/K, -1 when not encoded
+	voltage    float64 // -1 when not encoded
+	dielectric string
+	packaging  string
+}
+
+var part_number_rules []*part_number_rule
+
+func init() {
+	if err := json.Unmarshal(parts_json, &part_number_rules); err != nil {
+		panic(fmt.Errorf("invalid: part number rules: %w", err))
+	}
+
+	for _, rule := range part_number_rules {
+		rule.pattern = regexp.MustCompile(rule.Pattern)
+	}
+}
+
+func cmd_part_handler(cmd *cli.Command) string {
+	if cmd.ArgsLength == 0 {
+		panic("too few arguments: [args...]")
+	} else if cmd.ArgsLength > 1 {
+		panic("too many arguments: [args...]")
+	}
+
+	return format_part_number(decode_part_number(cmd.Args[0]), cmd.GetFlagValue("format"))
+}
+
+// decode_part_number decodes a part number with the first rule whose pattern it matches
+func decode_part_number(val string) *part_number {
+	code := strings.ToUpper(strings.Join(strings.Fields(val), ""))
+
+	for _, rule := range part_number_rules {
+		match := rule.pattern.FindStringSubmatch(code)
+		if match == nil {
+			continue
+		}
+
+		p := &part_number{rule: rule, temp_ce: -1, voltage: -1}
+
+		for i, name := range rule.pattern.SubexpNames() {
+			if name == "" {
+				continue
+			}
+
+			field := get_part_field(rule, name, match[i])
+
+			switch name {
+			case "package":
+				p.package_ = field
+			case "value":
+				p.nominal = get_part_value(rule, field)
+			case "tolerance":
+				if len(field) != 1 {
+					panic(fmt.Errorf("invalid or unsupported: %s tolerance code %s", rule.Manufacturer, match[i]))
+				}
+				t, ok := get_letter_tolerance(field[0])
+				if !ok {
+					panic(fmt.Errorf("invalid or unsupported: %s tolerance code %s", rule.Manufacturer, match[i]))
+				}
+				p.tolerance = &t
+			case "temp_coefficient":
+				if _, ok := rule.Fields[name]; ok {
+					p.temp_ce, _ = strconv.Atoi(field)
+				} else if len(field) == 1 && resistor_temp_ce_letter_mapping[field[0]] != 0 {
+					p.temp_ce = resistor_temp_ce_letter_mapping[field[0]]
+				} else {
+					panic(fmt.Errorf("invalid or unsupported: %s temperature coefficient code %s", rule.Manufacturer, match[i]))
+				}
+			case "voltage":
+				p.voltage = get_capacitor_voltage(field)
+			case "dielectric":
+				p.dielectric = get_capacitor_dielectric(field).code
+			case "packaging":
+				p.packaging = field
+			}
+		}
+
+		return p
+	}
+
+	panic(fmt.Errorf("invalid or unsupported: part number %s", val))
+}
+
+// get_part_field translates the manufacturer code of a field with its field map - codes of fields without a map are returned as is
+func get_part_field(rule *part_number_rule, name string, code string) string {
+	mapping, ok := rule.Fields[name]
+	if !ok {
+		return code
+	}
+
+	field, ok := mapping[code]
+	if !ok {
+		panic(fmt.Errorf("invalid or unsupported: %s %s code %s", rule.Manufacturer, utils.If(name == "temp_coefficient", "temperature coefficient", name), code))
+	}
+	return field
+}
+
+// get_part_value decodes the value field with the RKM & EIA parsers
+func get_part_value(rule *part_number_rule, code string) float64 {
+	switch rule.Value {
+	case "rkm":
+		v, err := utils.ParseRKMCode(code, utils.If(rule.Component == "capacitor", abbrvs.RKM_FARAD, abbrvs.RKM_RESISTOR))
+		if err != nil {
+			panic(err)
+		}
+		return v
+	case "eia":
+		if rule.Component == "capacitor" {
+			// the most confident candidate so the fractional multipliers 8 & 9 decode, i.e. 109 as 1pF
+			candidates, err := get_capacitor_candidates(code)
+			if err != nil {
+				panic(err)
+			}
+			return candidates[0].nominal
+		}
+
+		nominal, _, _ := decode_smd_resistor(code)
+		return nominal
+	}
+
+	panic(fmt.Errorf("invalid or unsupported: %s value decoder %s", rule.Manufacturer, rule.Value))
+}
+
+func format_part_number(p *part_number, format string) string {
+	unit := utils.If(p.rule.Component == "capacitor", "F", "Ω")
+
+	actual_min, actual_max := p.nominal, p.nominal
+	if p.tolerance != nil {
+		actual_min = p.nominal * (1 - p.tolerance.min.Value/100)
+		actual_max = p.nominal * (1 + p.tolerance.max.Value/100)
+	}
+
+	switch format {
+	case "json":
+		return fmt.Sprintf(`{"manufacturer":"%s","series":"%s","component":"%s","package":%s,"nominal":%s,"nominalAbbreviated":"%s%s","actualMin":%s,"actualMinAbbreviated":"%s%s","actualMax":%s,"actualMaxAbbreviated":"%s%s","toleranceMin":%s,"toleranceMax":%s,"temperatureCoefficient":%s,"voltage":%s,"voltageAbbreviated":%s,"dielectric":%s,"packaging":%s}`,
+			p.rule.Manufacturer,
+			p.rule.Series,
+			p.rule.Component,
+			utils.If(p.package_ != "", `"`+p.package_+`"`, "null"),
+			utils.FormatFloat(p.nominal),
+			utils.GetAbbreviatedValue(p.nominal),
+			unit,
+			utils.FormatFloat(actual_min),
+			utils.GetAbbreviatedValue(actual_min),
+			unit,
+			utils.FormatFloat(actual_max),
+			utils.GetAbbreviatedValue(actual_max),
+			unit,
+			utils.If(p.tolerance != nil, utils.FormatFloat(-p.tolerance.min.Value), "null"),
+			utils.If(p.tolerance != nil, utils.FormatFloat(p.tolerance.max.Value), "null"),
+			utils.If(p.temp_ce != -1, fmt.Sprint(p.temp_ce), "null"),
+			utils.If(p.voltage != -1, utils.FormatFloat(p.voltage), "null"),
+			utils.If(p.voltage != -1, `"`+utils.GetAbbreviatedValue(p.voltage)+`V"`, "null"),
+			utils.If(p.dielectric != "", `"`+p.dielectric+`"`, "null"),
+			utils.If(p.packaging != "", `"`+p.packaging+`"`, "null"),
+		)
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		tolerance := "nil"
+		if p.tolerance != nil && p.tolerance.min.Value == p.tolerance.max.Value {
+			tolerance = "±" + utils.FormatFloat(p.tolerance.max.Value) + "%"
+		} else if p.tolerance != nil {
+			tolerance = "+" + utils.FormatFloat(p.tolerance.max.Value) + "%/-" + utils.FormatFloat(p.tolerance.min.Value) + "%"
+		}
+
+		return fmt.Sprintf("manufacturer=%s series=%s component=%s package=%s nominal=%s%s min=%s%s max=%s%s tolerance=%s temp_coefficient=%s voltage=%s dielectric=%s packaging=%s",
+			p.rule.Manufacturer,
+			p.rule.Series,
+			p.rule.Component,
+			utils.If(p.package_ != "", p.package_, "nil"),
+			value(p.nominal),
+			unit,
+			value(actual_min),
+			unit,
+			value(actual_max),
+			unit,
+			tolerance,
+			utils.If(p.temp_ce != -1, fmt.Sprintf("%d ppm/K", p.temp_ce), "nil"),
+			utils.If(p.voltage != -1, value(p.voltage)+"V", "nil"),
+			utils.If(p.dielectric != "", p.dielectric, "nil"),
+			utils.If(p.packaging != "", `"`+p.packaging+`"`, "nil"),
+		)
+	}
+}
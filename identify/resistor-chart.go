@@ -0,0 +1,147 @@
+package identify
+
+import (
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/utils"
+	"math"
+	"slices"
+	"strings"
+)
+
+// resistor_chart_colors is the IEC 60062 color order used when printing the chart
+var resistor_chart_colors = []string{"black", "brown", "red", "orange", "yellow", "green", "blue", "violet", "grey", "white", "gold", "silver", "pink", "none"}
+
+var resistor_chart_abbreviations = map[string]string{
+	"black":  abbrvs.SI_BLACK,
+	"brown":  abbrvs.SI_BROWN,
+	"red":    abbrvs.SI_RED,
+	"orange": abbrvs.SI_ORANGE,
+	"yellow": abbrvs.SI_YELLOW,
+	"green":  abbrvs.SI_GREEN,
+	"blue":   abbrvs.SI_BLUE,
+	"violet": abbrvs.SI_VIOLET,
+	"grey":   abbrvs.SI_GREY,
+	"white":  abbrvs.SI_WHITE,
+	"gold":   abbrvs.SI_GOLD,
+	"silver": abbrvs.SI_SILVER,
+	"pink":   abbrvs.SI_PINK,
+}
+
+// GetResistorChart returns the IEC 60062 color code, tolerance letter & temperature coefficient letter tables
+func GetResistorChart(format string) string {
+	var sb strings.Builder
+
+	tolerance_letters := []byte{}
+	for letter := range tolerance_letter_mapping {
+		tolerance_letters = append(tolerance_letters, letter)
+	}
+	slices.SortFunc(tolerance_letters, func(a, b byte) int {
+		ta, tb := tolerance_letter_mapping[a], tolerance_letter_mapping[b]
+		if d := (ta.min.Value + ta.max.Value) - (tb.min.Value + tb.max.Value); d != 0 {
+			return int(math.Copysign(1, d))
+		}
+		return int(a) - int(b)
+	})
+
+	temp_ce_letters := []byte{}
+	for letter := range resistor_temp_ce_letter_mapping {
+		temp_ce_letters = append(temp_ce_letters, letter)
+	}
+	slices.SortFunc(temp_ce_letters, func(a, b byte) int {
+		return resistor_temp_ce_letter_mapping[b] - resistor_temp_ce_letter_mapping[a]
+	})
+
+	if format == "json" {
+		sb.WriteString(`{"colors":[`)
+		for i, color := range resistor_chart_colors {
+			band := resistor_band_mapping[color]
+
+			tolerance := "null"
+			if band.tolerance != nil {
+				tolerance = utils.FormatFloat(band.tolerance.Value)
+			}
+
+			fmt.Fprintf(&sb, `{"color":"%s","abbreviation":%s,"significant":%s,"multiplier":%s,"tolerance":%s,"toleranceLetter":%s,"temperatureCoefficient":%s,"temperatureCoefficientLetter":%s}`,
+				color,
+				utils.If(resistor_chart_abbreviations[color] != "", `"`+resistor_chart_abbreviations[color]+`"`, "null"),
+				utils.If(band.SignificantNumeral >= 0, fmt.Sprint(band.SignificantNumeral), "null"),
+				utils.If(color != "none", utils.FormatFloat(math.Pow10(band.Multiplier)), "null"),
+				tolerance,
+				utils.If(band.tolerance_letter != 0, `"`+string(band.tolerance_letter)+`"`, "null"),
+				utils.If(band.is_valid_temp_ce_band, fmt.Sprint(band.temp_ce), "null"),
+				utils.If(band.temp_ce_letter != 0, `"`+string(band.temp_ce_letter)+`"`, "null"),
+			)
+			if i != len(resistor_chart_colors)-1 {
+				sb.WriteRune(',')
+			}
+		}
+
+		sb.WriteString(`],"toleranceLetters":[`)
+		for i, letter := range tolerance_letters {
+			t := tolerance_letter_mapping[letter]
+			fmt.Fprintf(&sb, `{"letter":"%s","toleranceMin":%s,"toleranceMax":%s}`, string(letter), utils.FormatFloat(-t.min.Value), utils.FormatFloat(t.max.Value))
+			if i != len(tolerance_letters)-1 {
+				sb.WriteRune(',')
+			}
+		}
+
+		sb.WriteString(`],"temperatureCoefficientLetters":[`)
+		for i, letter := range temp_ce_letters {
+			fmt.Fprintf(&sb, `{"letter":"%s","temperatureCoefficient":%d}`, string(letter), resistor_temp_ce_letter_mapping[letter])
+			if i != len(temp_ce_letters)-1 {
+				sb.WriteRune(',')
+			}
+		}
+		sb.WriteString(`]}`)
+
+		return sb.String()
+	}
+
+	fmt.Fprintf(&sb, "  %-7s %-4s %-5s %-10s %-9s %-6s %-9s %s\n", "color", "abbr", "digit", "multiplier", "tolerance", "letter", "tempco", "letter")
+	for _, color := range resistor_chart_colors {
+		band := resistor_band_mapping[color]
+
+		multiplier := "-"
+		if color != "none" {
+			value := math.Pow10(band.Multiplier)
+			multiplier = "×" + utils.If(format == "raw" || value < 1, utils.FormatFloat(value), utils.GetAbbreviatedValue(value))
+		}
+
+		tolerance := "-"
+		if band.tolerance != nil {
+			tolerance = "±" + utils.FormatFloat(band.tolerance.Value) + "%"
+		}
+
+		fmt.Fprintf(&sb, "%s%s%s %-7s %-4s %-5s %-10s %-9s %-6s %-9s %s\n",
+			band.Ansi,
+			utils.If(color != "none", "▌", " "),
+			utils.ANSI_RESET,
+			color,
+			utils.If(resistor_chart_abbreviations[color] != "", resistor_chart_abbreviations[color], "-"),
+			utils.If(band.SignificantNumeral >= 0, fmt.Sprint(band.SignificantNumeral), "-"),
+			multiplier,
+			tolerance,
+			utils.If(band.tolerance_letter != 0, string(band.tolerance_letter), "-"),
+			utils.If(band.is_valid_temp_ce_band, fmt.Sprintf("%dppm/K", band.temp_ce), "-"),
+			utils.If(band.temp_ce_letter != 0, string(band.temp_ce_letter), "-"),
+		)
+	}
+
+	sb.WriteString("\ntolerance letters:")
+	for _, letter := range tolerance_letters {
+		t := tolerance_letter_mapping[letter]
+		if t.min.Value == t.max.Value {
+			fmt.Fprintf(&sb, " %s=±%s%%", string(letter), utils.FormatFloat(t.max.Value))
+		} else {
+			fmt.Fprintf(&sb, " %s=+%s%%/-%s%%", string(letter), utils.FormatFloat(t.max.Value), utils.FormatFloat(t.min.Value))
+		}
+	}
+
+	sb.WriteString("\ntemperature coefficient letters:")
+	for _, letter := range temp_ce_letters {
+		fmt.Fprintf(&sb, " %s=%dppm/K", string(letter), resistor_temp_ce_letter_mapping[letter])
+	}
+
+	return sb.String()
+}
@@ -0,0 +1,106 @@
+package identify
+
+import (
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/utils"
+	"strings"
+)
+
+// resistor_temp_ce_letter_mapping maps the IEC 60062 temperature coefficient letters to ppm/K
+//
+// Z is shared by green (20 ppm/K) & blue (10 ppm/K) and is read as the worse 20 ppm/K
+var resistor_temp_ce_letter_mapping = map[byte]int{
+	'U': 250,
+	'S': 100,
+	'R': 50,
+	'Q': 25,
+	'Z': 20,
+	'P': 15,
+	'M': 5,
+	'K': 1,
+}
+
+// get_resistance_from_code decodes the IEC 60062 letter & digit code, i.e. 4K7 (4.7kΩ), R47 (0.47Ω) or 15L (15mΩ),
+// followed by an optional tolerance letter & an optional temperature coefficient letter, i.e. 4K7JS
+func get_resistance_from_code(val string, measured *measured_check, format string) string {
+	code := strings.ToUpper(strings.TrimSpace(val))
+
+	i := 0
+	for i < len(code) && utils.IsDigit(code[i]) {
+		i++
+	}
+
+	if i >= len(code) {
+		panic(fmt.Errorf("invalid: resistor letter code %s", val))
+	} else if _, ok := utils.RKM_MAPPING[abbrvs.RKM_RESISTOR][rune(code[i])]; !ok && code[i] != abbrvs.RKM_RESISTOR {
+		panic(fmt.Errorf("invalid or unsupported: resistor letter code multiplier %s", string(code[i])))
+	}
+
+	j := i + 1
+	for j < len(code) && utils.IsDigit(code[j]) {
+		j++
+	}
+
+	nominal_value, err := utils.ParseRKMCode(code[:j], abbrvs.RKM_RESISTOR)
+	if err != nil {
+		panic(err)
+	}
+
+	suffix := code[j:]
+	if len(suffix) > 2 {
+		panic(fmt.Errorf("invalid: resistor letter code suffix %s", code[j:]))
+	}
+
+	tolerance := letter_tolerance{min: default_tolerance, max: default_tolerance}
+	if len(suffix) > 0 {
+		t, ok := get_letter_tolerance(suffix[0])
+		if !ok {
+			panic(fmt.Errorf("invalid or unsupported: resistor tolerance identifier: %s", string(code[j])))
+		}
+		tolerance = t
+	}
+
+	temp_ce := -1
+	if len(suffix) > 1 {
+		t, ok := resistor_temp_ce_letter_mapping[suffix[1]]
+		if !ok {
+			panic(fmt.Errorf("invalid or unsupported: resistor temperature coefficient identifier: %s", string(code[j+1])))
+		}
+		temp_ce = t
+	}
+
+	actual_min := nominal_value * (1 - tolerance.min.Value/100)
+	actual_max := nominal_value * (1 + tolerance.max.Value/100)
+	measured_suffix, measured_json_suffix := verify_measured(measured, nominal_value, actual_min, actual_max, format)
+
+	switch format {
+	case "json":
+		return fmt.Sprintf(`{"nominal":%s,"nominalAbbreviated":"%sΩ","actualMin":%s,"actualMinAbbreviated":"%sΩ","actualMax":%s,"actualMaxAbbreviated":"%sΩ","temperatureCoefficient":%s%s}`,
+			utils.FormatFloat(nominal_value),
+			utils.GetAbbreviatedValue(nominal_value),
+			utils.FormatFloat(actual_min),
+			utils.GetAbbreviatedValue(actual_min),
+			utils.FormatFloat(actual_max),
+			utils.GetAbbreviatedValue(actual_max),
+			utils.If(temp_ce != -1, fmt.Sprint(temp_ce), "null"),
+			measured_json_suffix,
+		)
+	case "raw":
+		return fmt.Sprintf("nominal=%sΩ min=%sΩ max=%sΩ temp_coefficient=%s%s",
+			utils.FormatFloat(nominal_value),
+			utils.FormatFloat(actual_min),
+			utils.FormatFloat(actual_max),
+			utils.If(temp_ce != -1, fmt.Sprintf("%d ppm/K", temp_ce), "nil"),
+			measured_suffix,
+		)
+	default:
+		return fmt.Sprintf("nominal=%sΩ min=%sΩ max=%sΩ temp_coefficient=%s%s",
+			utils.GetAbbreviatedValue(nominal_value),
+			utils.GetAbbreviatedValue(actual_min),
+			utils.GetAbbreviatedValue(actual_max),
+			utils.If(temp_ce != -1, fmt.Sprintf("%d ppm/K", temp_ce), "nil"),
+			measured_suffix,
+		)
+	}
+}
@@ -0,0 +1,163 @@
+package identify
+
+import (
+	"fmt"
+	"gohm/utils"
+	"math"
+	"slices"
+	"strings"
+)
+
+// RESISTOR_BAND_GAP marks the visible gap between bands when passed as an arg, i.e. brown black red _ gold
+const RESISTOR_BAND_GAP = "_"
+
+const (
+	resistor_score_weight_tolerance = .3
+	resistor_score_weight_e_series  = .4
+	resistor_score_weight_gap       = .3
+)
+
+type resistor_direction_reading struct {
+	direction string
+	reading   *resistor_reading
+	err       error
+	score     float64
+	e_series  string
+	gap_fits  *bool
+}
+
+// get_resistance_either_direction decodes the bands left to right & right to left and ranks both readings by plausibility
+//
+// A reading scores for a common tolerance color in the tolerance position, a value that exists in an E series (preferably the
+// one matching its tolerance) and, when a gap is marked, the gap sitting right before the tolerance band
+//
+// suffix & json_suffix are appended to every decoded reading
+func get_resistance_either_direction(args []string, suffix string, json_suffix string, format string) string {
+	gap_index := slices.Index(args, RESISTOR_BAND_GAP)
+	bands := slices.DeleteFunc(slices.Clone(args), func(s string) bool {
+		return s == RESISTOR_BAND_GAP
+	})
+
+	reversed := slices.Clone(bands)
+	slices.Reverse(reversed)
+
+	readings := []*resistor_direction_reading{
+		score_resistor_reading("forward", bands, gap_index),
+		score_resistor_reading("reverse", reversed, utils.If(gap_index == -1, -1, len(bands)-gap_index)),
+	}
+
+	// neither direction decodes - surface the error of the reading as given
+	if readings[0].err != nil && readings[1].err != nil {
+		panic(readings[0].err)
+	}
+
+	slices.SortStableFunc(readings, func(a, b *resistor_direction_reading) int {
+		if a.score > b.score {
+			return -1
+		} else if a.score < b.score {
+			return 1
+		}
+		return 0
+	})
+
+	var sb strings.Builder
+
+	if format == "json" {
+		sb.WriteRune('[')
+	}
+
+	for i, r := range readings {
+		gap := "nil"
+		if r.gap_fits != nil {
+			gap = utils.If(*r.gap_fits, "fits", "misfit")
+		}
+
+		if r.err != nil {
+			if format == "json" {
+				fmt.Fprintf(&sb, `{"direction":"%s","score":0,"error":%q}`, r.direction, r.err.Error())
+			} else {
+				fmt.Fprintf(&sb, "direction=%s score=0 error=%q", r.direction, r.err.Error())
+			}
+		} else if format == "json" {
+			sb.WriteString(format_resistor_reading(r.reading, "", fmt.Sprintf(`,"direction":"%s","score":%s,"eSeries":%s,"gap":%s%s`,
+				r.direction,
+				utils.FormatFloat(r.score),
+				utils.If(r.e_series != "", `"`+r.e_series+`"`, "null"),
+				utils.If(gap != "nil", `"`+gap+`"`, "null"),
+				json_suffix,
+			), format))
+		} else {
+			sb.WriteString(format_resistor_reading(r.reading, fmt.Sprintf(" direction=%s score=%s e_series=%s gap=%s%s",
+				r.direction,
+				utils.FormatFloat(r.score),
+				utils.If(r.e_series != "", r.e_series, "nil"),
+				gap,
+				suffix,
+			), "", format))
+		}
+
+		if i != len(readings)-1 {
+			sb.WriteString(utils.If(format == "json", ",", "\n"))
+		}
+	}
+
+	if format == "json" {
+		sb.WriteRune(']')
+	}
+
+	return sb.String()
+}
+
+// gap_index is the number of bands before the marked gap or -1 when no gap was marked
+func score_resistor_reading(direction string, bands []string, gap_index int) *resistor_direction_reading {
+	result := &resistor_direction_reading{
+		direction: direction,
+	}
+
+	reading, err := decode_resistor_bands(bands)
+	if err != nil {
+		result.err = err
+		return result
+	}
+	result.reading = reading
+
+	score, applicable := 0., resistor_score_weight_e_series
+
+	if reading.tolerance_color != "" {
+		applicable += resistor_score_weight_tolerance
+		// gold, silver, brown & red
+		switch reading.tolerance_letter {
+		case 'J', 'K', 'F', 'G':
+			score += resistor_score_weight_tolerance
+		default:
+			score += resistor_score_weight_tolerance / 2
+		}
+	}
+
+	expected_series := utils.GetESeriesForTolerance(reading.tolerance * 100)
+	if utils.IsInESeries(reading.nominal, utils.E_SERIES_MAPPING[expected_series]) {
+		score += resistor_score_weight_e_series
+		result.e_series = expected_series
+	} else {
+		for _, name := range []string{"E6", "E12", "E24", "E48", "E96", "E192"} {
+			if utils.IsInESeries(reading.nominal, utils.E_SERIES_MAPPING[name]) {
+				score += resistor_score_weight_e_series / 2
+				result.e_series = name
+				break
+			}
+		}
+	}
+
+	if gap_index != -1 && reading.tolerance_color != "" {
+		applicable += resistor_score_weight_gap
+		tolerance_index := utils.If(reading.temp_ce_color != "", len(bands)-2, len(bands)-1)
+		fits := gap_index == tolerance_index
+		result.gap_fits = &fits
+		if fits {
+			score += resistor_score_weight_gap
+		}
+	}
+
+	result.score = math.Round(score/applicable*100) / 100
+	return result
+}
@@ -0,0 +1,424 @@
+package identify
+
+import (
+	"errors"
+	"fmt"
+	"gohm/utils"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"math"
+	"os"
+	"slices"
+	"strings"
+)
+
+const (
+	image_max_side              = 400 // images are sampled down to roughly this many pixels on their longest side
+	image_min_foreground        = 50  // min number of sampled pixels that differ from the background
+	image_foreground_delta_e    = 20. // min ΔE from the background for a pixel to belong to the resistor
+	image_body_thickness        = .6  // fraction of the thickest cross section that still counts as resistor body
+	image_band_delta_e          = 14. // min ΔE from the body color for a cross section to belong to a band
+	image_color_sigma           = 12. // ΔE spread of the color model when converting distances to probabilities
+	image_min_color_probability = .05
+	image_max_colors_per_band   = 3
+	image_max_candidates        = 3
+	image_decisive_gap          = 1.5 // the widest gap must be this much wider than the median gap to orient the bands
+	image_misfit_gap_weight     = .2
+)
+
+type image_band struct {
+	lab        utils.Lab
+	start, end int
+	colors     []image_band_color
+}
+
+type image_band_color struct {
+	name        string
+	probability float64
+}
+
+type image_candidate struct {
+	bands      []string
+	reading    *resistor_reading
+	confidence float64
+}
+
+// get_resistance_from_image locates the resistor body in a photo, samples the bands along its axis & classifies them
+// against the EIA color model - every plausible band combination is decoded and the most likely readings are returned
+func get_resistance_from_image(path string, format string) string {
+	f, err := os.Open(path)
+	if err != nil {
+		panic(fmt.Errorf("invalid: could not open image %s: %w", path, err))
+	}
+	defer f.Close()
+
+	img, _, err := image.Decode(f)
+	if err != nil {
+		panic(fmt.Errorf("invalid or unsupported: image %s - %w", path, err))
+	}
+
+	bands, err := locate_image_bands(img)
+	if err != nil {
+		panic(err)
+	}
+
+	candidates := get_image_candidates(bands)
+	if len(candidates) == 0 {
+		detected := make([]string, len(bands))
+		for i, band := range bands {
+			detected[i] = band.colors[0].name
+		}
+		panic(fmt.Errorf("invalid: no valid resistor reading for the detected bands %s", strings.Join(detected, ",")))
+	}
+
+	var sb strings.Builder
+
+	if format == "json" {
+		sb.WriteRune('[')
+	}
+
+	for i, c := range candidates {
+		if format == "json" {
+			sb.WriteString(format_resistor_reading(c.reading, "", fmt.Sprintf(`,"bands":["%s"],"confidence":%s`,
+				strings.Join(c.bands, `","`),
+				utils.FormatFloat(c.confidence),
+			), format))
+		} else {
+			sb.WriteString(format_resistor_reading(c.reading, fmt.Sprintf(" bands=%s confidence=%s",
+				strings.Join(c.bands, ","),
+				utils.FormatFloat(c.confidence),
+			), "", format))
+		}
+
+		if i != len(candidates)-1 {
+			sb.WriteString(utils.If(format == "json", ",", "\n"))
+		}
+	}
+
+	if format == "json" {
+		sb.WriteRune(']')
+	}
+
+	return sb.String()
+}
+
+// locate_image_bands separates the resistor from the background, finds the body along the principal axis of the
+// resistor & splits the color profile of the body center line into bands
+func locate_image_bands(img image.Image) ([]*image_band, error) {
+	bounds := img.Bounds()
+	step := max(1, max(bounds.Dx(), bounds.Dy())/image_max_side)
+	w, h := bounds.Dx()/step, bounds.Dy()/step
+	if w < 3 || h < 3 {
+		return nil, errors.New("invalid: image too small")
+	}
+
+	// average every step x step block to smooth out noise & compression artifacts
+	grid := make([]utils.Lab, w*h)
+	for y := range h {
+		for x := range w {
+			r, g, b := 0., 0., 0.
+			for dy := range step {
+				for dx := range step {
+					pr, pg, pb, _ := img.At(bounds.Min.X+x*step+dx, bounds.Min.Y+y*step+dy).RGBA()
+					r, g, b = r+float64(pr>>8), g+float64(pg>>8), b+float64(pb>>8)
+				}
+			}
+			n := float64(step * step)
+			grid[y*w+x] = utils.RGB{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n)}.ToLab()
+		}
+	}
+
+	border := make([]utils.Lab, 0, 2*(w+h))
+	for x := range w {
+		border = append(border, grid[x], grid[(h-1)*w+x])
+	}
+	for y := range h {
+		border = append(border, grid[y*w], grid[y*w+w-1])
+	}
+	background := median_lab(border)
+
+	xs, ys := []float64{}, []float64{}
+	mx, my := 0., 0.
+	for y := range h {
+		for x := range w {
+			if utils.DeltaE(grid[y*w+x], background) > image_foreground_delta_e {
+				xs, ys = append(xs, float64(x)), append(ys, float64(y))
+				mx, my = mx+float64(x), my+float64(y)
+			}
+		}
+	}
+
+	if len(xs) < image_min_foreground {
+		return nil, errors.New("invalid: no resistor found in image")
+	}
+
+	mx, my = mx/float64(len(xs)), my/float64(len(xs))
+
+	// principal axis of the foreground pixels
+	cxx, cyy, cxy := 0., 0., 0.
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		cxx, cyy, cxy = cxx+dx*dx, cyy+dy*dy, cxy+dx*dy
+	}
+	angle := .5 * math.Atan2(2*cxy, cxx-cyy)
+	ux, uy := math.Cos(angle), math.Sin(angle)
+
+	t_min, t_max := math.Inf(1), math.Inf(-1)
+	for i := range xs {
+		t := (xs[i]-mx)*ux + (ys[i]-my)*uy
+		t_min, t_max = min(t_min, t), max(t_max, t)
+	}
+
+	// cross sections perpendicular to the axis - the leads are thin, the body is thick
+	counts := make([]int, int(math.Round(t_max-t_min))+1)
+	offsets := make([]float64, len(counts))
+	for i := range xs {
+		dx, dy := xs[i]-mx, ys[i]-my
+		bin := int(math.Round(dx*ux + dy*uy - t_min))
+		counts[bin]++
+		offsets[bin] += -dx*uy + dy*ux
+	}
+
+	peak := 0
+	for i := range counts {
+		if counts[i] > counts[peak] {
+			peak = i
+		}
+	}
+
+	// light bands on a light background thin out the cross section, so short dips are tolerated
+	threshold := int(math.Ceil(image_body_thickness * float64(counts[peak])))
+	tolerated := max(2, counts[peak]/3)
+	lo, hi := peak, peak
+	for i, dips := peak-1, 0; i >= 0 && dips <= tolerated; i-- {
+		if counts[i] >= threshold {
+			lo, dips = i, 0
+		} else {
+			dips++
+		}
+	}
+	for i, dips := peak+1, 0; i < len(counts) && dips <= tolerated; i++ {
+		if counts[i] >= threshold {
+			hi, dips = i, 0
+		} else {
+			dips++
+		}
+	}
+
+	thicknesses, centers := []float64{}, []float64{}
+	for i := lo; i <= hi; i++ {
+		if counts[i] >= threshold {
+			thicknesses = append(thicknesses, float64(counts[i]))
+			centers = append(centers, offsets[i]/float64(counts[i]))
+		}
+	}
+	thickness, center := median(thicknesses), median(centers)
+
+	// rounded end caps would mix the background into the profile
+	trim := int(thickness / 8)
+	lo, hi = lo+trim, hi-trim
+	if hi-lo < 4 {
+		return nil, errors.New("invalid: no resistor body found in image")
+	}
+
+	// color profile along the center line of the body
+	profile := make([]utils.Lab, 0, hi-lo+1)
+	for i := lo; i <= hi; i++ {
+		t := float64(i) + t_min
+		samples := []utils.Lab{}
+		for s := center - thickness/4; s <= center+thickness/4; s++ {
+			x := int(math.Round(mx + t*ux - s*uy))
+			y := int(math.Round(my + t*uy + s*ux))
+			if x >= 0 && x < w && y >= 0 && y < h {
+				samples = append(samples, grid[y*w+x])
+			}
+		}
+		profile = append(profile, mean_lab(samples))
+	}
+
+	body := median_lab(profile)
+
+	bands := []*image_band{}
+	var current *image_band = nil
+	for i, lab := range profile {
+		if utils.DeltaE(lab, body) <= image_band_delta_e {
+			current = nil
+			continue
+		}
+
+		if current != nil && utils.DeltaE(lab, mean_lab(profile[current.start:i])) <= image_band_delta_e {
+			current.end = i
+			continue
+		}
+
+		current = &image_band{start: i, end: i}
+		bands = append(bands, current)
+	}
+
+	min_width := max(1, len(profile)/40)
+	bands = slices.DeleteFunc(bands, func(b *image_band) bool {
+		return b.end-b.start+1 < min_width
+	})
+
+	if len(bands) != 1 && (len(bands) < 3 || len(bands) > 6) {
+		return nil, fmt.Errorf("invalid: found %d bands in image", len(bands))
+	}
+
+	for _, band := range bands {
+		// the band edges blend into the body, so only the central half is used
+		width := band.end - band.start + 1
+		band.lab = mean_lab(profile[band.start+width/4 : band.end-width/4+1])
+		band.colors = classify_image_band(band.lab)
+	}
+
+	return bands, nil
+}
+
+// classify_image_band converts the ΔE to every EIA color into a probability & keeps the most likely colors
+func classify_image_band(lab utils.Lab) []image_band_color {
+	matches := utils.ClassifyColor(lab)
+
+	total := 0.
+	colors := make([]image_band_color, len(matches))
+	for i, m := range matches {
+		colors[i] = image_band_color{
+			name:        m.Name,
+			probability: math.Exp(-(m.DeltaE * m.DeltaE) / (2 * image_color_sigma * image_color_sigma)),
+		}
+		total += colors[i].probability
+	}
+
+	for i := range colors {
+		colors[i].probability = utils.If(total > 0, colors[i].probability/total, utils.If(i == 0, 1., 0.))
+	}
+
+	// the nearest color is always kept, even when the sample is far from every color of the model
+	result := []image_band_color{colors[0]}
+	for _, c := range colors[1:] {
+		if len(result) >= image_max_colors_per_band || c.probability < image_min_color_probability {
+			break
+		}
+		result = append(result, c)
+	}
+
+	return result
+}
+
+// get_image_candidates decodes every combination of likely band colors in both directions - the widest gap between bands
+// is expected right before the tolerance band
+func get_image_candidates(bands []*image_band) []*image_candidate {
+	n := len(bands)
+	forward_weight, reverse_weight := 1., 1.
+
+	if n >= 4 {
+		gaps := make([]float64, n-1)
+		widest := 0
+		for i := range gaps {
+			gaps[i] = float64(bands[i+1].start - bands[i].end)
+			if gaps[i] > gaps[widest] {
+				widest = i
+			}
+		}
+
+		tolerance_gap := utils.If(n == 6, n-3, n-2)
+		if sorted := slices.Sorted(slices.Values(gaps)); gaps[widest] > image_decisive_gap*median(sorted[:len(sorted)-1]) {
+			if widest == tolerance_gap {
+				reverse_weight = image_misfit_gap_weight
+			} else if widest == n-2-tolerance_gap {
+				forward_weight = image_misfit_gap_weight
+			}
+		}
+	}
+
+	candidates := []*image_candidate{}
+	seen := map[string]bool{}
+
+	var walk func(order []*image_band, i int, names []string, p float64, weight float64)
+	walk = func(order []*image_band, i int, names []string, p float64, weight float64) {
+		if i == len(order) {
+			key := strings.Join(names, ",")
+			if seen[key] {
+				return
+			}
+			seen[key] = true
+
+			reading, err := decode_resistor_bands(names)
+			if err != nil {
+				return
+			}
+			candidates = append(candidates, &image_candidate{
+				bands:      slices.Clone(names),
+				reading:    reading,
+				confidence: p * weight,
+			})
+			return
+		}
+
+		for _, c := range order[i].colors {
+			walk(order, i+1, append(names, c.name), p*c.probability, weight)
+		}
+	}
+
+	walk(bands, 0, []string{}, 1, forward_weight)
+	reversed := slices.Clone(bands)
+	slices.Reverse(reversed)
+	walk(reversed, 0, []string{}, 1, reverse_weight)
+
+	total := 0.
+	for _, c := range candidates {
+		total += c.confidence
+	}
+
+	slices.SortStableFunc(candidates, func(a, b *image_candidate) int {
+		if a.confidence > b.confidence {
+			return -1
+		} else if a.confidence < b.confidence {
+			return 1
+		}
+		return 0
+	})
+
+	if len(candidates) > image_max_candidates {
+		candidates = candidates[:image_max_candidates]
+	}
+
+	for _, c := range candidates {
+		c.confidence = math.Round(c.confidence/total*100) / 100
+	}
+
+	return candidates
+}
+
+func median(values []float64) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+
+	sorted := slices.Sorted(slices.Values(values))
+	if len(sorted)%2 == 1 {
+		return sorted[len(sorted)/2]
+	}
+	return (sorted[len(sorted)/2-1] + sorted[len(sorted)/2]) / 2
+}
+
+func median_lab(labs []utils.Lab) utils.Lab {
+	ls, as, bs := make([]float64, len(labs)), make([]float64, len(labs)), make([]float64, len(labs))
+	for i, lab := range labs {
+		ls[i], as[i], bs[i] = lab.L, lab.A, lab.B
+	}
+	return utils.Lab{L: median(ls), A: median(as), B: median(bs)}
+}
+
+func mean_lab(labs []utils.Lab) utils.Lab {
+	if len(labs) == 0 {
+		return utils.Lab{}
+	}
+
+	sum := utils.Lab{}
+	for _, lab := range labs {
+		sum.L, sum.A, sum.B = sum.L+lab.L, sum.A+lab.A, sum.B+lab.B
+	}
+	n := float64(len(labs))
+	return utils.Lab{L: sum.L / n, A: sum.A / n, B: sum.B / n}
+}
@@ -0,0 +1,125 @@
+package identify
+
+import (
+	"fmt"
+	"gohm/abbrvs"
+	"gohm/utils"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// eia96_multiplier_mapping maps an EIA-96 multiplier letter to its power of 10
+var eia96_multiplier_mapping = map[byte]int{
+	'Z': -3,
+	'Y': -2,
+	'R': -2,
+	'X': -1,
+	'S': -1,
+	'A': 0,
+	'B': 1,
+	'H': 1,
+	'C': 2,
+	'D': 3,
+	'E': 4,
+	'F': 5,
+}
+
+// get_resistance_from_smd decodes 3 digit (±5%), 4 digit (±1%), RKM & EIA-96 (±1%) SMD resistor markings
+//
+// A trailing letter after 2 digits is always read as an EIA-96 multiplier, so 47R is 3.01Ω and not 47Ω
+func get_resistance_from_smd(val string, measured *measured_check, format string) string {
+	nominal_value, tolerance, convention := decode_smd_resistor(val)
+
+	actual_min := nominal_value * (1 - tolerance)
+	actual_max := nominal_value * (1 + tolerance)
+	measured_suffix, measured_json_suffix := verify_measured(measured, nominal_value, actual_min, actual_max, format)
+
+	switch format {
+	case "json":
+		return fmt.Sprintf(`{"nominal":%s,"nominalAbbreviated":"%sΩ","actualMin":%s,"actualMinAbbreviated":"%sΩ","actualMax":%s,"actualMaxAbbreviated":"%sΩ","convention":"%s"%s}`,
+			utils.FormatFloat(nominal_value),
+			utils.GetAbbreviatedValue(nominal_value),
+			utils.FormatFloat(actual_min),
+			utils.GetAbbreviatedValue(actual_min),
+			utils.FormatFloat(actual_max),
+			utils.GetAbbreviatedValue(actual_max),
+			convention,
+			measured_json_suffix,
+		)
+	case "raw":
+		return fmt.Sprintf("nominal=%sΩ min=%sΩ max=%sΩ convention=%s%s",
+			utils.FormatFloat(nominal_value),
+			utils.FormatFloat(actual_min),
+			utils.FormatFloat(actual_max),
+			convention,
+			measured_suffix,
+		)
+	default:
+		return fmt.Sprintf("nominal=%sΩ min=%sΩ max=%sΩ convention=%s%s",
+			utils.GetAbbreviatedValue(nominal_value),
+			utils.GetAbbreviatedValue(actual_min),
+			utils.GetAbbreviatedValue(actual_max),
+			convention,
+			measured_suffix,
+		)
+	}
+}
+
+// decode_smd_resistor returns the nominal value, tolerance (fraction) & convention of an SMD resistor marking
+func decode_smd_resistor(val string) (float64, float64, string) {
+	len_val := len(val)
+	nominal_value := 0.
+	tolerance := 0.
+	convention := ""
+
+	if len_val < 1 || len_val > 4 {
+		panic(fmt.Errorf("unsupported: %d digit smd codes", len_val))
+	}
+
+	if strings.Trim(val, "0") == "" {
+		convention = "jumper"
+	} else if len_val == 3 && utils.IsDigit(val[0]) && utils.IsDigit(val[1]) && utils.IsLetter(val[2]) {
+		code, _ := strconv.Atoi(val[0:2])
+		if code < 1 || code > len(utils.E96) {
+			panic(fmt.Errorf("invalid: EIA-96 code %s", val[0:2]))
+		}
+
+		multiplier, ok := eia96_multiplier_mapping[val[2]]
+		if !ok {
+			panic(fmt.Errorf("invalid or unsupported: EIA-96 multiplier %s", string(val[2])))
+		}
+
+		if multiplier < 0 {
+			nominal_value = utils.E96[code-1] / math.Pow10(-multiplier)
+		} else {
+			nominal_value = utils.E96[code-1] * math.Pow10(multiplier)
+		}
+		tolerance = .01
+		convention = "EIA-96"
+	} else if strings.ContainsRune(val, abbrvs.RKM_RESISTOR) {
+		v, err := utils.ParseRKMCode(val, abbrvs.RKM_RESISTOR)
+		if err != nil {
+			panic(fmt.Errorf("invalid: smd resistor code %s", val))
+		}
+
+		nominal_value = v
+		tolerance = utils.If(len_val == 4, .01, .05)
+		convention = "RKM"
+	} else if len_val == 3 || len_val == 4 {
+		for i := range val {
+			if !utils.IsDigit(val[i]) {
+				panic(fmt.Errorf("invalid: smd resistor code %s", val))
+			}
+		}
+
+		significant, _ := strconv.Atoi(val[0 : len_val-1])
+		nominal_value = float64(significant) * math.Pow10(int(val[len_val-1]-'0'))
+		tolerance = utils.If(len_val == 4, .01, .05)
+		convention = strconv.Itoa(len_val) + "-digit"
+	} else {
+		panic(fmt.Errorf("invalid: smd resistor code %s", val))
+	}
+
+	return nominal_value, tolerance, convention
+}
@@ -1,9 +1,11 @@
 package identify
 
 import (
+	"errors"
 	"fmt"
 	"gohm/abbrvs"
 	"gohm/cli"
+	"gohm/measure"
 	"gohm/utils"
 	"math"
 	"strconv"
@@ -14,16 +16,20 @@ type resistor_band struct {
 	utils.ColorBand
 
 	tolerance             *utils.Tolerance
+	tolerance_letter      byte
 	is_valid_temp_ce_band bool
 	temp_ce               int
+	temp_ce_letter        byte
 }
 
+// resistor_band_mapping follows the IEC 60062 color code - the letters are the matching IEC 60062 letter codes
 var resistor_band_mapping = map[string]*resistor_band{
 	"black": {
 		ColorBand:             utils.EIA_COLOR_MAPPING["black"],
 		tolerance:             nil,
 		is_valid_temp_ce_band: true,
 		temp_ce:               250,
+		temp_ce_letter:        'U',
 	},
 	"brown": {
 		ColorBand: utils.EIA_COLOR_MAPPING["brown"],
@@ -31,8 +37,10 @@ var resistor_band_mapping = map[string]*resistor_band{
 			Value:    1,
 			UnitType: utils.UNIT_TYPE_PERCENT,
 		},
+		tolerance_letter:      'F',
 		is_valid_temp_ce_band: true,
 		temp_ce:               100,
+		temp_ce_letter:        'S',
 	},
 	"red": {
 		ColorBand: utils.EIA_COLOR_MAPPING["red"],
@@ -40,8 +48,10 @@ var resistor_band_mapping = map[string]*resistor_band{
 			Value:    2,
 			UnitType: utils.UNIT_TYPE_PERCENT,
 		},
+		tolerance_letter:      'G',
 		is_valid_temp_ce_band: true,
 		temp_ce:               50,
+		temp_ce_letter:        'R',
 	},
 	"orange": {
 		ColorBand: utils.EIA_COLOR_MAPPING["orange"],
@@ -49,8 +59,10 @@ var resistor_band_mapping = map[string]*resistor_band{
 			Value:    .05,
 			UnitType: utils.UNIT_TYPE_PERCENT,
 		},
+		tolerance_letter:      'W',
 		is_valid_temp_ce_band: true,
 		temp_ce:               15,
+		temp_ce_letter:        'P',
 	},
 	"yellow": {
 		ColorBand: utils.EIA_COLOR_MAPPING["yellow"],
@@ -58,8 +70,10 @@ var resistor_band_mapping = map[string]*resistor_band{
 			Value:    .02,
 			UnitType: utils.UNIT_TYPE_PERCENT,
 		},
+		tolerance_letter:      'P',
 		is_valid_temp_ce_band: true,
 		temp_ce:               25,
+		temp_ce_letter:        'Q',
 	},
 	"green": {
 		ColorBand: utils.EIA_COLOR_MAPPING["green"],
@@ -67,8 +81,10 @@ var resistor_band_mapping = map[string]*resistor_band{
 			Value:    .5,
 			UnitType: utils.UNIT_TYPE_PERCENT,
 		},
+		tolerance_letter:      'D',
 		is_valid_temp_ce_band: true,
 		temp_ce:               20,
+		temp_ce_letter:        'Z',
 	},
 	"blue": {
 		ColorBand: utils.EIA_COLOR_MAPPING["blue"],
@@ -76,8 +92,10 @@ var resistor_band_mapping = map[string]*resistor_band{
 			Value:    .25,
 			UnitType: utils.UNIT_TYPE_PERCENT,
 		},
+		tolerance_letter:      'C',
 		is_valid_temp_ce_band: true,
 		temp_ce:               10,
+		temp_ce_letter:        'Z',
 	},
 	"violet": {
 		ColorBand: utils.EIA_COLOR_MAPPING["violet"],
@@ -85,8 +103,10 @@ var resistor_band_mapping = map[string]*resistor_band{
 			Value:    .1,
 			UnitType: utils.UNIT_TYPE_PERCENT,
 		},
+		tolerance_letter:      'B',
 		is_valid_temp_ce_band: true,
 		temp_ce:               5,
+		temp_ce_letter:        'M',
 	},
 	"grey": {
 		ColorBand: utils.EIA_COLOR_MAPPING["grey"],
@@ -94,8 +114,10 @@ var resistor_band_mapping = map[string]*resistor_band{
 			Value:    .01,
 			UnitType: utils.UNIT_TYPE_PERCENT,
 		},
+		tolerance_letter:      'L',
 		is_valid_temp_ce_band: true,
 		temp_ce:               1,
+		temp_ce_letter:        'K',
 	},
 	"white": {
 		ColorBand: utils.EIA_COLOR_MAPPING["white"],
@@ -108,7 +130,8 @@ var resistor_band_mapping = map[string]*resistor_band{
 			Value:    5,
 			UnitType: utils.UNIT_TYPE_PERCENT,
 		},
-		temp_ce: 0,
+		tolerance_letter: 'J',
+		temp_ce:          0,
 	},
 	"silver": {
 		ColorBand: utils.EIA_COLOR_MAPPING["silver"],
@@ -116,13 +139,26 @@ var resistor_band_mapping = map[string]*resistor_band{
 			Value:    10,
 			UnitType: utils.UNIT_TYPE_PERCENT,
 		},
-		temp_ce: 0,
+		tolerance_letter: 'K',
+		temp_ce:          0,
 	},
 	"pink": {
 		ColorBand: utils.EIA_COLOR_MAPPING["pink"],
 		tolerance: nil,
 		temp_ce:   0,
 	},
+	// no tolerance band at all
+	"none": {
+		ColorBand: utils.ColorBand{
+			SignificantNumeral: -1,
+			Multiplier:         -1,
+		},
+		tolerance: &utils.Tolerance{
+			Value:    default_tolerance.Value,
+			UnitType: utils.UNIT_TYPE_PERCENT,
+		},
+		tolerance_letter: 'M',
+	},
 }
 
 func init() {
@@ -141,27 +177,98 @@ func init() {
 	resistor_band_mapping[abbrvs.SI_WHITE] = resistor_band_mapping["white"]
 }
 
+type resistor_reading struct {
+	bands_visual     string
+	nominal          float64
+	tolerance        float64 // fraction, i.e. .05 for 5%
+	tolerance_color  string
+	tolerance_letter byte
+	temp_ce          int
+	temp_ce_color    string
+	temp_ce_letter   byte
+}
+
 func cmd_resistor_handler(cmd *cli.Command) string {
-	if cmd.ArgsLength > 6 {
-		panic("too many arguments: [args...]")
+	format := cmd.GetFlagValue("format")
+
+	measured := new_measured_check(cmd, abbrvs.RKM_RESISTOR, abbrvs.RESISTOR, measure.QUANTITY_RESISTANCE)
+	defer set_measured_exit_code(cmd, measured)
+
+	if cmd.IsFlagSet("smd") {
+		return get_resistance_from_smd(cmd.GetFlagValue("smd"), measured, format)
 	}
 
-	significant_bands := cmd.Args[0:int(math.Ceil(float64(cmd.ArgsLength)/2.))]
+	if cmd.IsFlagSet("image") {
+		if measured != nil {
+			panic("unsupported: -measured with -image")
+		}
+		return get_resistance_from_image(cmd.GetFlagValue("image"), format)
+	}
+
+	if cmd.IsFlagSet("code") {
+		return get_resistance_from_code(cmd.GetFlagValue("code"), measured, format)
+	}
+
+	args, matches := resolve_color_samples(utils.ResolveColorAliases(cmd.Args, cmd.GetFlagValue("lang")))
+	suffix, json_suffix := format_color_sample_matches(matches)
+
+	if cmd.IsFlagSet("either-direction") {
+		if measured != nil {
+			panic("unsupported: -measured with -either-direction")
+		}
+		return get_resistance_either_direction(args, suffix, json_suffix, format)
+	}
+
+	reading, err := decode_resistor_bands(args)
+	if err != nil {
+		panic(err)
+	}
+
+	measured_suffix, measured_json_suffix := verify_measured(measured, reading.nominal, reading.nominal*(1-reading.tolerance), reading.nominal*(1+reading.tolerance), format)
+
+	return format_resistor_reading(reading, suffix+measured_suffix, json_suffix+measured_json_suffix, format)
+}
+
+// get_resistor_band looks up a band color case-insensitively - "no band" & "no-band" are aliases of "none"
+func get_resistor_band(color string) (*resistor_band, bool) {
+	key := strings.ToLower(strings.TrimSpace(color))
+	if key == "no band" || key == "no-band" {
+		key = "none"
+	}
+
+	band, ok := resistor_band_mapping[key]
+	return band, ok
+}
+
+func decode_resistor_bands(args []string) (*resistor_reading, error) {
+	len_args := len(args)
+	if len_args == 1 {
+		// IEC 60062 0Ω jumper - a single black band
+		if band, ok := get_resistor_band(args[0]); ok && band == resistor_band_mapping["black"] {
+			return &resistor_reading{
+				bands_visual: band.ColorBand.Ansi + "▌" + utils.ANSI_RESET,
+			}, nil
+		}
+		return nil, fmt.Errorf("invalid: single band resistor color %s - only black (0Ω) is allowed", args[0])
+	} else if len_args > 6 {
+		return nil, errors.New("too many arguments: [args...]")
+	} else if len_args < 3 {
+		return nil, errors.New("too few arguments: [args...]")
+	}
+
+	significant_bands := args[0:int(math.Ceil(float64(len_args)/2.))]
 
 	var bands_colors strings.Builder
 	bands_visual := strings.Builder{}
 
 	for i := range significant_bands {
 		arg := significant_bands[i]
-		key := strings.ToLower(arg)
-
-		if key == "gold" || key == "silver" || key == "pink" || key == abbrvs.SI_GOLD || key == abbrvs.SI_SILVER || key == abbrvs.SI_PINK {
-			panic(fmt.Errorf("invalid: significant digit band color can not be %s", arg))
-		}
 
-		val, ok := resistor_band_mapping[key]
+		val, ok := get_resistor_band(arg)
 		if !ok {
-			panic(fmt.Errorf("invalid: significant digit band color %s", arg))
+			return nil, fmt.Errorf("invalid: significant digit band color %s", arg)
+		} else if val.ColorBand.SignificantNumeral < 0 {
+			return nil, fmt.Errorf("invalid: significant digit band color can not be %s", arg)
 		}
 		bands_colors.WriteString(strconv.Itoa(val.ColorBand.SignificantNumeral))
 		bands_visual.WriteString(val.ColorBand.Ansi)
@@ -170,91 +277,114 @@ func cmd_resistor_handler(cmd *cli.Command) string {
 
 	bands_value, err := strconv.ParseInt(bands_colors.String(), 10, 64)
 	if err != nil {
-		panic(err)
+		return nil, err
 	}
 
-	multiplier_color := cmd.Args[2]
+	multiplier_color := args[2]
 	tolerance_color := ""
 	temp_ce_color := ""
-	if len(cmd.Args) > 3 {
-		tolerance_color = cmd.Args[3]
+	if len_args > 3 {
+		tolerance_color = args[3]
 	}
-	if cmd.ArgsLength > 4 {
-		multiplier_color = cmd.Args[3]
-		tolerance_color = cmd.Args[4]
+	if len_args > 4 {
+		multiplier_color = args[3]
+		tolerance_color = args[4]
 	}
-	if cmd.ArgsLength > 5 {
-		temp_ce_color = cmd.Args[5]
+	if len_args > 5 {
+		temp_ce_color = args[5]
 	}
 
 	multiplier := 0
-	multiplier_band, ok := resistor_band_mapping[multiplier_color]
-	if !ok {
-		panic(fmt.Errorf("invalid: multiplier band color %s", multiplier_color))
+	multiplier_band, ok := get_resistor_band(multiplier_color)
+	if !ok || multiplier_band == resistor_band_mapping["none"] {
+		return nil, fmt.Errorf("invalid: multiplier band color %s", multiplier_color)
 	}
 
 	multiplier = multiplier_band.ColorBand.Multiplier
 	bands_visual.WriteString(multiplier_band.ColorBand.Ansi)
 	bands_visual.WriteString("▌")
 
-	tolerance := .2
+	// IEC 60062 - a missing tolerance band is ±20%
+	tolerance := default_tolerance.Value / 100
+	tolerance_letter := byte('M')
 	if tolerance_color != "" {
-		tolerance_band, ok := resistor_band_mapping[tolerance_color]
+		tolerance_band, ok := get_resistor_band(tolerance_color)
 		if !ok || tolerance_band.tolerance == nil {
-			panic(fmt.Errorf("invalid: tolerance band color %s", tolerance_color))
+			return nil, fmt.Errorf("invalid: tolerance band color %s", tolerance_color)
 		}
 
 		tolerance = tolerance_band.tolerance.Value / 100
+		tolerance_letter = tolerance_band.tolerance_letter
 
 		bands_visual.WriteString(utils.ANSI_RESET)
 		bands_visual.WriteString(" ")
-		bands_visual.WriteString(tolerance_band.ColorBand.Ansi)
-		bands_visual.WriteString("▌")
+		if tolerance_band != resistor_band_mapping["none"] {
+			bands_visual.WriteString(tolerance_band.ColorBand.Ansi)
+			bands_visual.WriteString("▌")
+		}
 	}
 
 	temp_ce := 0
+	temp_ce_letter := byte(0)
 	if temp_ce_color != "" {
-		in_tempce, ok := resistor_band_mapping[temp_ce_color]
+		in_tempce, ok := get_resistor_band(temp_ce_color)
 		if !ok || !in_tempce.is_valid_temp_ce_band {
-			panic(fmt.Errorf("invalid: temperature coefficient band color %s", temp_ce_color))
+			return nil, fmt.Errorf("invalid: temperature coefficient band color %s", temp_ce_color)
 		}
 		temp_ce = in_tempce.temp_ce
+		temp_ce_letter = in_tempce.temp_ce_letter
 		bands_visual.WriteString(in_tempce.ColorBand.Ansi)
 		bands_visual.WriteString("▌")
 	}
 
-	nominal_value := float64(bands_value) * math.Pow10(multiplier)
-	actual_min := nominal_value * (1 - tolerance)
-	actual_max := nominal_value * (1 + tolerance)
-
 	bands_visual.WriteString(utils.ANSI_RESET)
 
-	switch cmd.GetFlagValue("format") {
+	return &resistor_reading{
+		bands_visual:     bands_visual.String(),
+		nominal:          float64(bands_value) * math.Pow10(multiplier),
+		tolerance:        tolerance,
+		tolerance_color:  tolerance_color,
+		tolerance_letter: tolerance_letter,
+		temp_ce:          temp_ce,
+		temp_ce_color:    temp_ce_color,
+		temp_ce_letter:   temp_ce_letter,
+	}, nil
+}
+
+// format_resistor_reading formats a decoded reading - suffix & json_suffix are appended to the abbr/raw & json output respectively
+func format_resistor_reading(r *resistor_reading, suffix string, json_suffix string, format string) string {
+	actual_min := r.nominal * (1 - r.tolerance)
+	actual_max := r.nominal * (1 + r.tolerance)
+
+	switch format {
 	case "json":
-		return fmt.Sprintf(`{"nominal":%s,"nominalAbbreviated":"%sΩ","actualMin":%s,"actualMinAbbreviated":"%sΩ","actualMax":%s,"actualMaxAbbreviated":"%sΩ","temperatureCoefficient":%s}`,
-			utils.FormatFloat(nominal_value),
-			utils.GetAbbreviatedValue(nominal_value),
+		return fmt.Sprintf(`{"nominal":%s,"nominalAbbreviated":"%sΩ","actualMin":%s,"actualMinAbbreviated":"%sΩ","actualMax":%s,"actualMaxAbbreviated":"%sΩ","temperatureCoefficient":%s%s}`,
+			utils.FormatFloat(r.nominal),
+			utils.GetAbbreviatedValue(r.nominal),
 			utils.FormatFloat(actual_min),
 			utils.GetAbbreviatedValue(actual_min),
 			utils.FormatFloat(actual_max),
 			utils.GetAbbreviatedValue(actual_max),
-			utils.If(temp_ce_color != "", strconv.Itoa(temp_ce), "null"),
+			utils.If(r.temp_ce_color != "", strconv.Itoa(r.temp_ce), "null"),
+			json_suffix,
 		)
 	case "raw":
-		return fmt.Sprintf("%s nominal=%sΩ min=%sΩ max=%sΩ temp_coefficient=%s",
-			&bands_visual,
-			utils.FormatFloat(nominal_value),
+		return fmt.Sprintf("%s nominal=%sΩ min=%sΩ max=%sΩ temp_coefficient=%s%s",
+			r.bands_visual,
+			utils.FormatFloat(r.nominal),
 			utils.FormatFloat(actual_min),
 			utils.FormatFloat(actual_max),
-			utils.If(temp_ce_color != "", fmt.Sprintf("%d ppm/K", temp_ce), "nil"),
+			utils.If(r.temp_ce_color != "", fmt.Sprintf("%d ppm/K", r.temp_ce), "nil"),
+			suffix,
 		)
 	default:
-		return fmt.Sprintf("%s nominal=%sΩ min=%sΩ max=%sΩ temp_coefficient=%s",
-			&bands_visual,
-			utils.GetAbbreviatedValue(nominal_value),
+		return fmt.Sprintf("%s nominal=%sΩ min=%sΩ max=%sΩ temp_coefficient=%s%s",
+			r.bands_visual,
+			utils.GetAbbreviatedValue(r.nominal),
 			utils.GetAbbreviatedValue(actual_min),
 			utils.GetAbbreviatedValue(actual_max),
-			utils.If(temp_ce_color != "", fmt.Sprintf("%d ppm/K", temp_ce), "nil"),
+			utils.If(r.temp_ce_color != "", fmt.Sprintf("%d ppm/K", r.temp_ce), "nil"),
+			suffix,
 		)
 	}
 }
@@ -3,8 +3,10 @@ package main
 import (
 	"fmt"
 	"gohm/calculate"
+	"gohm/chart"
 	"gohm/cli"
 	"gohm/identify"
+	"gohm/measure"
 	"os"
 )
 
@@ -15,7 +17,10 @@ func main() {
 	c := cli.NewCLI("gohm", GOHM_VERSION, GOHM_DESCRIPTION)
 
 	c.AddCommand(calculate.GetCommand())
+	c.AddCommand(chart.GetCommand())
 	c.AddCommand(identify.GetCommand())
+	c.AddCommand(measure.GetCommand())
 
 	fmt.Println(c.Run(os.Args))
+	os.Exit(c.ExitCode())
 }
@@ -0,0 +1,247 @@
+package measure
+
+import (
+	"encoding/csv"
+	"fmt"
+	"gohm/cli"
+	"gohm/utils"
+	"math"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const reading_time_layout = "2006-01-02T15:04:05.000Z07:00"
+
+type reading struct {
+	time  time.Time
+	value float64
+}
+
+func GetCommand() *cli.Command {
+	cmd := &cli.Command{
+		Name:        "measure",
+		Aliases:     []string{"meas"},
+		Description: "Take readings from a SCPI bench instrument over LAN",
+		Handler:     cmd_measure_handler,
+		Examples: []cli.Example{
+			{
+				Command: "gohm measure -instrument tcp://192.168.1.50:5025",
+				Output:  "time=2026-10-19T09:30:00.000Z value=4.68kΩ",
+			},
+			{
+				Command:     "gohm measure -instrument tcp://192.168.1.50:5025 -quantity voltage-dc -repeat 3 -interval 500ms",
+				Description: "repeated readings end with a summary",
+				Output: `time=2026-10-19T09:30:00.000Z value=5.02V
+      time=2026-10-19T09:30:00.500Z value=5.01V
+      time=2026-10-19T09:30:01.000Z value=5V
+      count=3 mean=5.01V min=5V max=5.02V stddev=8.164965809277087mV`,
+			},
+			{
+				Command:     "gohm measure -instrument tcp://192.168.1.50:5025 -repeat 0 -interval 1m -csv log.csv",
+				Description: "log to csv until interrupted",
+			},
+			{
+				Command:     "gohm identify resistor yellow violet red gold -measured tcp://192.168.1.50:5025",
+				Description: "check a part with a reading taken from the instrument",
+				Output:      "\u001b[93m▌\u001b[95m▌\u001b[91m▌\033[0m \u001b[43m▌\033[0m nominal=4.7kΩ min=4.465kΩ max=4.935kΩ temp_coefficient=nil measured=4.68kΩ result=pass deviation=-0.43% deviation_sigma=-0.26σ",
+			},
+		},
+	}
+	cmd.AddFlag(&cli.Flag{
+		Name:        "instrument",
+		Aliases:     []string{"i"},
+		Description: "Instrument address - a raw SCPI socket, i.e. tcp://192.168.1.50:5025",
+		Required:    true,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:           "quantity",
+		Aliases:        []string{"q"},
+		Description:    "Quantity to measure",
+		Default:        QUANTITY_RESISTANCE,
+		PossibleValues: quantities,
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "repeat",
+		Description: "Number of readings to take - 0 takes readings until interrupted & requires -csv",
+		Default:     "1",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "interval",
+		Description: "Time between readings, i.e. 500ms, 10s or 1m",
+		Default:     "1s",
+	})
+	cmd.AddFlag(&cli.Flag{
+		Name:        "csv",
+		Description: "CSV file to append every reading to as it is taken",
+	})
+	cmd.AddFlag(NewTimeoutFlag())
+	cmd.AddFlag(&cli.Flag{
+		Name:           "format",
+		Description:    "Output format",
+		Default:        "abbr",
+		PossibleValues: []string{"abbr", "raw", "json"},
+	})
+	return cmd
+}
+
+func cmd_measure_handler(cmd *cli.Command) string {
+	format := cmd.GetFlagValue("format")
+	quantity := cmd.GetFlagValue("quantity")
+
+	q, ok := SCPI_QUANTITY_MAPPING[quantity]
+	if !ok {
+		panic(fmt.Errorf("invalid or unsupported: quantity %s", quantity))
+	}
+
+	repeat, err := strconv.Atoi(cmd.GetFlagValue("repeat"))
+	if err != nil || repeat < 0 {
+		panic(fmt.Errorf("invalid: repeat %s", cmd.GetFlagValue("repeat")))
+	}
+	if repeat == 0 && !cmd.IsFlagSet("csv") {
+		panic("invalid: -repeat 0 takes readings until interrupted and requires -csv")
+	}
+
+	interval, err := time.ParseDuration(cmd.GetFlagValue("interval"))
+	if err != nil || interval < 0 {
+		panic(fmt.Errorf("invalid: interval %s", cmd.GetFlagValue("interval")))
+	}
+
+	instrument, err := Dial(cmd.GetFlagValue("instrument"), GetTimeout(cmd))
+	if err != nil {
+		panic(err)
+	}
+	defer instrument.Close()
+
+	var log *csv.Writer
+	if cmd.IsFlagSet("csv") {
+		file, header := open_csv_log(cmd.GetFlagValue("csv"))
+		defer file.Close()
+
+		log = csv.NewWriter(file)
+		if header {
+			log.Write([]string{"time", "quantity", "value", "unit"})
+		}
+	}
+
+	readings := []reading{}
+	for n := 1; repeat == 0 || n <= repeat; n++ {
+		if n > 1 {
+			time.Sleep(interval)
+		}
+
+		value, err := instrument.Measure(quantity)
+		if err != nil {
+			panic(err)
+		}
+		r := reading{time: time.Now(), value: value}
+
+		// flushed per reading so an interrupted log keeps every reading taken
+		if log != nil {
+			log.Write([]string{r.time.Format(reading_time_layout), quantity, utils.FormatFloat(r.value), q.Unit})
+			log.Flush()
+			if err := log.Error(); err != nil {
+				panic(fmt.Errorf("invalid: could not write csv %s: %w", cmd.GetFlagValue("csv"), err))
+			}
+		}
+
+		// readings are only kept to be returned, a continuous log never returns
+		if repeat != 0 {
+			readings = append(readings, r)
+		}
+	}
+
+	return format_readings(readings, quantity, q.Unit, format)
+}
+
+// open_csv_log opens a csv file for appending & reports whether it is new and needs a header
+func open_csv_log(path string) (*os.File, bool) {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+	if err != nil {
+		panic(fmt.Errorf("invalid: could not open csv %s: %w", path, err))
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		panic(fmt.Errorf("invalid: could not open csv %s: %w", path, err))
+	}
+
+	return file, info.Size() == 0
+}
+
+// format_readings returns every reading followed by a summary when more than 1 reading was taken
+func format_readings(readings []reading, quantity string, unit string, format string) string {
+	mean, min_value, max_value, stddev := 0., math.Inf(1), math.Inf(-1), 0.
+	for _, r := range readings {
+		mean += r.value / float64(len(readings))
+		min_value = min(min_value, r.value)
+		max_value = max(max_value, r.value)
+	}
+	for _, r := range readings {
+		stddev += (r.value - mean) * (r.value - mean) / float64(len(readings))
+	}
+	stddev = math.Sqrt(stddev)
+
+	var sb strings.Builder
+
+	switch format {
+	case "json":
+		fmt.Fprintf(&sb, `{"quantity":"%s","unit":"%s","readings":[`, quantity, unit)
+		for i, r := range readings {
+			fmt.Fprintf(&sb, `{"time":"%s","value":%s,"valueAbbreviated":"%s%s"}`,
+				r.time.Format(reading_time_layout),
+				utils.FormatFloat(r.value),
+				utils.GetAbbreviatedValue(r.value),
+				unit,
+			)
+			if i != len(readings)-1 {
+				sb.WriteRune(',')
+			}
+		}
+		fmt.Fprintf(&sb, `],"mean":%s,"meanAbbreviated":"%s%s","min":%s,"minAbbreviated":"%s%s","max":%s,"maxAbbreviated":"%s%s","stddev":%s,"stddevAbbreviated":"%s%s"}`,
+			utils.FormatFloat(mean), utils.GetAbbreviatedValue(mean), unit,
+			utils.FormatFloat(min_value), utils.GetAbbreviatedValue(min_value), unit,
+			utils.FormatFloat(max_value), utils.GetAbbreviatedValue(max_value), unit,
+			utils.FormatFloat(stddev), utils.GetAbbreviatedValue(stddev), unit,
+		)
+	default:
+		value := utils.If(format == "raw", utils.FormatFloat, utils.GetAbbreviatedValue)
+
+		for i, r := range readings {
+			fmt.Fprintf(&sb, "time=%s value=%s%s", r.time.Format(reading_time_layout), value(r.value), unit)
+			if i != len(readings)-1 {
+				sb.WriteRune('\n')
+			}
+		}
+		if len(readings) > 1 {
+			fmt.Fprintf(&sb, "\ncount=%d mean=%s%s min=%s%s max=%s%s stddev=%s%s",
+				len(readings),
+				value(mean), unit,
+				value(min_value), unit,
+				value(max_value), unit,
+				value(stddev), unit,
+			)
+		}
+	}
+
+	return sb.String()
+}
+
+// NewTimeoutFlag returns the -timeout flag of the commands that take readings from an instrument
+func NewTimeoutFlag() *cli.Flag {
+	return &cli.Flag{
+		Name:        "timeout",
+		Description: "Timeout of connecting to & reading from the instrument",
+		Default:     SCPI_DEFAULT_TIMEOUT.String(),
+	}
+}
+
+// GetTimeout returns the duration of the -timeout flag
+func GetTimeout(cmd *cli.Command) time.Duration {
+	timeout, err := time.ParseDuration(cmd.GetFlagValue("timeout"))
+	if err != nil || timeout <= 0 {
+		panic(fmt.Errorf("invalid: timeout %s", cmd.GetFlagValue("timeout")))
+	}
+	return timeout
+}
@@ -0,0 +1,120 @@
+package measure
+
+import (
+	"gohm/test_utils"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+//region Measure Tests
+
+func TestCmdMeasureHandler(t *testing.T) {
+	address := test_utils.StartFakeSCPIServer(t, map[string][]string{
+		"MEAS:RES?":     {"+4.68000000E+03"},
+		"MEAS:VOLT:DC?": {"5.02", "5.01", "5.00"},
+		"MEAS:CAP?":     {"+9.61000000E-08,+1.00000000E-06"},
+	})
+
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		contains []string
+	}{
+		{"resistance", map[string]string{"quantity": "resistance"}, []string{"value=4.68kΩ"}},
+		{"resistance raw", map[string]string{"quantity": "resistance", "format": "raw"}, []string{"value=4680Ω"}},
+		{"capacitance with range", map[string]string{"quantity": "capacitance"}, []string{"value=96.1nF"}},
+		{"repeat summary", map[string]string{"quantity": "voltage-dc", "repeat": "3", "interval": "1ms"}, []string{"value=5.02V", "value=5.01V", "value=5V", "count=3 mean=5.01V min=5V max=5.02V stddev=8.164965809277"}},
+		{"json", map[string]string{"quantity": "resistance", "format": "json"}, []string{`{"quantity":"resistance","unit":"Ω","readings":[{"time":"`, `"value":4680,"valueAbbreviated":"4.68kΩ"}],"mean":4680,"meanAbbreviated":"4.68kΩ"`, `"stddev":0,"stddevAbbreviated":"0Ω"}`}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{
+				"instrument": address,
+				"repeat":     "1",
+				"interval":   "1s",
+				"timeout":    "1s",
+				"format":     "abbr",
+			}, tt.flags)
+
+			cmd := test_utils.CreateTestCommand(cmd_measure_handler, flags, nil, nil)
+			result := cmd_measure_handler(cmd)
+			test_utils.AssertContains(t, result, tt.contains...)
+		})
+	}
+}
+
+func TestCmdMeasureHandlerCsv(t *testing.T) {
+	address := test_utils.StartFakeSCPIServer(t, map[string][]string{
+		"MEAS:RES?": {"100.1", "100.2"},
+	})
+	path := filepath.Join(t.TempDir(), "log.csv")
+
+	for range 2 {
+		cmd := test_utils.CreateTestCommand(cmd_measure_handler, map[string]string{
+			"instrument": address,
+			"quantity":   "resistance",
+			"repeat":     "2",
+			"interval":   "1ms",
+			"timeout":    "1s",
+			"csv":        path,
+			"format":     "abbr",
+		}, nil, nil)
+		cmd_measure_handler(cmd)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	test_utils.AssertEquals(t, len(lines), 5)
+	test_utils.AssertEquals(t, lines[0], "time,quantity,value,unit")
+	test_utils.AssertContains(t, lines[1], ",resistance,100.1,Ω")
+	test_utils.AssertContains(t, lines[4], ",resistance,100.2,Ω")
+}
+
+func TestCmdMeasureHandlerPanics(t *testing.T) {
+	address := test_utils.StartFakeSCPIServer(t, map[string][]string{
+		"MEAS:RES?":  {"+9.90000000E+37"},
+		"MEAS:FREQ?": {"no reading"},
+	})
+
+	tests := []struct {
+		name     string
+		flags    map[string]string
+		expected string
+	}{
+		{"overload", map[string]string{"quantity": "resistance"}, "invalid: instrument reading overload for MEAS:RES?"},
+		{"invalid response", map[string]string{"quantity": "frequency"}, "invalid: instrument response no reading to MEAS:FREQ?"},
+		{"no response", map[string]string{"quantity": "current-dc", "timeout": "50ms"}, "invalid: no response to MEAS:CURR:DC? from instrument"},
+		{"unsupported quantity", map[string]string{"quantity": "inductance"}, "invalid or unsupported: quantity inductance"},
+		{"unsupported address", map[string]string{"instrument": "usb://0x2A8D"}, "invalid or unsupported: instrument address usb://0x2A8D"},
+		{"invalid repeat", map[string]string{"repeat": "-1"}, "invalid: repeat -1"},
+		{"continuous without csv", map[string]string{"repeat": "0"}, "invalid: -repeat 0 takes readings until interrupted and requires -csv"},
+		{"invalid interval", map[string]string{"interval": "1 second"}, "invalid: interval 1 second"},
+		{"invalid timeout", map[string]string{"timeout": "0s"}, "invalid: timeout 0s"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := test_utils.MergeFlags(map[string]string{
+				"instrument": address,
+				"quantity":   "resistance",
+				"repeat":     "1",
+				"interval":   "1s",
+				"timeout":    "1s",
+				"format":     "abbr",
+			}, tt.flags)
+
+			test_utils.ExpectPanicContains(t, tt.expected, func() {
+				cmd_measure_handler(test_utils.CreateTestCommand(cmd_measure_handler, flags, nil, nil))
+			})
+		})
+	}
+}
+
+//endregion Measure Tests
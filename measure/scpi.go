@@ -0,0 +1,143 @@
+package measure
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	QUANTITY_CAPACITANCE = "capacitance"
+	QUANTITY_CURRENT_AC  = "current-ac"
+	QUANTITY_CURRENT_DC  = "current-dc"
+	QUANTITY_FREQUENCY   = "frequency"
+	QUANTITY_RESISTANCE  = "resistance"
+	QUANTITY_RESISTANCE4 = "resistance-4w"
+	QUANTITY_VOLTAGE_AC  = "voltage-ac"
+	QUANTITY_VOLTAGE_DC  = "voltage-dc"
+)
+
+// SCPI_DEFAULT_TIMEOUT is the timeout of connecting to & reading from an instrument
+const SCPI_DEFAULT_TIMEOUT = 5 * time.Second
+
+// SCPI_OVERLOAD is the value an instrument returns for an overloaded (out of range) reading
+const SCPI_OVERLOAD = 9.9e37
+
+type Quantity struct {
+	Query string
+	Unit  string
+}
+
+// SCPI_QUANTITY_MAPPING maps the measurable quantities to their SCPI query & unit
+var SCPI_QUANTITY_MAPPING = map[string]Quantity{
+	QUANTITY_CAPACITANCE: {Query: "MEAS:CAP?", Unit: "F"},
+	QUANTITY_CURRENT_AC:  {Query: "MEAS:CURR:AC?", Unit: "A"},
+	QUANTITY_CURRENT_DC:  {Query: "MEAS:CURR:DC?", Unit: "A"},
+	QUANTITY_FREQUENCY:   {Query: "MEAS:FREQ?", Unit: "Hz"},
+	QUANTITY_RESISTANCE:  {Query: "MEAS:RES?", Unit: "Ω"},
+	QUANTITY_RESISTANCE4: {Query: "MEAS:FRES?", Unit: "Ω"},
+	QUANTITY_VOLTAGE_AC:  {Query: "MEAS:VOLT:AC?", Unit: "V"},
+	QUANTITY_VOLTAGE_DC:  {Query: "MEAS:VOLT:DC?", Unit: "V"},
+}
+
+var quantities = []string{
+	QUANTITY_CAPACITANCE,
+	QUANTITY_CURRENT_AC,
+	QUANTITY_CURRENT_DC,
+	QUANTITY_FREQUENCY,
+	QUANTITY_RESISTANCE,
+	QUANTITY_RESISTANCE4,
+	QUANTITY_VOLTAGE_AC,
+	QUANTITY_VOLTAGE_DC,
+}
+
+// Instrument is a SCPI instrument connected over a raw TCP socket, i.e. port 5025 of a LAN multimeter
+type Instrument struct {
+	conn    net.Conn
+	reader  *bufio.Reader
+	timeout time.Duration
+}
+
+// IsInstrumentAddress reports whether s is an instrument address, i.e. tcp://192.168.1.50:5025
+func IsInstrumentAddress(s string) bool {
+	return strings.HasPrefix(strings.ToLower(strings.TrimSpace(s)), "tcp://")
+}
+
+// Dial connects to an instrument address, i.e. tcp://192.168.1.50:5025
+func Dial(address string, timeout time.Duration) (*Instrument, error) {
+	if !IsInstrumentAddress(address) {
+		return nil, fmt.Errorf("invalid or unsupported: instrument address %s", address)
+	}
+
+	host := strings.TrimSpace(address)[len("tcp://"):]
+	conn, err := net.DialTimeout("tcp", host, timeout)
+	if err != nil {
+		return nil, fmt.Errorf("invalid: could not connect to instrument %s: %w", address, err)
+	}
+
+	return &Instrument{
+		conn:    conn,
+		reader:  bufio.NewReader(conn),
+		timeout: timeout,
+	}, nil
+}
+
+func (i *Instrument) Close() error {
+	return i.conn.Close()
+}
+
+// Query sends a SCPI query & returns its response without the line terminator
+func (i *Instrument) Query(query string) (string, error) {
+	i.conn.SetDeadline(time.Now().Add(i.timeout))
+
+	if _, err := fmt.Fprintf(i.conn, "%s\n", query); err != nil {
+		return "", fmt.Errorf("invalid: could not send %s to instrument: %w", query, err)
+	}
+
+	response, err := i.reader.ReadString('\n')
+	if err != nil {
+		return "", fmt.Errorf("invalid: no response to %s from instrument: %w", query, err)
+	}
+
+	return strings.TrimSpace(response), nil
+}
+
+// Measure queries a single reading of the quantity
+func (i *Instrument) Measure(quantity string) (float64, error) {
+	q, ok := SCPI_QUANTITY_MAPPING[quantity]
+	if !ok {
+		return 0, fmt.Errorf("invalid or unsupported: quantity %s", quantity)
+	}
+
+	response, err := i.Query(q.Query)
+	if err != nil {
+		return 0, err
+	}
+
+	// some instruments answer with a comma separated list, i.e. a reading followed by its range
+	first, _, _ := strings.Cut(response, ",")
+	value, err := strconv.ParseFloat(strings.TrimSpace(first), 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid: instrument response %s to %s", response, q.Query)
+	}
+
+	if value >= SCPI_OVERLOAD || value <= -SCPI_OVERLOAD {
+		return 0, fmt.Errorf("invalid: instrument reading overload for %s", q.Query)
+	}
+
+	return value, nil
+}
+
+// MeasureOnce connects to an instrument, takes a single reading of the quantity & disconnects
+func MeasureOnce(address string, quantity string, timeout time.Duration) (float64, error) {
+	instrument, err := Dial(address, timeout)
+	if err != nil {
+		return 0, err
+	}
+	defer instrument.Close()
+
+	return instrument.Measure(quantity)
+}
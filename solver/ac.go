@@ -0,0 +1,119 @@
+package solver
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// ACPoint is the small-signal solution at 1 frequency - the node voltages are phasors of the AC sources
+type ACPoint struct {
+	Frequency float64
+	Voltages  map[string]complex128 // node voltages, ground included
+}
+
+// ParseSweep parses the params of a .ac sweep - dec|oct|lin points fstart fstop - into its frequencies. dec & oct
+// take points per decade & octave, lin the total number of points
+func ParseSweep(params []string) ([]float64, error) {
+	if len(params) != 4 {
+		return nil, fmt.Errorf("invalid: sweep %s - expected dec|oct|lin points fstart fstop", strings.Join(params, " "))
+	}
+
+	points, err := strconv.Atoi(params[1])
+	if err != nil || points < 1 {
+		return nil, fmt.Errorf("invalid: sweep points %s", params[1])
+	}
+
+	start, err := ParseValue(params[2])
+	if err != nil || start <= 0 {
+		return nil, fmt.Errorf("invalid: sweep start frequency %s", params[2])
+	}
+
+	stop, err := ParseValue(params[3])
+	if err != nil || stop < start {
+		return nil, fmt.Errorf("invalid: sweep stop frequency %s", params[3])
+	}
+
+	frequencies := []float64{}
+	switch strings.ToLower(params[0]) {
+	case "dec", "oct":
+		base := map[string]float64{"dec": 10, "oct": 2}[strings.ToLower(params[0])]
+		// frequencies are computed from the start, not accumulated, so the stop frequency isn't missed to rounding
+		for i := 0; ; i++ {
+			f := start * math.Pow(base, float64(i)/float64(points))
+			if f > stop*(1+1e-9) {
+				break
+			}
+			frequencies = append(frequencies, f)
+		}
+	case "lin":
+		if points == 1 {
+			return []float64{start}, nil
+		}
+		for i := range points {
+			frequencies = append(frequencies, start+(stop-start)*float64(i)/float64(points-1))
+		}
+	default:
+		return nil, fmt.Errorf("invalid or unsupported: sweep type %s", params[0])
+	}
+
+	return frequencies, nil
+}
+
+// check_ac_source returns an error when no source of the netlist has an AC value - nothing drives its small-signal
+// response & every node would be 0V
+func check_ac_source(n *Netlist) error {
+	for _, e := range n.Elements {
+		if (e.Kind == 'V' || e.Kind == 'I') && e.AC != 0 {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid: netlist %s without an AC source, i.e. V1 in 0 AC 1", n.Title)
+}
+
+// SolveAC solves the small-signal response at every frequency - capacitors & inductors are their admittance & impedance
+// at the frequency & only the AC value of the sources drives the circuit
+func SolveAC(n *Netlist, frequencies []float64) ([]ACPoint, error) {
+	if err := check_ac_source(n); err != nil {
+		return nil, err
+	}
+
+	m := new_mna(n)
+	points := make([]ACPoint, 0, len(frequencies))
+
+	for _, f := range frequencies {
+		a, b := new_matrix[complex128](m.size), make([]complex128, m.size)
+		omega := 2 * math.Pi * f
+
+		for _, e := range n.Elements {
+			stamp_element(m, a, b, e, e.AC)
+			stamp_reactance(m, a, e, omega)
+		}
+
+		x, err := solve_mna(m, a, b)
+		if err != nil {
+			return nil, err
+		}
+
+		p := ACPoint{Frequency: f, Voltages: map[string]complex128{"0": 0}}
+		for node, i := range m.nodes {
+			p.Voltages[node] = x[i]
+		}
+		points = append(points, p)
+	}
+
+	return points, nil
+}
+
+// stamp_reactance stamps a capacitor as its admittance & an inductor as its impedance at the angular frequency omega
+func stamp_reactance(m *mna, a [][]complex128, e *Element, omega float64) {
+	switch e.Kind {
+	case 'C':
+		stamp_admittance(a, m.node(e.Nodes[0]), m.node(e.Nodes[1]), complex(0, omega*e.Value))
+	case 'L':
+		// V(n+) - V(n-) - jωL I = 0
+		k := m.branches[e.Name]
+		a[k][k] -= complex(0, omega*e.Value)
+	}
+}
@@ -0,0 +1,99 @@
+package solver
+
+import (
+	"math"
+	"math/cmplx"
+	"strings"
+	"testing"
+)
+
+func TestParseSweep(t *testing.T) {
+	tests := []struct {
+		params   string
+		expected []float64
+	}{
+		{"dec 1 10 10k", []float64{10, 100, 1000, 10000}},
+		{"dec 2 1 10", []float64{1, math.Sqrt(10), 10}},
+		{"oct 1 1k 8k", []float64{1000, 2000, 4000, 8000}},
+		{"lin 3 0.5 1.5", []float64{.5, 1, 1.5}},
+		{"lin 1 50 50", []float64{50}},
+		{"DEC 1 1Meg 1Meg", []float64{1e6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.params, func(t *testing.T) {
+			got, err := ParseSweep(strings.Fields(tt.params))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(got) != len(tt.expected) {
+				t.Fatalf("ParseSweep(%q) = %v, expected %v", tt.params, got, tt.expected)
+			}
+			for i := range got {
+				assert_close(t, "frequency", got[i], tt.expected[i])
+			}
+		})
+	}
+
+	for _, params := range []string{"dec 10 1", "dec 0 1 10", "dec 10 0 10", "dec 10 10 1", "log 10 1 10", "lin x 1 10"} {
+		if _, err := ParseSweep(strings.Fields(params)); err == nil {
+			t.Errorf("expected an error for %q", params)
+		}
+	}
+}
+
+func TestSolveAC(t *testing.T) {
+	n, err := ParseNetlist(strings.NewReader(`RLC
+V1 in 0 AC 1
+R1 in a 100
+L1 a b 10m
+C1 b out 100n
+R2 out 0 100
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	resonance := 1 / (2 * math.Pi * math.Sqrt(10e-3*100e-9))
+	points, err := SolveAC(n, []float64{resonance, resonance * 10})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// at resonance the LC cancels out & leaves a resistive divider
+	assert_close(t, "|V(out)|", cmplx.Abs(points[0].Voltages["out"]), .5)
+	assert_close(t, "phase", cmplx.Phase(points[0].Voltages["out"]), 0)
+	assert_close(t, "V(in)", real(points[0].Voltages["in"]), 1)
+
+	// above resonance the inductor dominates & the output lags
+	if cmplx.Phase(points[1].Voltages["out"]) >= 0 {
+		t.Errorf("expected a lagging output above resonance, got %v", points[1].Voltages["out"])
+	}
+}
+
+func TestSolveACSourcePhase(t *testing.T) {
+	n, err := ParseNetlist(strings.NewReader("Phase\nI1 0 out DC 1 AC 2m 90\nR1 out 0 1k\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	points, err := SolveAC(n, []float64{1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	assert_close(t, "real", real(points[0].Voltages["out"]), 0)
+	assert_close(t, "imag", imag(points[0].Voltages["out"]), 2)
+}
+
+func TestSolveACWithoutSource(t *testing.T) {
+	n, err := ParseNetlist(strings.NewReader("DC only\nV1 in 0 5\nR1 in 0 1k\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = SolveAC(n, []float64{1})
+	if err == nil || err.Error() != "invalid: netlist DC only without an AC source, i.e. V1 in 0 AC 1" {
+		t.Errorf("expected an error for a netlist without an AC source, got %v", err)
+	}
+}
@@ -0,0 +1,88 @@
+package solver
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+)
+
+// Scalar is a real or complex matrix entry - real for DC & transient, complex for AC analysis
+type Scalar interface {
+	float64 | complex128
+}
+
+var ErrSingularMatrix = errors.New("singular matrix")
+
+// singular_tolerance is the pivot magnitude, relative to the largest entry of its row, below which a matrix is treated
+// as singular - rounding leaves a pivot of about 1e-16 of its row in a singular system rather than 0
+const singular_tolerance = 1e-13
+
+// Solve solves a x = b with gaussian elimination & partial pivoting - a & b are left untouched
+func Solve[T Scalar](a [][]T, b []T) ([]T, error) {
+	n := len(b)
+
+	m := make([][]T, n)
+	scale := make([]float64, n)
+	for i := range a {
+		m[i] = make([]T, n+1)
+		copy(m[i], a[i])
+		m[i][n] = b[i]
+		for _, v := range a[i] {
+			scale[i] = max(scale[i], magnitude(v))
+		}
+	}
+
+	for col := range n {
+		pivot := col
+		for row := col + 1; row < n; row++ {
+			if magnitude(m[row][col]) > magnitude(m[pivot][col]) {
+				pivot = row
+			}
+		}
+		if magnitude(m[pivot][col]) <= singular_tolerance*scale[pivot] {
+			return nil, ErrSingularMatrix
+		}
+		m[col], m[pivot] = m[pivot], m[col]
+		scale[col], scale[pivot] = scale[pivot], scale[col]
+
+		for row := col + 1; row < n; row++ {
+			f := m[row][col] / m[col][col]
+			if f == 0 {
+				continue
+			}
+			for k := col; k <= n; k++ {
+				m[row][k] -= f * m[col][k]
+			}
+		}
+	}
+
+	x := make([]T, n)
+	for row := n - 1; row >= 0; row-- {
+		sum := m[row][n]
+		for k := row + 1; k < n; k++ {
+			sum -= m[row][k] * x[k]
+		}
+		x[row] = sum / m[row][row]
+	}
+
+	return x, nil
+}
+
+func magnitude[T Scalar](v T) float64 {
+	switch v := any(v).(type) {
+	case float64:
+		return math.Abs(v)
+	case complex128:
+		return cmplx.Abs(v)
+	}
+	return 0
+}
+
+// new_matrix returns a zeroed n x n matrix
+func new_matrix[T Scalar](n int) [][]T {
+	m := make([][]T, n)
+	for i := range m {
+		m[i] = make([]T, n)
+	}
+	return m
+}
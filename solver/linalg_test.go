@@ -0,0 +1,73 @@
+package solver
+
+import (
+	"errors"
+	"math"
+	"math/cmplx"
+	"testing"
+)
+
+func TestSolve(t *testing.T) {
+	a := [][]float64{
+		{0, 2, 1},
+		{1, -2, -3},
+		{-1, 1, 2},
+	}
+	b := []float64{-8, 0, 3}
+
+	x, err := Solve(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range []float64{-4, -5, 2} {
+		if math.Abs(x[i]-expected) > 1e-12 {
+			t.Errorf("x[%d] = %v, expected %v", i, x[i], expected)
+		}
+	}
+
+	if a[0][0] != 0 || b[0] != -8 {
+		t.Error("expected a & b to be left untouched")
+	}
+}
+
+func TestSolveComplex(t *testing.T) {
+	a := [][]complex128{
+		{1 + 1i, 2},
+		{0, 1i},
+	}
+	b := []complex128{3 + 3i, 1}
+
+	x, err := Solve(a, b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for i, expected := range []complex128{4 + 1i, -1i} {
+		if cmplx.Abs(x[i]-expected) > 1e-12 {
+			t.Errorf("x[%d] = %v, expected %v", i, x[i], expected)
+		}
+	}
+}
+
+func TestSolveSingular(t *testing.T) {
+	_, err := Solve([][]float64{{1, 2}, {2, 4}}, []float64{1, 2})
+	if !errors.Is(err, ErrSingularMatrix) {
+		t.Errorf("expected ErrSingularMatrix, got %v", err)
+	}
+}
+
+func TestSolveNearlySingular(t *testing.T) {
+	// rounding leaves a pivot of about 1e-17 rather than 0 in this singular system
+	_, err := Solve([][]float64{{.1, .2, .3}, {.4, .5, .6}, {.7, .8, .9}}, []float64{1, 2, 3})
+	if !errors.Is(err, ErrSingularMatrix) {
+		t.Errorf("expected ErrSingularMatrix, got %v", err)
+	}
+
+	// a small pivot of a well scaled row is not singular
+	x, err := Solve([][]float64{{1e-12, 0}, {0, 1}}, []float64{1e-12, 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	assert_close(t, "x0", x[0], 1)
+}
@@ -0,0 +1,146 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+)
+
+// mna indexes the unknowns of modified nodal analysis - the voltage of every node other than ground followed by the
+// current of every branch that is a voltage: voltage sources, VCVS & inductors
+type mna struct {
+	netlist  *Netlist
+	nodes    map[string]int
+	branches map[string]int
+	size     int
+}
+
+func new_mna(n *Netlist) *mna {
+	m := &mna{netlist: n, nodes: map[string]int{}, branches: map[string]int{}}
+
+	for i, node := range n.Nodes {
+		m.nodes[node] = i
+	}
+	m.size = len(n.Nodes)
+
+	for _, e := range n.Elements {
+		if e.Kind == 'V' || e.Kind == 'E' || e.Kind == 'L' {
+			m.branches[e.Name] = m.size
+			m.size++
+		}
+	}
+
+	return m
+}
+
+// node returns the index of a node, -1 for ground
+func (m *mna) node(name string) int {
+	if IsGround(name) {
+		return -1
+	}
+	return m.nodes[name]
+}
+
+// node_voltage returns the voltage of a node in a solution, 0 for ground
+func node_voltage[T Scalar](m *mna, x []T, node string) T {
+	if i := m.node(node); i >= 0 {
+		return x[i]
+	}
+	return 0
+}
+
+// stamp_admittance stamps an admittance between the nodes i & j
+func stamp_admittance[T Scalar](a [][]T, i int, j int, y T) {
+	if i >= 0 {
+		a[i][i] += y
+	}
+	if j >= 0 {
+		a[j][j] += y
+	}
+	if i >= 0 && j >= 0 {
+		a[i][j] -= y
+		a[j][i] -= y
+	}
+}
+
+// stamp_branch stamps the incidence of a branch current k flowing from node i through the branch to node j and the
+// branch voltage V(i) - V(j) into the row of the branch
+func stamp_branch[T Scalar](a [][]T, i int, j int, k int) {
+	if i >= 0 {
+		a[i][k] += 1
+		a[k][i] += 1
+	}
+	if j >= 0 {
+		a[j][k] -= 1
+		a[k][j] -= 1
+	}
+}
+
+// stamp_current stamps a current flowing from node i through a source to node j
+func stamp_current[T Scalar](b []T, i int, j int, current T) {
+	if i >= 0 {
+		b[i] -= current
+	}
+	if j >= 0 {
+		b[j] += current
+	}
+}
+
+// stamp_element stamps the elements every analysis stamps the same - resistors & controlled sources - and the sources
+// with their value in the analysis. Capacitors & inductors are left to the analysis, with the incidence of the
+// inductor branch already stamped
+func stamp_element[T Scalar](m *mna, a [][]T, b []T, e *Element, source T) {
+	i, j := m.node(e.Nodes[0]), m.node(e.Nodes[1])
+
+	switch e.Kind {
+	case 'R':
+		stamp_admittance(a, i, j, scalar[T](1/e.Value))
+	case 'L':
+		stamp_branch(a, i, j, m.branches[e.Name])
+	case 'V':
+		k := m.branches[e.Name]
+		stamp_branch(a, i, j, k)
+		b[k] += source
+	case 'I':
+		stamp_current(b, i, j, source)
+	case 'E':
+		// V(n+) - V(n-) - gain * (V(nc+) - V(nc-)) = 0
+		k := m.branches[e.Name]
+		stamp_branch(a, i, j, k)
+		if c := m.node(e.Nodes[2]); c >= 0 {
+			a[k][c] -= scalar[T](e.Value)
+		}
+		if c := m.node(e.Nodes[3]); c >= 0 {
+			a[k][c] += scalar[T](e.Value)
+		}
+	case 'F':
+		// gain * I(vcontrol) flows from n+ through the source to n-
+		k := m.branches[e.Control]
+		if i >= 0 {
+			a[i][k] += scalar[T](e.Value)
+		}
+		if j >= 0 {
+			a[j][k] -= scalar[T](e.Value)
+		}
+	}
+}
+
+// scalar converts a real value into a matrix entry
+func scalar[T Scalar](v float64) T {
+	var t T
+	switch p := any(&t).(type) {
+	case *float64:
+		*p = v
+	case *complex128:
+		*p = complex(v, 0)
+	}
+	return t
+}
+
+// solve_mna solves the system & explains a singular matrix
+func solve_mna[T Scalar](m *mna, a [][]T, b []T) ([]T, error) {
+	x, err := Solve(a, b)
+	if errors.Is(err, ErrSingularMatrix) {
+		return nil, fmt.Errorf("invalid: netlist %s cannot be solved - a node without a DC path to ground or a loop of voltage sources & inductors", m.netlist.Title)
+	}
+	return x, err
+}
@@ -0,0 +1,290 @@
+package solver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"math/cmplx"
+	"strings"
+)
+
+// Element is a component of a netlist - the first letter of its name is its kind
+//
+//	R          name n+ n- value
+//	C & L      name n+ n- value [IC=value] - the initial voltage & current of a transient with UIC
+//	V & I      name n+ n- [DC] value [AC magnitude [phase]] [PULSE(...) | SIN(...)] - a current source drives its
+//	           current from n+ through itself to n-
+//	E (VCVS)   name n+ n- nc+ nc- gain
+//	F (CCCS)   name n+ n- vcontrol gain - the current through the voltage source vcontrol times gain
+type Element struct {
+	Name     string
+	Kind     byte
+	Nodes    []string
+	Value    float64    // resistance, capacitance, inductance, DC value of a source or gain
+	AC       complex128 // phasor of a source in an AC analysis
+	Waveform *Waveform  // value of a source in a transient analysis, the DC value without one
+	IC       float64    // initial voltage of a capacitor or current of an inductor
+	Control  string     // name of the controlling voltage source of F
+}
+
+// Analysis is a dot command of a netlist, i.e. .op
+type Analysis struct {
+	Kind   string
+	Params []string
+}
+
+type Netlist struct {
+	Title    string
+	Elements []*Element
+	Nodes    []string // nodes other than ground in order of appearance
+	Analyses []Analysis
+}
+
+// IsGround reports whether a node is the ground node 0 - gnd is accepted as well
+func IsGround(node string) bool {
+	return node == "0" || strings.EqualFold(node, "gnd")
+}
+
+// ParseNetlist parses a subset of SPICE - the first line is the title, * starts a comment line, ; an inline comment &
+// + continues the previous line. Parsing stops at .end
+func ParseNetlist(r io.Reader) (*Netlist, error) {
+	lines := []string{}
+
+	scanner := bufio.NewScanner(r)
+	for first := true; scanner.Scan(); first = false {
+		line := scanner.Text()
+		if first {
+			lines = append(lines, line)
+			continue
+		}
+
+		if i := strings.IndexRune(line, ';'); i != -1 {
+			line = line[:i]
+		}
+		line = strings.TrimSpace(line)
+
+		if line == "" || strings.HasPrefix(line, "*") {
+			continue
+		}
+		if strings.HasPrefix(line, "+") {
+			if len(lines) < 2 {
+				return nil, fmt.Errorf("invalid: netlist continuation line %s without a line to continue", line)
+			}
+			lines[len(lines)-1] += " " + strings.TrimSpace(line[1:])
+			continue
+		}
+
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("invalid: empty netlist")
+	}
+
+	elements := []*Element{}
+	analyses := []Analysis{}
+
+	for _, line := range lines[1:] {
+		fields := tokenize_line(line)
+
+		if strings.HasPrefix(fields[0], ".") {
+			kind := strings.ToLower(fields[0][1:])
+			if kind == "end" {
+				break
+			}
+			analyses = append(analyses, Analysis{Kind: kind, Params: fields[1:]})
+			continue
+		}
+
+		e, err := parse_element(fields)
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, e)
+	}
+
+	n, err := NewNetlist(strings.TrimSpace(lines[0]), elements)
+	if err != nil {
+		return nil, err
+	}
+	n.Analyses = analyses
+
+	return n, nil
+}
+
+// NewNetlist collects the nodes of the elements & validates their names & controlling sources - the elements of a
+// built-in circuit are validated the same as a parsed netlist
+func NewNetlist(title string, elements []*Element) (*Netlist, error) {
+	n := &Netlist{Title: title}
+	names := map[string]bool{}
+	nodes := map[string]bool{}
+
+	for _, e := range elements {
+		if names[e.Name] {
+			return nil, fmt.Errorf("invalid: element %s specified multiple times", e.Name)
+		}
+		names[e.Name] = true
+
+		for _, node := range e.Nodes {
+			if !IsGround(node) && !nodes[node] {
+				nodes[node] = true
+				n.Nodes = append(n.Nodes, node)
+			}
+		}
+
+		n.Elements = append(n.Elements, e)
+	}
+
+	for _, e := range n.Elements {
+		if e.Kind == 'F' && !names[e.Control] {
+			return nil, fmt.Errorf("invalid: element %s controlled by the unknown voltage source %s", e.Name, e.Control)
+		} else if e.Kind == 'F' && e.Control[0] != 'V' {
+			return nil, fmt.Errorf("invalid: element %s controlled by %s - expected a voltage source", e.Name, e.Control)
+		}
+	}
+
+	if len(n.Elements) == 0 {
+		return nil, fmt.Errorf("invalid: netlist %s without elements", n.Title)
+	}
+
+	return n, nil
+}
+
+// tokenize_line splits a line on whitespace, commas & parenthesis around source functions - =, ( & ) are kept as
+// separate fields
+func tokenize_line(line string) []string {
+	for _, c := range []string{"(", ")", "="} {
+		line = strings.ReplaceAll(line, c, " "+c+" ")
+	}
+	return strings.Fields(strings.ReplaceAll(line, ",", " "))
+}
+
+func parse_element(fields []string) (*Element, error) {
+	e := &Element{Name: strings.ToUpper(fields[0]), Kind: strings.ToUpper(fields[0])[0]}
+
+	expected := map[byte]int{'R': 4, 'C': 4, 'L': 4, 'V': 4, 'I': 4, 'E': 6, 'F': 5}[e.Kind]
+	if expected == 0 {
+		return nil, fmt.Errorf("invalid or unsupported: element %s", fields[0])
+	}
+	if len(fields) < expected {
+		return nil, fmt.Errorf("invalid: element %s - too few fields", e.Name)
+	}
+
+	var err error
+	switch e.Kind {
+	case 'R', 'C', 'L':
+		e.Nodes = fields[1:3]
+		if e.Kind != 'R' && len(fields) == 7 && strings.EqualFold(fields[4], "IC") && fields[5] == "=" {
+			if e.IC, err = ParseValue(fields[6]); err != nil {
+				return nil, fmt.Errorf("invalid: element %s IC %s", e.Name, fields[6])
+			}
+		} else if len(fields) > 4 {
+			return nil, fmt.Errorf("invalid or unsupported: element %s parameter %s", e.Name, fields[4])
+		}
+		if e.Value, err = ParseValue(fields[3]); err != nil {
+			return nil, fmt.Errorf("invalid: element %s: %w", e.Name, err)
+		}
+		if e.Value <= 0 {
+			return nil, fmt.Errorf("invalid: element %s with a value of %s", e.Name, fields[3])
+		}
+	case 'V', 'I':
+		e.Nodes = fields[1:3]
+		if err := parse_source(e, fields[3:]); err != nil {
+			return nil, err
+		}
+	case 'E':
+		e.Nodes = fields[1:5]
+		if len(fields) > 6 {
+			return nil, fmt.Errorf("invalid or unsupported: element %s parameter %s", e.Name, fields[6])
+		}
+		if e.Value, err = ParseValue(fields[5]); err != nil {
+			return nil, fmt.Errorf("invalid: element %s: %w", e.Name, err)
+		}
+	case 'F':
+		e.Nodes = fields[1:3]
+		e.Control = strings.ToUpper(fields[3])
+		if len(fields) > 5 {
+			return nil, fmt.Errorf("invalid or unsupported: element %s parameter %s", e.Name, fields[5])
+		}
+		if e.Value, err = ParseValue(fields[4]); err != nil {
+			return nil, fmt.Errorf("invalid: element %s: %w", e.Name, err)
+		}
+	}
+
+	if e.Nodes[0] == e.Nodes[1] {
+		return nil, fmt.Errorf("invalid: element %s connected to node %s at both ends", e.Name, e.Nodes[0])
+	}
+
+	return e, nil
+}
+
+// parse_source parses the value of an independent source - [DC] value [AC magnitude [phase]] with the phase in
+// degrees & a PULSE or SIN waveform
+func parse_source(e *Element, fields []string) error {
+	for i := 0; i < len(fields); i++ {
+		switch kind := strings.ToUpper(fields[i]); kind {
+		case "PULSE", "SIN":
+			// the params of a waveform are optionally in parenthesis - PULSE(0 5 1m) or PULSE 0 5 1m
+			w := &Waveform{Kind: kind}
+			parenthesis := i+1 < len(fields) && fields[i+1] == "("
+			if parenthesis {
+				i++
+			}
+			for i+1 < len(fields) && fields[i+1] != ")" {
+				v, err := ParseValue(fields[i+1])
+				if err != nil {
+					if parenthesis {
+						return fmt.Errorf("invalid: element %s %s parameter %s", e.Name, kind, fields[i+1])
+					}
+					break
+				}
+				w.Params = append(w.Params, v)
+				i++
+			}
+			if parenthesis {
+				if i+1 >= len(fields) {
+					return fmt.Errorf("invalid: element %s %s without a closing )", e.Name, kind)
+				}
+				i++
+			}
+			if err := validate_waveform(w); err != nil {
+				return fmt.Errorf("invalid: element %s %w", e.Name, err)
+			}
+			e.Waveform = w
+		case "AC":
+			if i+1 >= len(fields) {
+				return fmt.Errorf("invalid: element %s - AC without a magnitude", e.Name)
+			}
+			magnitude, err := ParseValue(fields[i+1])
+			if err != nil {
+				return fmt.Errorf("invalid: element %s AC magnitude %s", e.Name, fields[i+1])
+			}
+			i++
+
+			phase := 0.
+			if i+1 < len(fields) {
+				if p, err := ParseValue(fields[i+1]); err == nil {
+					phase = p
+					i++
+				}
+			}
+			e.AC = cmplx.Rect(magnitude, phase*math.Pi/180)
+		case "DC":
+			if i+1 >= len(fields) {
+				return fmt.Errorf("invalid: element %s - DC without a value", e.Name)
+			}
+			i++
+			fallthrough
+		default:
+			v, err := ParseValue(fields[i])
+			if err != nil {
+				return fmt.Errorf("invalid or unsupported: element %s parameter %s", e.Name, fields[i])
+			}
+			e.Value = v
+		}
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+package solver
+
+import (
+	"gohm/test_utils"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestParseValue(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected float64
+	}{
+		{"10", 10},
+		{"4.7k", 4700},
+		{"4.7K", 4700},
+		{"1Meg", 1e6},
+		{"1MEG", 1e6},
+		{"10m", 10e-3},
+		{"10M", 10e-3},
+		{"100n", 100e-9},
+		{"4.7uF", 4.7e-6},
+		{"4.7μ", 4.7e-6},
+		{"22pF", 22e-12},
+		{"1e3", 1000},
+		{"2.5e-3V", 2.5e-3},
+		{"-5", -5},
+		{".5", .5},
+		{"10Ohm", 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseValue(tt.input)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-tt.expected) > 1e-15*math.Abs(tt.expected) {
+				t.Errorf("ParseValue(%q) = %v, expected %v", tt.input, got, tt.expected)
+			}
+		})
+	}
+
+	if _, err := ParseValue("k10"); err == nil {
+		t.Error("expected an error for k10")
+	}
+}
+
+func TestParseNetlist(t *testing.T) {
+	n, err := ParseNetlist(strings.NewReader(`Test circuit
+* a comment
+V1 in 0 DC 5 ; inline comment
+R1 in out
++ 1k
+r2 out gnd 1k
+E1 amp 0 out 0 2
+F1 0 sink v1 3
+R3 amp 0 1k
+R4 sink 0 1k
+.op
+.end
+R5 ignored 0 1k
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test_utils.AssertEquals(t, n.Title, "Test circuit")
+	test_utils.AssertEquals(t, len(n.Elements), 7)
+	test_utils.AssertEquals(t, strings.Join(n.Nodes, ","), "in,out,amp,sink")
+	test_utils.AssertEquals(t, len(n.Analyses), 1)
+	test_utils.AssertEquals(t, n.Analyses[0].Kind, "op")
+
+	test_utils.AssertEquals(t, n.Elements[0].Value, 5.)
+	test_utils.AssertEquals(t, n.Elements[1].Value, 1000.)
+	test_utils.AssertEquals(t, n.Elements[2].Name, "R2")
+	test_utils.AssertEquals(t, n.Elements[3].Value, 2.)
+	test_utils.AssertEquals(t, n.Elements[4].Control, "V1")
+}
+
+func TestParseNetlistTransient(t *testing.T) {
+	n, err := ParseNetlist(strings.NewReader(`Transient
+V1 in 0 PULSE(0 5 1m 1u 1u 2m 4m)
+V2 b 0 DC 1 SIN 0 1 1k
+R1 in out 1k
+C1 out 0 1u IC=2.5
+L1 b 0 1m ic=10m
+.tran 1u 10m UIC
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	test_utils.AssertEquals(t, n.Elements[0].Waveform.Kind, "PULSE")
+	test_utils.AssertEquals(t, len(n.Elements[0].Waveform.Params), 7)
+	test_utils.AssertEquals(t, n.Elements[1].Value, 1.)
+	test_utils.AssertEquals(t, n.Elements[1].Waveform.Kind, "SIN")
+	test_utils.AssertEquals(t, n.Elements[1].Waveform.Params[2], 1000.)
+	test_utils.AssertEquals(t, n.Elements[3].IC, 2.5)
+	test_utils.AssertEquals(t, n.Elements[4].IC, .01)
+	test_utils.AssertEquals(t, n.Analyses[0].Kind, "tran")
+}
+
+func TestParseNetlistErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		netlist  string
+		expected string
+	}{
+		{"empty", "", "invalid: empty netlist"},
+		{"no elements", "title\n.op\n", "invalid: netlist title without elements"},
+		{"unknown element", "title\nQ1 c b e model\n", "invalid or unsupported: element Q1"},
+		{"too few fields", "title\nR1 a 0\n", "invalid: element R1 - too few fields"},
+		{"invalid value", "title\nR1 a 0 x\n", "invalid: element R1: invalid: value x"},
+		{"zero resistance", "title\nR1 a 0 0\n", "invalid: element R1 with a value of 0"},
+		{"shorted element", "title\nR1 a a 1k\n", "invalid: element R1 connected to node a at both ends"},
+		{"duplicate element", "title\nR1 a 0 1k\nr1 a 0 1k\n", "invalid: element R1 specified multiple times"},
+		{"unknown control", "title\nF1 a 0 V9 2\nR1 a 0 1k\n", "invalid: element F1 controlled by the unknown voltage source V9"},
+		{"control not a voltage source", "title\nF1 a 0 R1 2\nR1 a 0 1k\n", "invalid: element F1 controlled by R1 - expected a voltage source"},
+		{"unsupported source parameter", "title\nV1 a 0 5 XYZ\n", "invalid or unsupported: element V1 parameter XYZ"},
+		{"pulse without closing parenthesis", "title\nV1 a 0 PULSE(0 5 1m\nR1 a 0 1k\n", "invalid: element V1 PULSE without a closing )"},
+		{"sine with too few parameters", "title\nV1 a 0 SIN(0 1)\nR1 a 0 1k\n", "invalid: element V1 SIN with 2 parameters - expected vo va freq [td [theta]]"},
+		{"continuation without line", "title\n+ 1k\n", "invalid: netlist continuation line + 1k without a line to continue"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseNetlist(strings.NewReader(tt.netlist))
+			if err == nil {
+				t.Fatalf("expected error %q, got nil", tt.expected)
+			}
+			test_utils.AssertEquals(t, err.Error(), tt.expected)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package solver
+
+// ElementResult is the voltage across an element - V(n+) - V(n-) - the current through it from n+ to n- & the power
+// it absorbs - negative when it delivers power, as a source usually does
+type ElementResult struct {
+	Element *Element
+	Voltage float64
+	Current float64
+	Power   float64
+}
+
+type OperatingPoint struct {
+	Voltages map[string]float64 // node voltages, ground included
+	Elements []ElementResult
+}
+
+// SolveOperatingPoint solves the DC operating point - capacitors are open & inductors shorted
+func SolveOperatingPoint(n *Netlist) (*OperatingPoint, error) {
+	m := new_mna(n)
+
+	x, err := solve_dc(m, func(e *Element) float64 {
+		return e.Value
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return get_operating_point(m, x), nil
+}
+
+// solve_dc solves the DC solution with the value of every source from source - the DC value for .op, the value at 0
+// for the start of a transient
+func solve_dc(m *mna, source func(*Element) float64) ([]float64, error) {
+	a, b := new_matrix[float64](m.size), make([]float64, m.size)
+
+	for _, e := range m.netlist.Elements {
+		// capacitors are open & the branch of an inductor is a 0V voltage source - nothing more to stamp
+		stamp_element(m, a, b, e, source(e))
+	}
+
+	return solve_mna(m, a, b)
+}
+
+func get_operating_point(m *mna, x []float64) *OperatingPoint {
+	op := &OperatingPoint{Voltages: map[string]float64{"0": 0}}
+	// + 0 turns the -0 of a node or element at 0 into 0, i.e. -0V
+	for node, i := range m.nodes {
+		op.Voltages[node] = x[i] + 0
+	}
+
+	for _, e := range m.netlist.Elements {
+		r := ElementResult{Element: e, Voltage: node_voltage(m, x, e.Nodes[0]) - node_voltage(m, x, e.Nodes[1])}
+
+		switch e.Kind {
+		case 'R':
+			r.Current = r.Voltage / e.Value
+		case 'L', 'V', 'E':
+			r.Current = x[m.branches[e.Name]]
+		case 'I':
+			r.Current = e.Value
+		case 'F':
+			r.Current = e.Value * x[m.branches[e.Control]]
+		}
+		r.Voltage, r.Current = r.Voltage+0, r.Current+0
+		r.Power = r.Voltage*r.Current + 0
+
+		op.Elements = append(op.Elements, r)
+	}
+
+	return op
+}
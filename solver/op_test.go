@@ -0,0 +1,121 @@
+package solver
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func solve_test_operating_point(t *testing.T, netlist string) *OperatingPoint {
+	t.Helper()
+
+	n, err := ParseNetlist(strings.NewReader(netlist))
+	if err != nil {
+		t.Fatal(err)
+	}
+	op, err := SolveOperatingPoint(n)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return op
+}
+
+func assert_close(t *testing.T, name string, got float64, expected float64) {
+	t.Helper()
+	if math.Abs(got-expected) > 1e-9*max(1, math.Abs(expected)) {
+		t.Errorf("%s = %v, expected %v", name, got, expected)
+	}
+}
+
+func TestSolveOperatingPoint(t *testing.T) {
+	op := solve_test_operating_point(t, `Divider
+V1 in 0 10
+R1 in out 1k
+R2 out 0 4k
+C1 out 0 1u
+L1 in mid 1m
+R3 mid 0 100
+`)
+
+	assert_close(t, "V(in)", op.Voltages["in"], 10)
+	assert_close(t, "V(out)", op.Voltages["out"], 8)
+	assert_close(t, "V(mid)", op.Voltages["mid"], 10)
+
+	expected := map[string][3]float64{
+		"V1": {10, -.102, -1.02},
+		"R1": {2, .002, .004},
+		"R2": {8, .002, .016},
+		"C1": {8, 0, 0},
+		"L1": {0, .1, 0},
+		"R3": {10, .1, 1},
+	}
+	for _, r := range op.Elements {
+		e := expected[r.Element.Name]
+		assert_close(t, r.Element.Name+" voltage", r.Voltage, e[0])
+		assert_close(t, r.Element.Name+" current", r.Current, e[1])
+		assert_close(t, r.Element.Name+" power", r.Power, e[2])
+	}
+}
+
+func TestSolveOperatingPointSources(t *testing.T) {
+	op := solve_test_operating_point(t, `Controlled sources
+V1 in 0 1
+R1 in 0 1k
+E1 amp 0 in 0 10
+R2 amp 0 2k
+F1 0 mirror V1 2
+R3 mirror 0 1k
+I1 0 isrc 1m
+R4 isrc 0 5k
+`)
+
+	assert_close(t, "V(amp)", op.Voltages["amp"], 10)
+	assert_close(t, "V(mirror)", op.Voltages["mirror"], -2)
+	assert_close(t, "V(isrc)", op.Voltages["isrc"], 5)
+
+	// the power delivered by the sources is absorbed by the resistors
+	total := 0.
+	for _, r := range op.Elements {
+		total += r.Power
+	}
+	assert_close(t, "total power", total, 0)
+}
+
+func TestSolveOperatingPointSingular(t *testing.T) {
+	n, err := ParseNetlist(strings.NewReader("Floating\nV1 in 0 5\nC1 in out 1u\nR1 out x 1k\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = SolveOperatingPoint(n)
+	if err == nil || !strings.Contains(err.Error(), "invalid: netlist Floating cannot be solved") {
+		t.Errorf("expected a singular netlist error, got %v", err)
+	}
+}
+
+func TestSolveOperatingPointFloating(t *testing.T) {
+	// a loop of resistors driven by a current source without a DC path to ground beside a grounded divider
+	n, err := ParseNetlist(strings.NewReader("Floating loop\nV1 in 0 5\nR3 in 0 1k\nR1 a b 1k\nR2 b c 2k\nR4 c a 3k\nI1 a c 1m\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = SolveOperatingPoint(n)
+	if err == nil || !strings.Contains(err.Error(), "invalid: netlist Floating loop cannot be solved") {
+		t.Errorf("expected a singular netlist error, got %v", err)
+	}
+}
+
+func TestGetOperatingPointNegativeZero(t *testing.T) {
+	n, err := ParseNetlist(strings.NewReader("Zero\nR1 a 0 1k\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	op := get_operating_point(new_mna(n), []float64{math.Copysign(0, -1)})
+	for name, v := range map[string]float64{"V(a)": op.Voltages["a"], "voltage": op.Elements[0].Voltage, "current": op.Elements[0].Current, "power": op.Elements[0].Power} {
+		if math.Signbit(v) {
+			t.Errorf("%s = -0, expected 0", name)
+		}
+	}
+}
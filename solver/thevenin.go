@@ -0,0 +1,73 @@
+package solver
+
+import (
+	"fmt"
+	"math"
+	"slices"
+)
+
+// SolveThevenin solves the Thevenin equivalent of the port from n+ to n- at DC - the open circuit voltage of the port
+// & the resistance into it with the independent sources zeroed. The Norton current is the voltage over the resistance
+func SolveThevenin(n *Netlist, pos string, neg string) (float64, float64, error) {
+	return solve_port(n, pos, neg, func(m *mna, a [][]float64, b []float64, e *Element, zeroed bool) {
+		// capacitors are open & inductors shorted, as for .op
+		source := e.Value
+		if zeroed {
+			source = 0
+		}
+		stamp_element(m, a, b, e, source)
+	})
+}
+
+// SolveTheveninAC solves the Thevenin equivalent of the port from n+ to n- at a frequency - the open circuit phasor of
+// the AC sources & the impedance into the port with the independent sources zeroed
+func SolveTheveninAC(n *Netlist, pos string, neg string, frequency float64) (complex128, complex128, error) {
+	if err := check_ac_source(n); err != nil {
+		return 0, 0, err
+	}
+
+	omega := 2 * math.Pi * frequency
+	return solve_port(n, pos, neg, func(m *mna, a [][]complex128, b []complex128, e *Element, zeroed bool) {
+		source := e.AC
+		if zeroed {
+			source = 0
+		}
+		stamp_element(m, a, b, e, source)
+		stamp_reactance(m, a, e, omega)
+	})
+}
+
+// solve_port solves the netlist twice - with its sources for the open circuit voltage of the port & with the sources
+// zeroed & a 1A test current into n+ for the impedance into the port. stamp stamps an element, zeroed without its
+// source
+func solve_port[T Scalar](n *Netlist, pos string, neg string, stamp func(m *mna, a [][]T, b []T, e *Element, zeroed bool)) (T, T, error) {
+	var v [2]T
+
+	for _, node := range []string{pos, neg} {
+		if !IsGround(node) && !slices.Contains(n.Nodes, node) {
+			return v[0], v[1], fmt.Errorf("invalid: node %s not in netlist %s", node, n.Title)
+		}
+	}
+	if pos == neg || (IsGround(pos) && IsGround(neg)) {
+		return v[0], v[1], fmt.Errorf("invalid: port %s,%s - expected 2 different nodes", pos, neg)
+	}
+
+	m := new_mna(n)
+	for i, zeroed := range []bool{false, true} {
+		a, b := new_matrix[T](m.size), make([]T, m.size)
+		for _, e := range n.Elements {
+			stamp(m, a, b, e, zeroed)
+		}
+		if zeroed {
+			stamp_current(b, m.node(neg), m.node(pos), scalar[T](1))
+		}
+
+		x, err := solve_mna(m, a, b)
+		if err != nil {
+			return v[0], v[1], err
+		}
+		v[i] = node_voltage(m, x, pos) - node_voltage(m, x, neg)
+	}
+
+	return v[0], v[1], nil
+}
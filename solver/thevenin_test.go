@@ -0,0 +1,87 @@
+package solver
+
+import (
+	"math"
+	"math/cmplx"
+	"strings"
+	"testing"
+)
+
+func TestSolveThevenin(t *testing.T) {
+	tests := []struct {
+		name     string
+		netlist  string
+		pos      string
+		neg      string
+		voltage  float64
+		resistor float64
+	}{
+		{"divider", "Divider\nV1 in 0 10\nR1 in out 1k\nR2 out 0 4k\n", "out", "0", 8, 800},
+		{"divider reversed", "Divider\nV1 in 0 10\nR1 in out 1k\nR2 out 0 4k\n", "gnd", "out", -8, 800},
+		{"current source", "Norton\nI1 0 out 2m\nR1 out 0 1k\n", "out", "0", 2, 1000},
+		{"floating port", "Bridge\nV1 in 0 10\nR1 in a 1k\nR2 a 0 1k\nR3 in b 1k\nR4 b 0 3k\n", "b", "a", 2.5, 1250},
+		{"inductor short & capacitor open", "LC\nV1 in 0 5\nL1 in out 1m\nC1 out 0 1u\nR1 out 0 1k\n", "out", "0", 5, 0},
+		// the controlled source stays - a voltage follower with a gain of 2 has an output resistance of 0
+		{"vcvs", "Amplifier\nV1 in 0 1\nR1 in 0 1k\nE1 amp 0 in 0 2\nR2 amp out 100\nR3 out 0 100\n", "out", "0", 1, 50},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			n, err := ParseNetlist(strings.NewReader(tt.netlist))
+			if err != nil {
+				t.Fatal(err)
+			}
+			v, r, err := SolveThevenin(n, tt.pos, tt.neg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert_close(t, "Vth", v, tt.voltage)
+			assert_close(t, "Rth", r, tt.resistor)
+		})
+	}
+}
+
+func TestSolveTheveninAC(t *testing.T) {
+	n, err := ParseNetlist(strings.NewReader("RC\nV1 in 0 DC 5 AC 1\nR1 in out 1k\nC1 out 0 159n\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, z, err := SolveTheveninAC(n, "out", "0", 1000)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// the capacitor in parallel with the resistor, driven through the resistor
+	zc := 1 / complex(0, 2*math.Pi*1000*159e-9)
+	expected_z := 1000 * zc / (1000 + zc)
+	expected_v := zc / (1000 + zc)
+	if cmplx.Abs(z-expected_z) > 1e-9 || cmplx.Abs(v-expected_v) > 1e-12 {
+		t.Errorf("SolveTheveninAC = %v & %v, expected %v & %v", v, z, expected_v, expected_z)
+	}
+}
+
+func TestSolveTheveninErrors(t *testing.T) {
+	n, err := ParseNetlist(strings.NewReader("Divider\nV1 in 0 10\nR1 in out 1k\nR2 out 0 4k\nC1 out open 1u\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		pos      string
+		neg      string
+		expected string
+	}{
+		{"x", "0", "invalid: node x not in netlist Divider"},
+		{"out", "out", "invalid: port out,out - expected 2 different nodes"},
+		{"0", "gnd", "invalid: port 0,gnd - expected 2 different nodes"},
+		{"open", "0", "invalid: netlist Divider cannot be solved - a node without a DC path to ground or a loop of voltage sources & inductors"},
+	}
+
+	for _, tt := range tests {
+		_, _, err := SolveThevenin(n, tt.pos, tt.neg)
+		if err == nil || err.Error() != tt.expected {
+			t.Errorf("SolveThevenin(%s, %s) = %v, expected %q", tt.pos, tt.neg, err, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,475 @@
+package solver
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+const (
+	METHOD_TRAPEZOIDAL = "trapezoidal"
+	METHOD_EULER       = "euler" // backward Euler
+)
+
+// transient_max_steps bounds a transient, i.e. a .tran 1n 1 by mistake
+const transient_max_steps = 1000000
+
+// transient_min_steps is the number of internal steps a transient takes at least - the internal step is at most
+// tstop/50, however coarse the tstep the points are printed at
+const transient_min_steps = 50
+
+// transient_restart is how much smaller than the internal step the first step from a breakpoint is - the step doubles
+// back up to the internal step after it
+const transient_restart = 64
+
+// Waveform is the value of a source over time
+//
+//	PULSE v1 v2 [td [tr [tf [pw [per]]]]] - without pw & per the pulse is a step from v1 to v2 at td
+//	SIN vo va freq [td [theta]]           - vo + va e^(-theta (t - td)) sin(2π freq (t - td)) from td
+type Waveform struct {
+	Kind   string
+	Params []float64
+}
+
+func validate_waveform(w *Waveform) error {
+	switch {
+	case w.Kind == "PULSE" && (len(w.Params) < 2 || len(w.Params) > 7):
+		return fmt.Errorf("PULSE with %d parameters - expected v1 v2 [td [tr [tf [pw [per]]]]]", len(w.Params))
+	case w.Kind == "SIN" && (len(w.Params) < 3 || len(w.Params) > 5):
+		return fmt.Errorf("SIN with %d parameters - expected vo va freq [td [theta]]", len(w.Params))
+	}
+	for _, p := range w.Params[min(len(w.Params), 2):] {
+		if p < 0 {
+			return fmt.Errorf("%s with a negative time or frequency %g", w.Kind, p)
+		}
+	}
+	return nil
+}
+
+// param returns the nth parameter of the waveform or a default when it was left out
+func (w *Waveform) param(n int, def float64) float64 {
+	if n < len(w.Params) {
+		return w.Params[n]
+	}
+	return def
+}
+
+// At returns the value of the waveform at the time t
+func (w *Waveform) At(t float64) float64 {
+	switch w.Kind {
+	case "SIN":
+		vo, va, freq, td, theta := w.Params[0], w.Params[1], w.Params[2], w.param(3, 0), w.param(4, 0)
+		if t < td {
+			return vo
+		}
+		return vo + va*math.Exp(-theta*(t-td))*math.Sin(2*math.Pi*freq*(t-td))
+	default:
+		v1, v2, td, tr, tf := w.Params[0], w.Params[1], w.param(2, 0), w.param(3, 0), w.param(4, 0)
+		pw, per := w.param(5, math.Inf(1)), w.param(6, math.Inf(1))
+		if t < td {
+			return v1
+		}
+
+		t -= td
+		if per > 0 && !math.IsInf(per, 1) {
+			t = math.Mod(t, per)
+		}
+
+		switch {
+		case t == 0 && tr == 0:
+			// an ideal edge starts at v1, so a step at 0 starts the transient from v1
+			return v1
+		case t < tr:
+			return v1 + (v2-v1)*t/tr
+		case t < tr+pw:
+			return v2
+		case t < tr+pw+tf:
+			return v2 + (v1-v2)*(t-tr-pw)/tf
+		default:
+			return v1
+		}
+	}
+}
+
+// TransientParams are the params of a .tran - tstep tstop [tstart [tmax]] [UIC]. Points are printed every tstep &
+// points before tstart are left out. The internal step is at most tmax. With UIC the transient starts from the IC of
+// the capacitors & inductors instead of the operating point
+type TransientParams struct {
+	Step  float64
+	Stop  float64
+	Start float64
+	Max   float64 // 0 when left out
+	UIC   bool
+}
+
+func ParseTransient(params []string) (TransientParams, error) {
+	p := TransientParams{}
+	if len(params) > 0 && strings.EqualFold(params[len(params)-1], "UIC") {
+		p.UIC = true
+		params = params[:len(params)-1]
+	}
+	if len(params) < 2 || len(params) > 4 {
+		return p, fmt.Errorf("invalid: transient %s - expected tstep tstop [tstart [tmax]] [UIC]", strings.Join(params, " "))
+	}
+
+	values := make([]float64, len(params))
+	for i, param := range params {
+		v, err := ParseValue(param)
+		if err != nil || v < 0 {
+			return p, fmt.Errorf("invalid: transient time %s", param)
+		}
+		values[i] = v
+	}
+
+	p.Step, p.Stop = values[0], values[1]
+	if len(values) > 2 {
+		p.Start = values[2]
+	}
+	if len(values) > 3 {
+		p.Max = values[3]
+	}
+
+	if p.Step <= 0 || p.Stop <= 0 {
+		return p, fmt.Errorf("invalid: transient %s - tstep & tstop must be above 0", strings.Join(params, " "))
+	}
+	if p.Start >= p.Stop {
+		return p, fmt.Errorf("invalid: transient tstart %s at or after tstop %s", params[2], params[1])
+	}
+	if p.Stop/p.max_step() > transient_max_steps {
+		return p, fmt.Errorf("invalid: transient of more than %d steps - a larger tstep or a smaller tstop", transient_max_steps)
+	}
+
+	return p, nil
+}
+
+// max_step returns the internal step of the transient - the smallest of tstep, tmax & tstop/50
+func (p TransientParams) max_step() float64 {
+	step := min(p.Step, p.Stop/transient_min_steps)
+	if p.Max > 0 {
+		step = min(step, p.Max)
+	}
+	return step
+}
+
+type TransientPoint struct {
+	Time     float64
+	Voltages map[string]float64 // node voltages, ground included
+}
+
+// Transient integrates a netlist over time 1 step at a time - the value of an element may change between steps, i.e.
+// a resistor as a switch
+type Transient struct {
+	Time   float64
+	mna    *mna
+	method string
+	step   float64
+	x      []float64
+	// voltage across & current through every capacitor & inductor at the last step
+	state map[string][2]float64
+	// the first step of UIC & after a discontinuity has no consistent capacitor currents for the trapezoidal rule
+	euler bool
+}
+
+func NewTransient(n *Netlist, step float64, method string, uic bool) (*Transient, error) {
+	if method != METHOD_TRAPEZOIDAL && method != METHOD_EULER {
+		return nil, fmt.Errorf("invalid or unsupported: integration method %s", method)
+	}
+
+	tr := &Transient{mna: new_mna(n), method: method, step: step, state: map[string][2]float64{}}
+
+	if uic {
+		tr.euler = true
+		for _, e := range n.Elements {
+			switch e.Kind {
+			case 'C':
+				tr.state[e.Name] = [2]float64{e.IC, 0}
+			case 'L':
+				tr.state[e.Name] = [2]float64{0, e.IC}
+			}
+		}
+		tr.x = solve_initial_conditions(tr.mna)
+		return tr, nil
+	}
+
+	x, err := solve_dc(tr.mna, func(e *Element) float64 {
+		return source_at(e, 0)
+	})
+	if err != nil {
+		return nil, err
+	}
+	tr.x = x
+	tr.update_state(x, nil)
+
+	return tr, nil
+}
+
+// solve_initial_conditions returns the solution at 0 of a transient with UIC - the capacitors are voltage sources &
+// the inductors current sources of their IC. Inconsistent initial conditions, i.e. a capacitor across a voltage
+// source, start from 0V & the IC of the inductors instead
+func solve_initial_conditions(m *mna) []float64 {
+	x := make([]float64, m.size)
+
+	elements := make([]*Element, len(m.netlist.Elements))
+	for i, e := range m.netlist.Elements {
+		initial := *e
+		switch e.Kind {
+		case 'C':
+			initial = Element{Name: e.Name, Kind: 'V', Nodes: e.Nodes, Value: e.IC}
+		case 'L':
+			initial = Element{Name: e.Name, Kind: 'I', Nodes: e.Nodes, Value: e.IC}
+			x[m.branches[e.Name]] = e.IC
+		}
+		elements[i] = &initial
+	}
+
+	n, err := NewNetlist(m.netlist.Title, elements)
+	if err != nil {
+		return x
+	}
+	initial := new_mna(n)
+	solution, err := solve_dc(initial, func(e *Element) float64 {
+		return source_at(e, 0)
+	})
+	if err != nil {
+		return x
+	}
+
+	for node, i := range initial.nodes {
+		x[m.nodes[node]] = solution[i]
+	}
+	// the capacitors are branches of the initial netlist only & the inductors of the transient only
+	for name, k := range initial.branches {
+		if branch, ok := m.branches[name]; ok {
+			x[branch] = solution[k]
+		}
+	}
+
+	return x
+}
+
+// round_time rounds a time to 12 significant figures so 11 steps of 100μs are 1.1ms rather than 1.1000000000000001ms
+func round_time(t float64) float64 {
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(t, 'g', 12, 64), 64)
+	return rounded
+}
+
+func source_at(e *Element, t float64) float64 {
+	if e.Waveform != nil {
+		return e.Waveform.At(t)
+	}
+	return e.Value
+}
+
+// Step advances the transient by 1 step
+func (tr *Transient) Step() error {
+	return tr.StepBy(tr.step)
+}
+
+// StepBy advances the transient by h, i.e. a part of a step to land on an event within it - capacitors & inductors
+// are replaced by their companion models, a conductance & a current source for a capacitor, of the integration method
+func (tr *Transient) StepBy(h float64) error {
+	m := tr.mna
+	t := round_time(tr.Time + h)
+	a, b := new_matrix[float64](m.size), make([]float64, m.size)
+
+	trapezoidal := tr.method == METHOD_TRAPEZOIDAL && !tr.euler
+	companion := map[string]float64{}
+
+	for _, e := range m.netlist.Elements {
+		stamp_element(m, a, b, e, source_at(e, t))
+
+		state := tr.state[e.Name]
+		switch e.Kind {
+		case 'C':
+			// I = G V - Ieq with G = C/h & Ieq = G Vprev for backward Euler, G = 2C/h & Ieq = G Vprev + Iprev
+			// for the trapezoidal rule
+			g := e.Value / h
+			ieq := g * state[0]
+			if trapezoidal {
+				g *= 2
+				ieq = g*state[0] + state[1]
+			}
+			i, j := m.node(e.Nodes[0]), m.node(e.Nodes[1])
+			stamp_admittance(a, i, j, g)
+			stamp_current(b, j, i, ieq)
+			companion[e.Name] = ieq
+		case 'L':
+			// V - L/h I = -L/h Iprev for backward Euler, V - 2L/h I = -2L/h Iprev - Vprev for the trapezoidal rule
+			k := m.branches[e.Name]
+			r := e.Value / h
+			if trapezoidal {
+				r *= 2
+				b[k] -= state[0]
+			}
+			a[k][k] -= r
+			b[k] -= r * state[1]
+		}
+	}
+
+	x, err := solve_mna(m, a, b)
+	if err != nil {
+		return err
+	}
+
+	tr.x = x
+	tr.Time = t
+	tr.euler = false
+	tr.update_state(x, func(e *Element, v float64) float64 {
+		g := e.Value / h
+		if trapezoidal {
+			g *= 2
+		}
+		return g*v - companion[e.Name]
+	})
+
+	return nil
+}
+
+// Discontinuity marks a change of the circuit, i.e. a switched resistor - the next step is a backward Euler step as
+// the capacitor currents of the trapezoidal rule no longer hold
+func (tr *Transient) Discontinuity() {
+	tr.euler = true
+}
+
+// Clone returns a copy of the transient to step ahead of it, i.e. to find when an event happens within the next step
+func (tr *Transient) Clone() *Transient {
+	clone := *tr
+	clone.x = append([]float64{}, tr.x...)
+	clone.state = make(map[string][2]float64, len(tr.state))
+	for name, state := range tr.state {
+		clone.state[name] = state
+	}
+	return &clone
+}
+
+// update_state keeps the voltage & current of every capacitor & inductor - capacitor currents are 0 at the operating
+// point & from the companion model after a step
+func (tr *Transient) update_state(x []float64, capacitor_current func(*Element, float64) float64) {
+	m := tr.mna
+	for _, e := range m.netlist.Elements {
+		v := node_voltage(m, x, e.Nodes[0]) - node_voltage(m, x, e.Nodes[1])
+		switch e.Kind {
+		case 'C':
+			i := 0.
+			if capacitor_current != nil {
+				i = capacitor_current(e, v)
+			}
+			tr.state[e.Name] = [2]float64{v, i}
+		case 'L':
+			tr.state[e.Name] = [2]float64{v, x[m.branches[e.Name]]}
+		}
+	}
+}
+
+// Point returns the node voltages at the last step
+func (tr *Transient) Point() TransientPoint {
+	p := TransientPoint{Time: tr.Time, Voltages: map[string]float64{"0": 0}}
+	for node, i := range tr.mna.nodes {
+		p.Voltages[node] = tr.x[i]
+	}
+	return p
+}
+
+// SolveTransient integrates the netlist from 0 to the stop time of the params
+//
+// The internal step is the smallest of tstep, tmax & tstop/50 & the transient lands on every breakpoint of its sources,
+// i.e. the edges of a pulse, & on every point printed every tstep rather than interpolating them. From 0 & every
+// breakpoint it restarts with a backward Euler step 1/64 of the internal step, which doubles back up to the internal step
+func SolveTransient(n *Netlist, params TransientParams, method string) ([]TransientPoint, error) {
+	step := params.max_step()
+	tr, err := NewTransient(n, step, method, params.UIC)
+	if err != nil {
+		return nil, err
+	}
+
+	// print points are counted rather than the time accumulated so the stop time isn't missed to rounding
+	prints := int(math.Floor(params.Stop/params.Step + 1e-9))
+	next := 1
+	print_time := func() float64 {
+		return round_time(float64(next) * params.Step)
+	}
+
+	points := []TransientPoint{}
+	if params.Start <= 0 {
+		points = append(points, tr.Point())
+	}
+
+	stop := round_time(params.Stop)
+	breaks := append(breakpoints(n, stop), stop)
+	h := step / transient_restart
+	tr.Discontinuity()
+
+	for tr.Time < stop {
+		for breaks[0] <= tr.Time {
+			breaks = breaks[1:]
+		}
+		target := breaks[0]
+		if next <= prints {
+			target = min(target, print_time())
+		}
+
+		// a step within a quarter step of the target is stretched to land on it
+		dt := h
+		if target-tr.Time < 1.25*h {
+			dt = target - tr.Time
+		}
+		if err := tr.StepBy(dt); err != nil {
+			return nil, err
+		}
+
+		if next <= prints && tr.Time == print_time() {
+			if tr.Time >= params.Start*(1-1e-9) {
+				points = append(points, tr.Point())
+			}
+			next++
+		}
+
+		h = min(2*h, step)
+		if tr.Time == breaks[0] {
+			tr.Discontinuity()
+			h = step / transient_restart
+		}
+	}
+
+	return points, nil
+}
+
+// breakpoints returns the times between 0 & stop a source changes abruptly, ascending
+func breakpoints(n *Netlist, stop float64) []float64 {
+	times := []float64{}
+	for _, e := range n.Elements {
+		if e.Waveform == nil {
+			continue
+		}
+		for _, t := range e.Waveform.breakpoints(stop) {
+			if t = round_time(t); t > 0 && t < stop {
+				times = append(times, t)
+			}
+		}
+	}
+
+	slices.Sort(times)
+	return slices.Compact(times)
+}
+
+// breakpoints returns the times the waveform changes abruptly up to stop - the corners of a pulse in every period & the
+// start of a sine
+func (w *Waveform) breakpoints(stop float64) []float64 {
+	if w.Kind == "SIN" {
+		return []float64{w.param(3, 0)}
+	}
+
+	td, tr, tf := w.param(2, 0), w.param(3, 0), w.param(4, 0)
+	pw, per := w.param(5, math.Inf(1)), w.param(6, math.Inf(1))
+
+	times := []float64{}
+	for start := td; start < stop && len(times) < transient_max_steps; start += per {
+		times = append(times, start, start+tr, start+tr+pw, start+tr+pw+tf)
+		if per <= 0 || math.IsInf(per, 1) {
+			break
+		}
+	}
+	return times
+}
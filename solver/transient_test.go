@@ -0,0 +1,237 @@
+package solver
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestWaveformAt(t *testing.T) {
+	tests := []struct {
+		name     string
+		waveform Waveform
+		time     float64
+		expected float64
+	}{
+		{"step at 0", Waveform{"PULSE", []float64{0, 5}}, 0, 0},
+		{"step after 0", Waveform{"PULSE", []float64{0, 5}}, 1e-9, 5},
+		{"step before delay", Waveform{"PULSE", []float64{1, 5, 1e-3}}, .5e-3, 1},
+		{"pulse rise", Waveform{"PULSE", []float64{0, 4, 0, 1e-3, 1e-3, 1e-3, 4e-3}}, .5e-3, 2},
+		{"pulse width", Waveform{"PULSE", []float64{0, 4, 0, 1e-3, 1e-3, 1e-3, 4e-3}}, 1.5e-3, 4},
+		{"pulse fall", Waveform{"PULSE", []float64{0, 4, 0, 1e-3, 1e-3, 1e-3, 4e-3}}, 2.25e-3, 3},
+		{"pulse low", Waveform{"PULSE", []float64{0, 4, 0, 1e-3, 1e-3, 1e-3, 4e-3}}, 3.5e-3, 0},
+		{"pulse next period", Waveform{"PULSE", []float64{0, 4, 0, 1e-3, 1e-3, 1e-3, 4e-3}}, 5.5e-3, 4},
+		{"sine", Waveform{"SIN", []float64{1, 2, 250}}, 1e-3, 3},
+		{"sine before delay", Waveform{"SIN", []float64{1, 2, 250, 1e-3}}, .5e-3, 1},
+		{"damped sine", Waveform{"SIN", []float64{0, 1, 250, 0, 1000}}, 1e-3, math.Exp(-1)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert_close(t, "At", tt.waveform.At(tt.time), tt.expected)
+		})
+	}
+}
+
+func TestParseTransient(t *testing.T) {
+	tests := []struct {
+		params   string
+		expected TransientParams
+	}{
+		{"1u 1m", TransientParams{Step: 1e-6, Stop: 1e-3}},
+		{"1u 1m 500u", TransientParams{Step: 1e-6, Stop: 1e-3, Start: 5e-4}},
+		{"1u 1m 0 100n", TransientParams{Step: 1e-6, Stop: 1e-3, Max: 1e-7}},
+		{"1u 1m uic", TransientParams{Step: 1e-6, Stop: 1e-3, UIC: true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.params, func(t *testing.T) {
+			got, err := ParseTransient(strings.Fields(tt.params))
+			if err != nil {
+				t.Fatal(err)
+			}
+			assert_close(t, "Step", got.Step, tt.expected.Step)
+			assert_close(t, "Stop", got.Stop, tt.expected.Stop)
+			assert_close(t, "Start", got.Start, tt.expected.Start)
+			assert_close(t, "Max", got.Max, tt.expected.Max)
+			if got.UIC != tt.expected.UIC {
+				t.Errorf("UIC = %v, expected %v", got.UIC, tt.expected.UIC)
+			}
+		})
+	}
+
+	for _, params := range []string{"1u", "1u 1m 0 1n 1", "0 1m", "1u x", "1u 1m 1m", "1n 1", "UIC"} {
+		if _, err := ParseTransient(strings.Fields(params)); err == nil {
+			t.Errorf("expected an error for %q", params)
+		}
+	}
+}
+
+func solve_test_transient(t *testing.T, netlist string, params string, method string) []TransientPoint {
+	t.Helper()
+
+	n, err := ParseNetlist(strings.NewReader(netlist))
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := ParseTransient(strings.Fields(params))
+	if err != nil {
+		t.Fatal(err)
+	}
+	points, err := SolveTransient(n, p, method)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return points
+}
+
+func TestSolveTransientRC(t *testing.T) {
+	netlist := `RC step
+V1 in 0 PULSE(0 1 0)
+R1 in out 1k
+C1 out 0 1u
+`
+	for _, method := range []string{METHOD_TRAPEZOIDAL, METHOD_EULER} {
+		t.Run(method, func(t *testing.T) {
+			points := solve_test_transient(t, netlist, "1u 5m", method)
+			if len(points) != 5001 {
+				t.Fatalf("len(points) = %d, expected 5001", len(points))
+			}
+			assert_close(t, "V(out) at 0", points[0].Voltages["out"], 0)
+
+			// 1 - e^(-t/RC) to the accuracy of the method
+			for _, i := range []int{1000, 2000, 5000} {
+				expected := 1 - math.Exp(-points[i].Time/1e-3)
+				if got := points[i].Voltages["out"]; math.Abs(got-expected) > 1e-3 {
+					t.Errorf("V(out) at %v = %v, expected %v", points[i].Time, got, expected)
+				}
+			}
+		})
+	}
+}
+
+func TestSolveTransientCoarseStep(t *testing.T) {
+	// a tstep of half the time constant is only where the points are printed - the transient steps far finer
+	netlist := `RC step
+V1 in 0 PULSE(0 1 0)
+R1 in out 1k
+C1 out 0 1u
+`
+	for method, tolerance := range map[string]float64{METHOD_TRAPEZOIDAL: 1e-3, METHOD_EULER: 1e-2} {
+		t.Run(method, func(t *testing.T) {
+			points := solve_test_transient(t, netlist, "500u 2m", method)
+			if len(points) != 5 {
+				t.Fatalf("len(points) = %d, expected 5", len(points))
+			}
+			for _, p := range points {
+				expected := 1 - math.Exp(-p.Time/1e-3)
+				if got := p.Voltages["out"]; math.Abs(got-expected) > tolerance {
+					t.Errorf("V(out) at %v = %v, expected %v", p.Time, got, expected)
+				}
+			}
+		})
+	}
+
+	// the transient lands on the edges of a pulse in the middle of a step rather than stepping over them
+	points := solve_test_transient(t, `RC pulse
+V1 in 0 PULSE(0 1 300u 0 0 1 1)
+R1 in out 1k
+C1 out 0 1u
+`, "500u 2m", METHOD_TRAPEZOIDAL)
+	for _, p := range points {
+		expected := 0.
+		if p.Time > 3e-4 {
+			expected = 1 - math.Exp(-(p.Time-3e-4)/1e-3)
+		}
+		if got := p.Voltages["out"]; math.Abs(got-expected) > 1e-3 {
+			t.Errorf("V(out) at %v = %v, expected %v", p.Time, got, expected)
+		}
+	}
+}
+
+func TestSolveTransientRL(t *testing.T) {
+	points := solve_test_transient(t, `RL step
+V1 in 0 PULSE(0 1 0)
+R1 in out 100
+L1 out 0 100m
+`, "1u 2m", METHOD_TRAPEZOIDAL)
+
+	// e^(-t R/L) across the inductor
+	last := points[len(points)-1]
+	assert_close(t, "time", last.Time, 2e-3)
+	if got, expected := last.Voltages["out"], math.Exp(-2); math.Abs(got-expected) > 1e-4 {
+		t.Errorf("V(out) = %v, expected %v", got, expected)
+	}
+}
+
+func TestSolveTransientUIC(t *testing.T) {
+	netlist := `RC discharge
+R1 out 0 1k
+C1 out 0 1u IC=2
+.end
+`
+	points := solve_test_transient(t, netlist, "10u 1m 500u UIC", METHOD_TRAPEZOIDAL)
+
+	assert_close(t, "start", points[0].Time, 5e-4)
+	last := points[len(points)-1]
+	if got, expected := last.Voltages["out"], 2*math.Exp(-1); math.Abs(got-expected) > 1e-3 {
+		t.Errorf("V(out) = %v, expected %v", got, expected)
+	}
+
+	// the capacitor is a voltage source of its IC at 0
+	points = solve_test_transient(t, netlist, "10u 1m UIC", METHOD_TRAPEZOIDAL)
+	assert_close(t, "V(out) at 0", points[0].Voltages["out"], 2)
+
+	// without UIC the capacitor starts from the operating point at 0V
+	points = solve_test_transient(t, netlist, "10u 1m", METHOD_TRAPEZOIDAL)
+	assert_close(t, "V(out)", points[len(points)-1].Voltages["out"], 0)
+}
+
+func TestTransientStepBy(t *testing.T) {
+	n, err := ParseNetlist(strings.NewReader(`Switch
+V1 in 0 1
+R1 in out 1k
+C1 out 0 1u
+RS out 0 1G
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tr, err := NewTransient(n, 1e-6, METHOD_TRAPEZOIDAL, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// a clone steps ahead without advancing the transient
+	clone := tr.Clone()
+	if err := clone.Step(); err != nil {
+		t.Fatal(err)
+	}
+	assert_close(t, "time", tr.Time, 0)
+	assert_close(t, "clone time", clone.Time, 1e-6)
+
+	for tr.Time < 1e-3 {
+		if err := tr.StepBy(min(1e-6, 1e-3-tr.Time)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	assert_close(t, "time", tr.Time, 1e-3)
+	if got, expected := tr.Point().Voltages["out"], 1-math.Exp(-1); math.Abs(got-expected) > 1e-4 {
+		t.Errorf("V(out) = %v, expected %v", got, expected)
+	}
+
+	// the switch closes & the capacitor discharges through it
+	n.Elements[3].Value = 1e-3
+	tr.Discontinuity()
+	if err := tr.Step(); err != nil {
+		t.Fatal(err)
+	}
+	if got := tr.Point().Voltages["out"]; math.Abs(got) > 1e-3 {
+		t.Errorf("V(out) = %v, expected about 0", got)
+	}
+
+	if _, err := NewTransient(n, 1e-6, "gear", false); err == nil {
+		t.Errorf("expected an error for the method gear")
+	}
+}
@@ -0,0 +1,56 @@
+package solver
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var value_pattern = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?`)
+
+// ParseValue parses a SPICE number - a scale suffix (t, g, meg, k, m, u/μ, n, p, f - case insensitive, so m is milli
+// & meg mega) and any unit letters after it, i.e. 4.7uF, 1Meg or 10mA
+func ParseValue(s string) (float64, error) {
+	number := value_pattern.FindString(s)
+	if number == "" {
+		return 0, fmt.Errorf("invalid: value %s", s)
+	}
+
+	value, err := strconv.ParseFloat(number, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid: value %s", s)
+	}
+
+	suffix := strings.ToLower(s[len(number):])
+	if strings.HasPrefix(suffix, "meg") {
+		return value * 1e6, nil
+	}
+	if strings.HasPrefix(suffix, "mil") {
+		return value * 25.4e-6, nil
+	}
+
+	scale := 1.
+	if r := []rune(suffix); len(r) > 0 {
+		switch r[0] {
+		case 't':
+			scale = 1e12
+		case 'g':
+			scale = 1e9
+		case 'k':
+			scale = 1e3
+		case 'm':
+			scale = 1e-3
+		case 'u', 'μ', 'µ':
+			scale = 1e-6
+		case 'n':
+			scale = 1e-9
+		case 'p':
+			scale = 1e-12
+		case 'f':
+			scale = 1e-15
+		}
+	}
+
+	return value * scale, nil
+}
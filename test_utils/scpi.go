@@ -0,0 +1,60 @@
+package test_utils
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// StartFakeSCPIServer starts a local SCPI instrument on a raw TCP socket & returns its tcp:// address
+//
+// Every query is answered with the next of its responses, starting over after the last one. Anything else is never
+// answered, like a real instrument that does not support the query
+func StartFakeSCPIServer(t *testing.T, responses map[string][]string) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("could not start fake SCPI server: %v", err)
+	}
+	t.Cleanup(func() { listener.Close() })
+
+	var mu sync.Mutex
+	next := map[string]int{}
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+
+			go func() {
+				defer conn.Close()
+
+				scanner := bufio.NewScanner(conn)
+				for scanner.Scan() {
+					query := strings.ToUpper(strings.TrimSpace(scanner.Text()))
+
+					mu.Lock()
+					values, ok := responses[query]
+					response := ""
+					if ok && len(values) > 0 {
+						response = values[next[query]%len(values)]
+						next[query]++
+					}
+					mu.Unlock()
+
+					if ok {
+						fmt.Fprintf(conn, "%s\n", response)
+					}
+				}
+			}()
+		}
+	}()
+
+	return "tcp://" + listener.Addr().String()
+}
@@ -3,6 +3,7 @@ package test_utils
 import (
 	"fmt"
 	"gohm/cli"
+	"maps"
 	"strings"
 	"testing"
 )
@@ -44,6 +45,14 @@ func CreateTestCommand(handler func(*cli.Command) string, flags map[string]strin
 	return cmd
 }
 
+// MergeFlags returns the default flags of a test table with the flags of a test case over them - an empty value leaves
+// the flag unset
+func MergeFlags(defaults map[string]string, flags map[string]string) map[string]string {
+	merged := maps.Clone(defaults)
+	maps.Copy(merged, flags)
+	return merged
+}
+
 // ExpectPanic runs fn and verifies it panics with the expected message.
 // Returns true if the panic occurred with the expected message.
 func ExpectPanic(t *testing.T, expected string, fn func()) {
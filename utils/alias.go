@@ -0,0 +1,186 @@
+package utils
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const COLOR_ALIAS_DEFAULT_LANGUAGE = "en"
+
+// COLOR_ALIAS_CONFIG_ENV overrides the path of the user color alias config file
+const COLOR_ALIAS_CONFIG_ENV = "GOHM_COLOR_ALIASES"
+
+// COLOR_ALIAS_MAPPING maps alternative & localized color names per language onto the color names of EIA_COLOR_MAPPING
+//
+// The en aliases (including the digits) are shared by every language
+var COLOR_ALIAS_MAPPING = map[string]map[string]string{
+	"en": {
+		"0":      "black",
+		"1":      "brown",
+		"2":      "red",
+		"3":      "orange",
+		"4":      "yellow",
+		"5":      "green",
+		"6":      "blue",
+		"7":      "violet",
+		"8":      "grey",
+		"9":      "white",
+		"gray":   "grey",
+		"purple": "violet",
+	},
+	"de": {
+		"schwarz": "black",
+		"braun":   "brown",
+		"rot":     "red",
+		"orange":  "orange",
+		"gelb":    "yellow",
+		"grün":    "green",
+		"gruen":   "green",
+		"blau":    "blue",
+		"violett": "violet",
+		"lila":    "violet",
+		"grau":    "grey",
+		"weiß":    "white",
+		"weiss":   "white",
+		"gold":    "gold",
+		"silber":  "silver",
+		"rosa":    "pink",
+		"keine":   "none",
+	},
+	"fr": {
+		"noir":   "black",
+		"marron": "brown",
+		"brun":   "brown",
+		"rouge":  "red",
+		"orange": "orange",
+		"jaune":  "yellow",
+		"vert":   "green",
+		"bleu":   "blue",
+		"violet": "violet",
+		"gris":   "grey",
+		"blanc":  "white",
+		"or":     "gold",
+		"argent": "silver",
+		"rose":   "pink",
+		"aucune": "none",
+	},
+	"es": {
+		"negro":    "black",
+		"marrón":   "brown",
+		"marron":   "brown",
+		"café":     "brown",
+		"cafe":     "brown",
+		"rojo":     "red",
+		"naranja":  "orange",
+		"amarillo": "yellow",
+		"verde":    "green",
+		"azul":     "blue",
+		"violeta":  "violet",
+		"morado":   "violet",
+		"gris":     "grey",
+		"blanco":   "white",
+		"oro":      "gold",
+		"dorado":   "gold",
+		"plata":    "silver",
+		"plateado": "silver",
+		"rosa":     "pink",
+		"ninguna":  "none",
+	},
+}
+
+var load_user_color_aliases_once sync.Once
+
+// GetColorAliasConfigPath returns the user color alias config file - $GOHM_COLOR_ALIASES or <user config dir>/gohm/colors.json
+func GetColorAliasConfigPath() string {
+	if path := os.Getenv(COLOR_ALIAS_CONFIG_ENV); path != "" {
+		return path
+	}
+
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+
+	return filepath.Join(dir, "gohm", "colors.json")
+}
+
+// LoadColorAliases merges a JSON config of the form {"nl": {"zwart": "black"}} into COLOR_ALIAS_MAPPING - new languages are added
+func LoadColorAliases(path string) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	config := map[string]map[string]string{}
+	if err := json.Unmarshal(data, &config); err != nil {
+		return fmt.Errorf("invalid: color alias config %s: %w", path, err)
+	}
+
+	// validate every alias before merging any so an invalid config leaves COLOR_ALIAS_MAPPING untouched
+	merged := map[string]map[string]string{}
+	for lang, aliases := range config {
+		lang = strings.ToLower(lang)
+		if _, ok := merged[lang]; !ok {
+			merged[lang] = map[string]string{}
+		}
+
+		for alias, color := range aliases {
+			color = strings.ToLower(color)
+			if _, ok := EIA_COLOR_MAPPING[color]; !ok && color != "none" {
+				return fmt.Errorf("invalid: color alias %s maps to unknown color %s", alias, color)
+			}
+			merged[lang][strings.ToLower(alias)] = color
+		}
+	}
+
+	for lang, aliases := range merged {
+		if _, ok := COLOR_ALIAS_MAPPING[lang]; !ok {
+			COLOR_ALIAS_MAPPING[lang] = map[string]string{}
+		}
+		maps.Copy(COLOR_ALIAS_MAPPING[lang], aliases)
+	}
+
+	return nil
+}
+
+// ResolveColorAliases maps every arg that is an alias in the given language onto its color name - other args are returned as is
+func ResolveColorAliases(args []string, lang string) []string {
+	load_user_color_aliases_once.Do(func() {
+		if path := GetColorAliasConfigPath(); path != "" {
+			if err := LoadColorAliases(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				panic(err)
+			}
+		}
+	})
+
+	lang = strings.ToLower(lang)
+	if lang == "" {
+		lang = COLOR_ALIAS_DEFAULT_LANGUAGE
+	}
+
+	aliases, ok := COLOR_ALIAS_MAPPING[lang]
+	if !ok {
+		panic(fmt.Errorf("invalid or unsupported: language %s", lang))
+	}
+
+	resolved := make([]string, len(args))
+	for i, arg := range args {
+		key := strings.ToLower(strings.TrimSpace(arg))
+		if color, ok := aliases[key]; ok {
+			resolved[i] = color
+		} else if color, ok := COLOR_ALIAS_MAPPING[COLOR_ALIAS_DEFAULT_LANGUAGE][key]; ok {
+			resolved[i] = color
+		} else {
+			resolved[i] = arg
+		}
+	}
+
+	return resolved
+}
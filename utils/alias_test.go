@@ -0,0 +1,97 @@
+package utils
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+)
+
+// isolate_color_aliases points the user color alias config at a missing file so the tests never load the real one
+func isolate_color_aliases(t *testing.T) {
+	t.Setenv(COLOR_ALIAS_CONFIG_ENV, filepath.Join(t.TempDir(), "colors.json"))
+	load_user_color_aliases_once = sync.Once{}
+	t.Cleanup(func() { load_user_color_aliases_once = sync.Once{} })
+}
+
+func TestResolveColorAliases(t *testing.T) {
+	isolate_color_aliases(t)
+
+	tests := []struct {
+		name     string
+		args     []string
+		lang     string
+		expected []string
+	}{
+		{"english aliases", []string{"Gray", "purple", "red"}, "", []string{"grey", "violet", "red"}},
+		{"digits", []string{"1", "0", "2"}, "en", []string{"brown", "black", "red"}},
+		{"german", []string{"Rot", "weiß", "_"}, "de", []string{"red", "white", "_"}},
+		{"french", []string{"noir", "or"}, "fr", []string{"black", "gold"}},
+		{"spanish", []string{"café", "9"}, "es", []string{"brown", "white"}},
+		{"unknown kept as is", []string{"Magenta"}, "de", []string{"Magenta"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ResolveColorAliases(tt.args, tt.lang); !slices.Equal(got, tt.expected) {
+				t.Errorf("ResolveColorAliases(%v, %s) = %v, expected %v", tt.args, tt.lang, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoadColorAliases(t *testing.T) {
+	isolate_color_aliases(t)
+	path := filepath.Join(t.TempDir(), "colors.json")
+	if err := os.WriteFile(path, []byte(`{"NL": {"Zwart": "black", "bruin": "brown"}, "de": {"dunkelrot": "red"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := LoadColorAliases(path); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	t.Cleanup(func() {
+		delete(COLOR_ALIAS_MAPPING, "nl")
+		delete(COLOR_ALIAS_MAPPING["de"], "dunkelrot")
+	})
+
+	if got := ResolveColorAliases([]string{"zwart", "bruin", "rood"}, "nl"); !slices.Equal(got, []string{"black", "brown", "rood"}) {
+		t.Errorf("unexpected nl aliases %v", got)
+	}
+	if got := ResolveColorAliases([]string{"dunkelrot", "rot"}, "de"); !slices.Equal(got, []string{"red", "red"}) {
+		t.Errorf("unexpected de aliases %v", got)
+	}
+}
+
+func TestLoadColorAliasesErrors(t *testing.T) {
+	dir := t.TempDir()
+
+	unknown := filepath.Join(dir, "unknown.json")
+	os.WriteFile(unknown, []byte(`{"nl": {"zwart": "ink"}}`), 0o644)
+	if err := LoadColorAliases(unknown); err == nil || err.Error() != "invalid: color alias zwart maps to unknown color ink" {
+		t.Errorf("expected unknown color error, got %v", err)
+	}
+
+	partial := filepath.Join(dir, "partial.json")
+	os.WriteFile(partial, []byte(`{"nl": {"zwart": "black", "inkt": "ink"}}`), 0o644)
+	if err := LoadColorAliases(partial); err == nil {
+		t.Error("expected unknown color error")
+	}
+	if _, ok := COLOR_ALIAS_MAPPING["nl"]; ok {
+		t.Error("expected an invalid config to leave the color aliases untouched")
+	}
+
+	malformed := filepath.Join(dir, "malformed.json")
+	os.WriteFile(malformed, []byte(`{"nl": [`), 0o644)
+	if err := LoadColorAliases(malformed); err == nil {
+		t.Error("expected malformed config error")
+	}
+}
+
+func TestGetColorAliasConfigPath(t *testing.T) {
+	t.Setenv(COLOR_ALIAS_CONFIG_ENV, "/tmp/gohm-colors.json")
+	if got := GetColorAliasConfigPath(); got != "/tmp/gohm-colors.json" {
+		t.Errorf("expected env override, got %s", got)
+	}
+}
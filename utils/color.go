@@ -0,0 +1,134 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+type RGB struct {
+	R, G, B uint8
+}
+
+// Lab is a CIELAB (D65) color
+type Lab struct {
+	L, A, B float64
+}
+
+type ColorMatch struct {
+	Name   string
+	DeltaE float64
+}
+
+// d65 reference white
+const (
+	lab_white_x = .95047
+	lab_white_y = 1.
+	lab_white_z = 1.08883
+)
+
+func srgb_to_linear(c uint8) float64 {
+	v := float64(c) / 255
+	if v <= .04045 {
+		return v / 12.92
+	}
+	return math.Pow((v+.055)/1.055, 2.4)
+}
+
+func lab_f(t float64) float64 {
+	if t > 216./24389. {
+		return math.Cbrt(t)
+	}
+	return (24389./27.*t + 16) / 116
+}
+
+// ToLab converts an sRGB color to CIELAB
+func (c RGB) ToLab() Lab {
+	r, g, b := srgb_to_linear(c.R), srgb_to_linear(c.G), srgb_to_linear(c.B)
+
+	x := r*.4124564 + g*.3575761 + b*.1804375
+	y := r*.2126729 + g*.7151522 + b*.0721750
+	z := r*.0193339 + g*.1191920 + b*.9503041
+
+	fx, fy, fz := lab_f(x/lab_white_x), lab_f(y/lab_white_y), lab_f(z/lab_white_z)
+
+	return Lab{
+		L: 116*fy - 16,
+		A: 500 * (fx - fy),
+		B: 200 * (fy - fz),
+	}
+}
+
+// DeltaE is the CIE76 color difference - ~2.3 is a just noticeable difference
+func DeltaE(a, b Lab) float64 {
+	return math.Sqrt((a.L-b.L)*(a.L-b.L) + (a.A-b.A)*(a.A-b.A) + (a.B-b.B)*(a.B-b.B))
+}
+
+// ClassifyColor returns every EIA band color ordered by its ΔE to the given color, nearest first
+func ClassifyColor(lab Lab) []ColorMatch {
+	matches := make([]ColorMatch, 0, len(EIA_COLOR_MAPPING))
+	for name, band := range EIA_COLOR_MAPPING {
+		matches = append(matches, ColorMatch{
+			Name:   name,
+			DeltaE: DeltaE(lab, band.Rgb.ToLab()),
+		})
+	}
+
+	slices.SortFunc(matches, func(a, b ColorMatch) int {
+		if a.DeltaE < b.DeltaE {
+			return -1
+		} else if a.DeltaE > b.DeltaE {
+			return 1
+		}
+		return strings.Compare(a.Name, b.Name)
+	})
+
+	return matches
+}
+
+// IsColorSample reports whether s is written as a hex (#8B4513, #f00) or rgb(255,0,0) color
+func IsColorSample(s string) bool {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.HasPrefix(s, "#") || strings.HasPrefix(s, "rgb(")
+}
+
+// ParseColorSample parses a hex (#8B4513, #f00) or rgb(255,0,0) color
+func ParseColorSample(s string) (RGB, error) {
+	val := strings.ToLower(strings.TrimSpace(s))
+
+	if hex, ok := strings.CutPrefix(val, "#"); ok {
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+
+		n, err := strconv.ParseUint(hex, 16, 32)
+		if len(hex) != 6 || err != nil {
+			return RGB{}, fmt.Errorf("invalid: hex color %s", s)
+		}
+
+		return RGB{R: uint8(n >> 16), G: uint8(n >> 8), B: uint8(n)}, nil
+	}
+
+	if inner, ok := strings.CutPrefix(val, "rgb("); ok {
+		inner, ok = strings.CutSuffix(inner, ")")
+		parts := strings.Split(inner, ",")
+		if !ok || len(parts) != 3 {
+			return RGB{}, fmt.Errorf("invalid: rgb color %s", s)
+		}
+
+		channels := [3]uint8{}
+		for i, part := range parts {
+			n, err := strconv.ParseUint(strings.TrimSpace(part), 10, 8)
+			if err != nil {
+				return RGB{}, fmt.Errorf("invalid: rgb color %s", s)
+			}
+			channels[i] = uint8(n)
+		}
+
+		return RGB{R: channels[0], G: channels[1], B: channels[2]}, nil
+	}
+
+	return RGB{}, fmt.Errorf("invalid: color sample %s", s)
+}
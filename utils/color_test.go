@@ -0,0 +1,84 @@
+package utils
+
+import (
+	"math"
+	"testing"
+)
+
+func TestRGBToLab(t *testing.T) {
+	tests := []struct {
+		name     string
+		rgb      RGB
+		expected Lab
+	}{
+		{"white", RGB{255, 255, 255}, Lab{100, 0, 0}},
+		{"black", RGB{0, 0, 0}, Lab{0, 0, 0}},
+		{"red", RGB{255, 0, 0}, Lab{53.24, 80.09, 67.2}},
+		{"blue", RGB{0, 0, 255}, Lab{32.3, 79.19, -107.86}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.rgb.ToLab(); DeltaE(got, tt.expected) > .05 {
+				t.Errorf("%v.ToLab() = %v, expected %v", tt.rgb, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDeltaE(t *testing.T) {
+	if got := DeltaE(Lab{50, 0, 0}, Lab{53, 4, 0}); math.Abs(got-5) > 1e-9 {
+		t.Errorf("expected ΔE 5, got %v", got)
+	}
+}
+
+func TestClassifyColor(t *testing.T) {
+	for name, band := range EIA_COLOR_MAPPING {
+		matches := ClassifyColor(band.Rgb.ToLab())
+		if matches[0].Name != name || matches[0].DeltaE != 0 {
+			t.Errorf("expected %s to classify as itself, got %v", name, matches[0])
+		}
+		if len(matches) != len(EIA_COLOR_MAPPING) || matches[1].DeltaE < matches[0].DeltaE {
+			t.Errorf("expected every color ordered by ΔE, got %v", matches)
+		}
+	}
+
+	if got := ClassifyColor(RGB{139, 69, 19}.ToLab())[0].Name; got != "brown" {
+		t.Errorf("expected saddle brown to classify as brown, got %s", got)
+	}
+}
+
+func TestParseColorSample(t *testing.T) {
+	tests := []struct {
+		sample   string
+		expected RGB
+	}{
+		{"#8B4513", RGB{139, 69, 19}},
+		{"#8b4513", RGB{139, 69, 19}},
+		{"#f00", RGB{255, 0, 0}},
+		{"rgb(255,0,0)", RGB{255, 0, 0}},
+		{"RGB( 1, 2 , 3 )", RGB{1, 2, 3}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.sample, func(t *testing.T) {
+			if !IsColorSample(tt.sample) {
+				t.Errorf("expected %s to be a color sample", tt.sample)
+			}
+			got, err := ParseColorSample(tt.sample)
+			if err != nil || got != tt.expected {
+				t.Errorf("ParseColorSample(%s) = %v, %v, expected %v", tt.sample, got, err, tt.expected)
+			}
+		})
+	}
+
+	for _, invalid := range []string{"#ff", "#ff00zz", "rgb(1,2,3", "rgb(1,2,3,4)", "rgb(-1,0,0)", "red"} {
+		if _, err := ParseColorSample(invalid); err == nil {
+			t.Errorf("expected ParseColorSample(%s) to fail", invalid)
+		}
+	}
+
+	if IsColorSample("red") {
+		t.Error("expected red not to be a color sample")
+	}
+}
@@ -5,65 +5,115 @@ var EIA_COLOR_MAPPING = map[string]ColorBand{
 		SignificantNumeral: 0,
 		Multiplier:         0,
 		Ansi:               ANSI_BLACK_FG,
+		Rgb:                RGB{35, 35, 35},
 	},
 	"brown": {
 		SignificantNumeral: 1,
 		Multiplier:         1,
 		Ansi:               ANSI_BROWN_FG,
+		Rgb:                RGB{120, 72, 40},
 	},
 	"red": {
 		SignificantNumeral: 2,
 		Multiplier:         2,
 		Ansi:               ANSI_RED_BRIGHT_FG,
+		Rgb:                RGB{200, 35, 35},
 	},
 	"orange": {
 		SignificantNumeral: 3,
 		Multiplier:         3,
 		Ansi:               ANSI_ORANGE_FG,
+		Rgb:                RGB{235, 125, 35},
 	},
 	"yellow": {
 		SignificantNumeral: 4,
 		Multiplier:         4,
 		Ansi:               ANSI_YELLOW_BRIGHT_FG,
+		Rgb:                RGB{235, 205, 45},
 	},
 	"green": {
 		SignificantNumeral: 5,
 		Multiplier:         5,
 		Ansi:               ANSI_GREEN_BRIGHT_FG,
+		Rgb:                RGB{45, 140, 65},
 	},
 	"blue": {
 		SignificantNumeral: 6,
 		Multiplier:         6,
 		Ansi:               ANSI_BLUE_BRIGHT_FG,
+		Rgb:                RGB{45, 85, 185},
 	},
 	"violet": {
 		SignificantNumeral: 7,
 		Multiplier:         7,
 		Ansi:               ANSI_VIOLET_FG,
+		Rgb:                RGB{135, 65, 165},
 	},
 	"grey": {
 		SignificantNumeral: 8,
 		Multiplier:         8,
 		Ansi:               ANSI_GRAY_FG,
+		Rgb:                RGB{130, 130, 130},
 	},
 	"white": {
 		SignificantNumeral: 9,
 		Multiplier:         9,
 		Ansi:               ANSI_WHITE_BRIGHT_FG,
+		Rgb:                RGB{238, 238, 238},
 	},
 	"gold": {
 		SignificantNumeral: -1,
 		Multiplier:         -1,
 		Ansi:               ANSI_GOLD_FG,
+		Rgb:                RGB{185, 150, 70},
 	},
 	"silver": {
 		SignificantNumeral: -1,
 		Multiplier:         -2,
-		Ansi:               ANSI_GOLD_FG,
+		Ansi:               ANSI_SILVER_FG,
+		Rgb:                RGB{192, 192, 198},
 	},
 	"pink": {
 		SignificantNumeral: -1,
 		Multiplier:         -3,
 		Ansi:               ANSI_PINK_FG,
+		Rgb:                RGB{240, 145, 175},
 	},
 }
+
+// EIA_198_MAPPING maps the EIA-198 letter of a 2 character capacitor code to its mantissa in pF
+var EIA_198_MAPPING = map[byte]float64{
+	'A': 1,
+	'B': 1.1,
+	'C': 1.2,
+	'D': 1.3,
+	'E': 1.5,
+	'F': 1.6,
+	'G': 1.8,
+	'H': 2,
+	'J': 2.2,
+	'K': 2.4,
+	'L': 2.7,
+	'M': 3,
+	'N': 3.3,
+	'P': 3.6,
+	'Q': 3.9,
+	'R': 4.3,
+	'S': 4.7,
+	'T': 5.1,
+	'U': 5.6,
+	'V': 6.2,
+	'W': 6.8,
+	'X': 7.5,
+	'Y': 8.2,
+	'Z': 9.1,
+	'a': 2.6,
+	'b': 3.5,
+	'd': 4,
+	'e': 4.5,
+	'f': 5,
+	'm': 6,
+	'n': 7,
+	't': 8,
+	'y': 9,
+}
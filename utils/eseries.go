@@ -0,0 +1,157 @@
+package utils
+
+import (
+	"math"
+	"slices"
+	"strconv"
+)
+
+// E6 holds the significant figures of the IEC 60063 E6 (±20%) preferred number series
+var E6 = []float64{10, 15, 22, 33, 47, 68}
+
+// E12 holds the significant figures of the IEC 60063 E12 (±10%) preferred number series
+var E12 = []float64{10, 12, 15, 18, 22, 27, 33, 39, 47, 56, 68, 82}
+
+// E24 holds the significant figures of the IEC 60063 E24 (±5%) preferred number series
+var E24 = []float64{
+	10, 11, 12, 13, 15, 16, 18, 20, 22, 24, 27, 30,
+	33, 36, 39, 43, 47, 51, 56, 62, 68, 75, 82, 91,
+}
+
+// E48 holds the significant figures of the IEC 60063 E48 (±2%) preferred number series
+var E48 = []float64{
+	100, 105, 110, 115, 121, 127, 133, 140, 147, 154, 162, 169,
+	178, 187, 196, 205, 215, 226, 237, 249, 261, 274, 287, 301,
+	316, 332, 348, 365, 383, 402, 422, 442, 464, 487, 511, 536,
+	562, 590, 619, 649, 681, 715, 750, 787, 825, 866, 909, 953,
+}
+
+// E96 holds the significant figures of the IEC 60063 E96 (±1%) preferred number series
+var E96 = []float64{
+	100, 102, 105, 107, 110, 113, 115, 118, 121, 124, 127, 130,
+	133, 137, 140, 143, 147, 150, 154, 158, 162, 165, 169, 174,
+	178, 182, 187, 191, 196, 200, 205, 210, 215, 221, 226, 232,
+	237, 243, 249, 255, 261, 267, 274, 280, 287, 294, 301, 309,
+	316, 324, 332, 340, 348, 357, 365, 374, 383, 392, 402, 412,
+	422, 432, 442, 453, 464, 475, 487, 499, 511, 523, 536, 549,
+	562, 576, 590, 604, 619, 634, 649, 665, 681, 698, 715, 732,
+	750, 768, 787, 806, 825, 845, 866, 887, 909, 931, 953, 976,
+}
+
+// E192 holds the significant figures of the IEC 60063 E192 (±0.5% and better) preferred number series
+var E192 = []float64{
+	100, 101, 102, 104, 105, 106, 107, 109, 110, 111, 113, 114,
+	115, 117, 118, 120, 121, 123, 124, 126, 127, 129, 130, 132,
+	133, 135, 137, 138, 140, 142, 143, 145, 147, 149, 150, 152,
+	154, 156, 158, 160, 162, 164, 165, 167, 169, 172, 174, 176,
+	178, 180, 182, 184, 187, 189, 191, 193, 196, 198, 200, 203,
+	205, 208, 210, 213, 215, 218, 221, 223, 226, 229, 232, 234,
+	237, 240, 243, 246, 249, 252, 255, 258, 261, 264, 267, 271,
+	274, 277, 280, 284, 287, 291, 294, 298, 301, 305, 309, 312,
+	316, 320, 324, 328, 332, 336, 340, 344, 348, 352, 357, 361,
+	365, 370, 374, 379, 383, 388, 392, 397, 402, 407, 412, 417,
+	422, 427, 432, 437, 442, 448, 453, 459, 464, 470, 475, 481,
+	487, 493, 499, 505, 511, 517, 523, 530, 536, 542, 549, 556,
+	562, 569, 576, 583, 590, 597, 604, 612, 619, 626, 634, 642,
+	649, 657, 665, 673, 681, 690, 698, 706, 715, 723, 732, 741,
+	750, 759, 768, 777, 787, 796, 806, 816, 825, 835, 845, 856,
+	866, 876, 887, 898, 909, 920, 931, 942, 953, 965, 976, 988,
+}
+
+var E_SERIES_MAPPING = map[string][]float64{
+	"E6":   E6,
+	"E12":  E12,
+	"E24":  E24,
+	"E48":  E48,
+	"E96":  E96,
+	"E192": E192,
+}
+
+// GetESeriesForTolerance returns the name of the coarsest E series that a part with the given tolerance (%) is manufactured in
+func GetESeriesForTolerance(tolerance float64) string {
+	switch {
+	case tolerance <= .5:
+		return "E192"
+	case tolerance <= 1:
+		return "E96"
+	case tolerance <= 2:
+		return "E48"
+	case tolerance <= 5:
+		return "E24"
+	case tolerance <= 10:
+		return "E12"
+	default:
+		return "E6"
+	}
+}
+
+// GetToleranceForESeries returns the tolerance (%) the parts of the named E series are manufactured to - 0.5% for E192,
+// the tightest it is specified for
+func GetToleranceForESeries(series string) float64 {
+	switch series {
+	case "E192":
+		return .5
+	case "E96":
+		return 1
+	case "E48":
+		return 2
+	case "E24":
+		return 5
+	case "E12":
+		return 10
+	default:
+		return 20
+	}
+}
+
+// IsInESeries reports whether the significant figures of val are a member of the given E series
+func IsInESeries(val float64, series []float64) bool {
+	if val <= 0 || math.IsInf(val, 0) || math.IsNaN(val) {
+		return false
+	}
+
+	figures := 2
+	if series[0] >= 100 {
+		figures = 3
+	}
+
+	normalized := val / math.Pow10(int(math.Floor(math.Log10(val)))-(figures-1))
+	rounded := math.Round(normalized)
+	if math.Abs(normalized-rounded) > 1e-6 {
+		return false
+	}
+
+	// float noise can push i.e. 1000 to 999.9999999 and into the previous decade
+	if rounded == math.Pow10(figures) {
+		rounded = math.Pow10(figures - 1)
+	}
+
+	return slices.Contains(series, rounded)
+}
+
+// GetNearestESeriesValue returns the member of the given E series closest to val by ratio, which is how the series are
+// spaced - the value of the next decade is a candidate as well, i.e. 9.9k snaps to 10k in E12
+func GetNearestESeriesValue(val float64, series []float64) float64 {
+	if val <= 0 || math.IsInf(val, 0) || math.IsNaN(val) {
+		return val
+	}
+
+	figures := 2
+	if series[0] >= 100 {
+		figures = 3
+	}
+
+	pow10 := math.Pow10(int(math.Floor(math.Log10(val))) - (figures - 1))
+	normalized := val / pow10
+
+	nearest, nearest_distance := 0., math.Inf(1)
+	for _, s := range append(slices.Clone(series), math.Pow10(figures)) {
+		if distance := math.Abs(math.Log(normalized / s)); distance < nearest_distance {
+			nearest, nearest_distance = s, distance
+		}
+	}
+
+	// rounded to the figures of the series to drop float noise, i.e. 47 * 1e-7
+	rounded, _ := strconv.ParseFloat(strconv.FormatFloat(nearest*pow10, 'g', figures, 64), 64)
+	return rounded
+}
@@ -0,0 +1,84 @@
+package utils
+
+import "testing"
+
+func TestIsInESeries(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		series   string
+		expected bool
+	}{
+		{"E12 value", 4700, "E12", true},
+		{"E12 sub 1", .47, "E12", true},
+		{"not in E12", 5100, "E12", false},
+		{"E24 value", 5100, "E24", true},
+		{"E96 value", 10200, "E96", true},
+		{"not in E96", 47, "E96", false},
+		{"E192 value", 101, "E192", true},
+		{"zero", 0, "E6", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsInESeries(tt.value, E_SERIES_MAPPING[tt.series]); got != tt.expected {
+				t.Errorf("IsInESeries(%v, %s) = %v, expected %v", tt.value, tt.series, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetESeriesForTolerance(t *testing.T) {
+	tests := []struct {
+		tolerance float64
+		expected  string
+	}{
+		{.1, "E192"},
+		{1, "E96"},
+		{2, "E48"},
+		{5, "E24"},
+		{10, "E12"},
+		{20, "E6"},
+	}
+
+	for _, tt := range tests {
+		if got := GetESeriesForTolerance(tt.tolerance); got != tt.expected {
+			t.Errorf("GetESeriesForTolerance(%v) = %s, expected %s", tt.tolerance, got, tt.expected)
+		}
+	}
+}
+
+func TestGetToleranceForESeries(t *testing.T) {
+	for series := range E_SERIES_MAPPING {
+		tolerance := GetToleranceForESeries(series)
+		if got := GetESeriesForTolerance(tolerance); got != series {
+			t.Errorf("GetESeriesForTolerance(GetToleranceForESeries(%s)) = %s, expected %s", series, got, series)
+		}
+	}
+}
+
+func TestGetNearestESeriesValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    float64
+		series   string
+		expected float64
+	}{
+		{"member", 4700, "E12", 4700},
+		{"between members", 5000, "E12", 4700},
+		{"by ratio", 4300, "E12", 4700},
+		{"next decade", 9900, "E12", 10000},
+		{"sub 1", .0051, "E24", .0051},
+		{"capacitance", 4.6e-6, "E6", 4.7e-6},
+		{"E96", 12345, "E96", 12400},
+		{"E192", 1234, "E192", 1230},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetNearestESeriesValue(tt.value, E_SERIES_MAPPING[tt.series]); got != tt.expected {
+				t.Errorf("GetNearestESeriesValue(%v, %s) = %v, expected %v", tt.value, tt.series, got, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,63 @@
+package utils
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// packages.json holds the body dimensions (mm), typical ratings & recommended land pattern of common passive &
+// discrete packages. The power rating of chip & MELF packages is the typical rating of a thick film resistor at 70°C
+// and of discrete packages the typical dissipation on a minimal land pattern at 25°C - the voltage rating is the
+// typical resistor working voltage, 0 for packages without one. Land patterns follow IPC-7351 nominal density
+//
+//go:embed packages.json
+var packages_json []byte
+
+type LandPattern struct {
+	Pads      int     `json:"pads"`
+	PadLength float64 `json:"padLength"` // mm, along the lead
+	PadWidth  float64 `json:"padWidth"`  // mm, across the lead
+	Pitch     float64 `json:"pitch"`     // mm between adjacent pads in a row, 0 for 2 pad packages
+	Span      float64 `json:"span"`      // mm between the centers of opposite pads
+}
+
+type Package struct {
+	Name        string      `json:"name"`
+	Aliases     []string    `json:"aliases"`
+	Type        string      `json:"type"`
+	Length      float64     `json:"length"`
+	Width       float64     `json:"width"`
+	Height      float64     `json:"height"`
+	Power       float64     `json:"power"`
+	Voltage     float64     `json:"voltage"`
+	LandPattern LandPattern `json:"landPattern"`
+}
+
+var PACKAGES []*Package
+
+func init() {
+	if err := json.Unmarshal(packages_json, &PACKAGES); err != nil {
+		panic(fmt.Errorf("invalid: packages: %w", err))
+	}
+}
+
+// GetPackage looks up a package by its name or an alias - case & whitespace insensitive. Metric chip sizes are
+// aliased with an M suffix (i.e. 1608M) as 0402 & 0603 are both an imperial & a metric size
+func GetPackage(name string) (*Package, bool) {
+	name = strings.Join(strings.Fields(name), "")
+
+	for _, p := range PACKAGES {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+		for _, alias := range p.Aliases {
+			if strings.EqualFold(alias, name) {
+				return p, true
+			}
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,50 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGetPackage(t *testing.T) {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{"0603", "0603"},
+		{"1608M", "0603"},
+		{"1608m", "0603"},
+		{"sot-23", "SOT-23"},
+		{"TO-236", "SOT-23"},
+		{" SOT-223 ", "SOT-223"},
+		{"DO-214AC", "SMA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, ok := GetPackage(tt.name)
+			if !ok || p.Name != tt.expected {
+				t.Errorf("GetPackage(%q) = %v, %v, expected %s", tt.name, p, ok, tt.expected)
+			}
+		})
+	}
+
+	if p, ok := GetPackage("0608"); ok {
+		t.Errorf("GetPackage(%q) = %v, expected no package", "0608", p)
+	}
+}
+
+func TestPackagesAreUnique(t *testing.T) {
+	seen := map[string]string{}
+	for _, p := range PACKAGES {
+		for _, name := range append([]string{p.Name}, p.Aliases...) {
+			if other, ok := seen[strings.ToUpper(name)]; ok {
+				t.Errorf("%s of %s is also a name or alias of %s", name, p.Name, other)
+			}
+			seen[strings.ToUpper(name)] = p.Name
+		}
+
+		if p.Power <= 0 || p.LandPattern.Pads < 2 || p.LandPattern.Span <= 0 {
+			t.Errorf("%s has no power rating or land pattern", p.Name)
+		}
+	}
+}
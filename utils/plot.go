@@ -0,0 +1,202 @@
+package utils
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// PlotAxis is an axis of a plot - a log axis spaces decades evenly, i.e. the frequency of a Bode plot. A plain axis
+// is labeled without SI prefixes, i.e. dB
+type PlotAxis struct {
+	Label string
+	Unit  string
+	Log   bool
+	Plain bool
+}
+
+// PlotSeries is a curve of a plot, sampled at the x values of the plot
+type PlotSeries struct {
+	Label  string
+	Values []float64
+}
+
+var plot_markers = []rune{'*', '+', 'o', 'x', '#', '@'}
+
+// Plot draws the series as an ASCII chart of width x height characters - x is ascending & the series are interpolated
+// between its values. The y axis is labeled at its top, middle & bottom, the x axis at its ends & middle
+func Plot(x []float64, x_axis PlotAxis, y_axis PlotAxis, series []PlotSeries, width int, height int) string {
+	transform := func(v float64) float64 {
+		if x_axis.Log {
+			return math.Log10(v)
+		}
+		return v
+	}
+
+	x_min, x_max := transform(x[0]), transform(x[len(x)-1])
+	if x_max == x_min {
+		x_max = x_min + 1
+	}
+
+	y_min, y_max := math.Inf(1), math.Inf(-1)
+	for _, s := range series {
+		for _, v := range s.Values {
+			if !math.IsNaN(v) && !math.IsInf(v, 0) {
+				y_min, y_max = min(y_min, v), max(y_max, v)
+			}
+		}
+	}
+	if math.IsInf(y_min, 1) {
+		y_min, y_max = 0, 0
+	}
+	if y_max == y_min {
+		pad := math.Abs(y_min) * .1
+		if pad == 0 {
+			pad = 1
+		}
+		y_min, y_max = y_min-pad, y_max+pad
+	}
+
+	grid := make([][]rune, height)
+	for i := range grid {
+		grid[i] = []rune(strings.Repeat(" ", width))
+	}
+
+	for i, s := range series {
+		marker := plot_markers[i%len(plot_markers)]
+		previous := -1
+
+		for col := range width {
+			v, ok := interpolate_plot(x, s.Values, x_min+(x_max-x_min)*float64(col)/float64(max(width-1, 1)), transform)
+			if !ok {
+				previous = -1
+				continue
+			}
+
+			row := int(math.Round((y_max - v) / (y_max - y_min) * float64(height-1)))
+
+			// steep edges are drawn as a vertical line so a step doesn't leave a gap
+			from := row
+			if previous != -1 {
+				from = previous + int(math.Copysign(1, float64(row-previous)))
+				if previous == row {
+					from = row
+				}
+			}
+			for r := min(from, row); r <= max(from, row); r++ {
+				grid[r][col] = marker
+			}
+			previous = row
+		}
+	}
+
+	labels := map[int]string{
+		0:                format_plot_value(y_max, y_max-y_min, y_axis),
+		(height - 1) / 2: format_plot_value(y_max-(y_max-y_min)*float64((height-1)/2)/float64(height-1), y_max-y_min, y_axis),
+		height - 1:       format_plot_value(y_min, y_max-y_min, y_axis),
+	}
+	label_width := 0
+	for _, l := range labels {
+		label_width = max(label_width, len([]rune(l)))
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, "%s vs %s", plot_axis_title(y_axis), plot_axis_title(x_axis))
+	if len(series) > 1 {
+		for i, s := range series {
+			fmt.Fprintf(&sb, "  %c %s", plot_markers[i%len(plot_markers)], s.Label)
+		}
+	}
+
+	for i, row := range grid {
+		label, labeled := labels[i]
+		fmt.Fprintf(&sb, "\n%s%s %s%s",
+			strings.Repeat(" ", label_width-len([]rune(label))),
+			label,
+			If(labeled, "┤", "│"),
+			strings.TrimRight(string(row), " "),
+		)
+	}
+	fmt.Fprintf(&sb, "\n%s └%s", strings.Repeat(" ", label_width), strings.Repeat("─", width))
+
+	inverse := func(v float64) float64 {
+		if x_axis.Log {
+			return math.Pow(10, v)
+		}
+		return v
+	}
+	// a log axis is labeled to the resolution of each value, a linear axis to that of its range
+	span := func(v float64) float64 {
+		return If(x_axis.Log, v, inverse(x_max)-inverse(x_min))
+	}
+	left := format_plot_value(inverse(x_min), span(inverse(x_min)), x_axis)
+	middle := format_plot_value(inverse((x_min+x_max)/2), span(inverse((x_min+x_max)/2)), x_axis)
+	right := format_plot_value(inverse(x_max), span(inverse(x_max)), x_axis)
+
+	// the right label ends at the end of the axis & the middle label is left out when there is no room for it
+	right_start := max(width-len([]rune(right)), len([]rune(left))+1)
+	middle_start := width/2 - len([]rune(middle))/2
+
+	axis := []rune(strings.Repeat(" ", right_start+len([]rune(right))))
+	copy(axis, []rune(left))
+	if middle_start > len([]rune(left)) && middle_start+len([]rune(middle)) < right_start {
+		copy(axis[middle_start:], []rune(middle))
+	}
+	copy(axis[right_start:], []rune(right))
+	fmt.Fprintf(&sb, "\n%s  %s", strings.Repeat(" ", label_width), strings.TrimRight(string(axis), " "))
+
+	return sb.String()
+}
+
+// interpolate_plot returns the value of the series at the transformed x position t
+func interpolate_plot(x []float64, values []float64, t float64, transform func(float64) float64) (float64, bool) {
+	for i := 0; i < len(x)-1 && i < len(values)-1; i++ {
+		x0, x1 := transform(x[i]), transform(x[i+1])
+		if t < x0 || t > x1 {
+			continue
+		}
+
+		v := values[i]
+		if x1 != x0 {
+			v += (values[i+1] - values[i]) * (t - x0) / (x1 - x0)
+		}
+		return v, !math.IsNaN(v) && !math.IsInf(v, 0)
+	}
+
+	if len(values) == 1 {
+		return values[0], true
+	}
+	return 0, false
+}
+
+// format_plot_value abbreviates an axis label rounded to a resolution of a hundredth of the range of the axis
+func format_plot_value(v float64, span float64, axis PlotAxis) string {
+	resolution := math.Pow10(int(math.Floor(math.Log10(span))) - 2)
+	v = math.Round(v/resolution) * resolution
+	if v == 0 {
+		// no -0
+		v = 0
+	}
+
+	if axis.Plain {
+		return strconv.FormatFloat(v, 'g', 6, 64) + axis.Unit
+	}
+
+	// the division by the SI prefix leaves float noise, i.e. 3.0500000000000003m
+	abbreviated := GetAbbreviatedValue(v)
+	i := strings.LastIndexAny(abbreviated, "0123456789") + 1
+	if n, err := strconv.ParseFloat(abbreviated[:i], 64); err == nil {
+		abbreviated = strconv.FormatFloat(n, 'g', 6, 64) + abbreviated[i:]
+	}
+
+	return abbreviated + axis.Unit
+}
+
+func plot_axis_title(axis PlotAxis) string {
+	if axis.Unit == "" {
+		return axis.Label
+	}
+	return fmt.Sprintf("%s (%s)", axis.Label, axis.Unit)
+}
@@ -0,0 +1,57 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPlot(t *testing.T) {
+	x := []float64{0, 1, 2, 3}
+	got := Plot(x, PlotAxis{Label: "time", Unit: "s"}, PlotAxis{Label: "voltage", Unit: "V"}, []PlotSeries{{Label: "out", Values: []float64{0, 1, 2, 3}}}, 4, 4)
+
+	expected := "voltage (V) vs time (s)\n" +
+		"3V ┤   *\n" +
+		"2V ┤  *\n" +
+		"   │ *\n" +
+		"0V ┤*\n" +
+		"   └────\n" +
+		"    0s 3s"
+	if got != expected {
+		t.Errorf("Plot() =\n%s\nexpected\n%s", got, expected)
+	}
+}
+
+func TestPlotSteps(t *testing.T) {
+	// a step is drawn as a vertical edge
+	x := []float64{0, 1, 1, 2}
+	got := Plot(x, PlotAxis{Label: "time", Unit: "s"}, PlotAxis{Label: "voltage", Unit: "V"}, []PlotSeries{{Label: "out", Values: []float64{0, 0, 5, 5}}}, 5, 3)
+
+	rows := strings.Split(got, "\n")
+	if !strings.HasSuffix(rows[1], "┤   **") || !strings.HasSuffix(rows[2], "┤   *") || !strings.HasSuffix(rows[3], "┤***") {
+		t.Errorf("expected a vertical edge, got\n%s", got)
+	}
+}
+
+func TestPlotLegendAndLogAxis(t *testing.T) {
+	x := []float64{10, 100, 1000}
+	got := Plot(x, PlotAxis{Label: "frequency", Unit: "Hz", Log: true}, PlotAxis{Label: "gain", Unit: "dB", Plain: true}, []PlotSeries{
+		{Label: "a", Values: []float64{0, -20, -40}},
+		{Label: "b", Values: []float64{-0.5, -0.5, -0.5}},
+	}, 21, 5)
+
+	for _, s := range []string{"gain (dB) vs frequency (Hz)  * a  + b", "0dB ┤", "-40dB ┤", "10Hz", "100Hz", "1kHz"} {
+		if !strings.Contains(got, s) {
+			t.Errorf("expected plot to contain %q, got\n%s", s, got)
+		}
+	}
+}
+
+func TestPlotFlatSeries(t *testing.T) {
+	got := Plot([]float64{1, 2}, PlotAxis{Label: "x"}, PlotAxis{Label: "y", Unit: "A"}, []PlotSeries{{Values: []float64{2e-3, 2e-3}}}, 4, 3)
+
+	for _, s := range []string{"2.2mA ┤", "2mA ┤****", "1.8mA ┤"} {
+		if !strings.Contains(got, s) {
+			t.Errorf("expected plot to contain %q, got\n%s", s, got)
+		}
+	}
+}
@@ -20,4 +20,11 @@ var RKM_MAPPING = map[rune]map[rune]SIPrefix{
 		'G': SI_MAPPING[abbrvs.SI_GIGA],
 		'T': SI_MAPPING[abbrvs.SI_TERA],
 	},
+	abbrvs.RKM_HENRY: { // inductor | henry - R marks the decimal point like H, i.e. 4R7 is 4.7H
+		'R': {Name: "", Pow10: 1},
+		'p': SI_MAPPING[abbrvs.SI_PICO],
+		'n': SI_MAPPING[abbrvs.SI_NANO],
+		'μ': SI_MAPPING[abbrvs.SI_MICRO],
+		'm': SI_MAPPING[abbrvs.SI_MILLI],
+	},
 }
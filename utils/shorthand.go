@@ -2,6 +2,7 @@ package utils
 
 import (
 	"fmt"
+	"gohm/abbrvs"
 	"strconv"
 	"strings"
 )
@@ -83,6 +84,11 @@ func ParseRKMCode(val string, target rune) (float64, error) {
 			return 0., fmt.Errorf("invalid or unsupported: prefix %s for target %s", string(prefix_rune), string(target))
 		}
 		pow10 = code_letter.Pow10
+
+		// an inductance may carry its unit after a prefix letter, i.e. 4μ7H
+		if target == abbrvs.RKM_HENRY {
+			val = strings.TrimSuffix(val, string(target))
+		}
 	}
 
 	replaced := strings.Replace(val, string(prefix_rune), ".", 1)
@@ -147,6 +147,14 @@ func TestParseRKMCode(t *testing.T) {
 		{"micro single leading digit", "4μ7", 'F', 4.7e-6},
 		{"micro two leading digits", "47μ", 'F', 47e-6},
 
+		// Inductance (H target) - H & R mark the decimal point, the unit may follow a prefix
+		{"henry H decimal point", "4H7", 'H', 4.7},
+		{"henry R decimal point", "4R7", 'H', 4.7},
+		{"henry micro", "4μ7", 'H', 4.7e-6},
+		{"henry micro with unit", "4μ7H", 'H', 4.7e-6},
+		{"henry nano with unit", "10nH", 'H', 10e-9},
+		{"henry milli", "2m2", 'H', 2.2e-3},
+
 		// Edge cases - minimum length (2 chars)
 		{"min length R prefix", "1R", 'R', 1},
 		{"min length K prefix", "1K", 'R', 1000},
@@ -182,6 +190,13 @@ func TestParseRKMCodeErrors(t *testing.T) {
 		{"invalid prefix for resistance", "4p7", 'R', "invalid or unsupported: prefix p for target R"},
 		{"invalid prefix for resistance - lowercase k", "4k7", 'R', "invalid or unsupported: prefix k for target R"},
 		{"invalid prefix X", "4X7", 'R', "invalid or unsupported: prefix X for target R"},
+		{"invalid prefix for inductance", "4M7", 'H', "invalid or unsupported: prefix M for target H"},
+		{"no kilo prefix for inductance", "4K7", 'H', "invalid or unsupported: prefix K for target H"},
+		{"no L prefix for inductance", "4L7", 'H', "invalid or unsupported: prefix L for target H"},
+
+		// Only an inductance may carry its unit after a prefix letter
+		{"unit after a resistance prefix", "4K7R", 'R', "invalid syntax"},
+		{"unit after a capacitance prefix", "4n7F", 'F', "invalid syntax"},
 	}
 
 	for _, tt := range tests {
@@ -44,9 +44,14 @@ type ColorBand struct {
 	SignificantNumeral int
 	Multiplier         int
 	Ansi               string
+	Rgb                RGB // typical sRGB appearance of the band paint in a daylight photo
 }
 
 func GetAbbreviatedValue(val float64) string {
+	if val < 0 {
+		return "-" + GetAbbreviatedValue(-val)
+	}
+
 	for i := range si_prefixes_positive_base10 {
 		pow10 := math.Pow10(30 - (i * 3))
 		if val >= pow10 {